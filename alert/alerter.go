@@ -0,0 +1,12 @@
+// Package alert pages an on-call responder about critical failures that a
+// Slack message alone is too easy to miss out-of-hours, via PagerDuty or
+// Opsgenie.
+package alert
+
+import "context"
+
+// Alerter pages an on-call responder about event. Implemented by
+// PagerDutyClient and OpsgenieClient.
+type Alerter interface {
+	Trigger(ctx context.Context, event Event) error
+}
@@ -0,0 +1,23 @@
+package alert
+
+// Severity maps an Event onto the receiving paging system's own severity or
+// priority scale.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// Event describes a single alertable condition. DedupKey lets the same
+// underlying problem (e.g. a specific upload's publish failure) be reported
+// repeatedly without opening a new incident each time.
+type Event struct {
+	Summary  string
+	Source   string
+	Severity Severity
+	DedupKey string
+	Details  map[string]string
+}
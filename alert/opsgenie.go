@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// opsgenieAlertsURL is Opsgenie's Alert API endpoint.
+const opsgenieAlertsURL = "https://api.opsgenie.com/v2/alerts"
+
+// opsgeniePriority maps a Severity onto Opsgenie's P1 (highest) to P5
+// (lowest) priority scale.
+var opsgeniePriority = map[Severity]string{
+	SeverityCritical: "P1",
+	SeverityError:    "P2",
+	SeverityWarning:  "P3",
+	SeverityInfo:     "P5",
+}
+
+// OpsgenieClient creates Opsgenie alerts via the Alert API.
+type OpsgenieClient struct {
+	APIKey     string
+	HTTPClient *http.Client
+	// AlertsURL overrides the Opsgenie Alert API endpoint; tests point it at
+	// a local server instead of opsgenieAlertsURL.
+	AlertsURL string
+}
+
+// NewOpsgenie constructs an OpsgenieClient using http.DefaultClient.
+func NewOpsgenie(apiKey string) *OpsgenieClient {
+	return &OpsgenieClient{APIKey: apiKey, HTTPClient: http.DefaultClient, AlertsURL: opsgenieAlertsURL}
+}
+
+type opsgeniePayload struct {
+	Message  string            `json:"message"`
+	Alias    string            `json:"alias,omitempty"`
+	Source   string            `json:"source,omitempty"`
+	Priority string            `json:"priority,omitempty"`
+	Details  map[string]string `json:"details,omitempty"`
+}
+
+// Trigger creates an Opsgenie alert for event. Events sharing a DedupKey are
+// deduplicated by Opsgenie via the alert's alias.
+func (c *OpsgenieClient) Trigger(ctx context.Context, event Event) error {
+	body, err := json.Marshal(opsgeniePayload{
+		Message:  event.Summary,
+		Alias:    event.DedupKey,
+		Source:   event.Source,
+		Priority: opsgeniePriority[event.Severity],
+		Details:  event.Details,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode opsgenie alert: %w", err)
+	}
+
+	url := c.AlertsURL
+	if url == "" {
+		url = opsgenieAlertsURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post opsgenie alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("opsgenie alerts api returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
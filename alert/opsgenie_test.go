@@ -0,0 +1,48 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/alert"
+)
+
+func TestOpsgenieClient_Trigger(t *testing.T) {
+	t.Run("maps severity to a priority and sends the alias as the dedup key", func(t *testing.T) {
+		var received map[string]interface{}
+		var authHeader string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := alert.NewOpsgenie("api-key-123")
+		client.HTTPClient = server.Client()
+		client.AlertsURL = server.URL
+
+		err := client.Trigger(context.Background(), alert.Event{
+			Summary:  "mongo unavailable",
+			Source:   "dis-imf-uploader",
+			Severity: alert.SeverityCritical,
+			DedupKey: "store-failure",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if authHeader != "GenieKey api-key-123" {
+			t.Fatalf("expected GenieKey auth header, got %q", authHeader)
+		}
+		if received["priority"] != "P1" {
+			t.Fatalf("expected priority P1 for critical severity, got %v", received["priority"])
+		}
+		if received["alias"] != "store-failure" {
+			t.Fatalf("expected alias to carry the dedup key, got %v", received["alias"])
+		}
+	})
+}
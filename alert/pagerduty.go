@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// pagerDutyEventsURL is PagerDuty's Events API v2 endpoint.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyClient triggers PagerDuty incidents via the Events API v2.
+type PagerDutyClient struct {
+	RoutingKey string
+	HTTPClient *http.Client
+	// EventsURL overrides the PagerDuty Events API endpoint; tests point it
+	// at a local server instead of pagerDutyEventsURL.
+	EventsURL string
+}
+
+// New constructs a PagerDutyClient posting with the given integration
+// routing key, using http.DefaultClient.
+func New(routingKey string) *PagerDutyClient {
+	return &PagerDutyClient{RoutingKey: routingKey, HTTPClient: http.DefaultClient, EventsURL: pagerDutyEventsURL}
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key,omitempty"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+// Trigger opens or updates a PagerDuty incident for event. Events sharing a
+// DedupKey are coalesced by PagerDuty into the same incident.
+func (c *PagerDutyClient) Trigger(ctx context.Context, event Event) error {
+	body, err := json.Marshal(pagerDutyEvent{
+		RoutingKey:  c.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    event.DedupKey,
+		Payload: pagerDutyPayload{
+			Summary:       event.Summary,
+			Source:        event.Source,
+			Severity:      event.Severity,
+			CustomDetails: event.Details,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode pagerduty event: %w", err)
+	}
+
+	url := c.EventsURL
+	if url == "" {
+		url = pagerDutyEventsURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build pagerduty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post pagerduty event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pagerduty events api returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/alert"
+)
+
+func TestPagerDutyClient_Trigger(t *testing.T) {
+	t.Run("posts the routing key, dedup key and severity", func(t *testing.T) {
+		var received map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := alert.New("routing-key-123")
+		client.HTTPClient = server.Client()
+		client.EventsURL = server.URL
+
+		err := client.Trigger(context.Background(), alert.Event{
+			Summary:  "s3 publish failed",
+			Source:   "dis-imf-uploader",
+			Severity: alert.SeverityCritical,
+			DedupKey: "publish-failure:1",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if received["routing_key"] != "routing-key-123" {
+			t.Fatalf("expected routing key to be sent, got %v", received["routing_key"])
+		}
+		if received["dedup_key"] != "publish-failure:1" {
+			t.Fatalf("expected dedup key to be sent, got %v", received["dedup_key"])
+		}
+		payload, _ := received["payload"].(map[string]interface{})
+		if payload["severity"] != string(alert.SeverityCritical) {
+			t.Fatalf("expected severity critical, got %v", payload["severity"])
+		}
+	})
+
+	t.Run("non-2xx response is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := alert.New("routing-key-123")
+		client.HTTPClient = server.Client()
+		client.EventsURL = server.URL
+
+		if err := client.Trigger(context.Background(), alert.Event{Summary: "boom"}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
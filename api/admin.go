@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// tempStorageObject is the JSON representation of a temp.ObjectInfo.
+type tempStorageObject struct {
+	Key       string `json:"key"`
+	SizeBytes int64  `json:"size_bytes"`
+	AgeSecs   int64  `json:"age_seconds"`
+}
+
+// ListTempStorage handles GET /api/v1/admin/temp-storage, listing the keys,
+// sizes and ages of everything currently held in temp storage, for
+// operators diagnosing storage pressure.
+func (h *Handler) ListTempStorage(w http.ResponseWriter, r *http.Request) {
+	lister, ok := h.Temp.(temp.Lister)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "configured temp storage backend does not support listing")
+		return
+	}
+
+	objects, err := lister.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list temp storage")
+		return
+	}
+
+	response := make([]tempStorageObject, 0, len(objects))
+	for _, o := range objects {
+		response = append(response, tempStorageObject{Key: o.Key, SizeBytes: o.Size, AgeSecs: int64(o.Age.Seconds())})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteTempStorageObject handles DELETE /api/v1/admin/temp-storage/{key},
+// force-removing a single object regardless of the upload it belongs to.
+func (h *Handler) DeleteTempStorageObject(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+
+	if err := h.Temp.Delete(r.Context(), key); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete temp storage object")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
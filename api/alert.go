@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ONSdigital/dis-imf-uploader/alert"
+)
+
+// cdnFailureThreshold is the number of consecutive CloudFront invalidation
+// failures for the same target key before alertCDNFailure pages, so a
+// single transient blip doesn't wake anyone up.
+const cdnFailureThreshold = 3
+
+// cdnFailureCounter tracks consecutive CloudFront invalidation failures per
+// target key.
+type cdnFailureCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newCDNFailureCounter() cdnFailureCounter {
+	return cdnFailureCounter{counts: make(map[string]int)}
+}
+
+// incr records a failure for key and returns the new consecutive count.
+func (c *cdnFailureCounter) incr(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[key]++
+	return c.counts[key]
+}
+
+// reset clears the consecutive failure count for key.
+func (c *cdnFailureCounter) reset(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.counts, key)
+}
+
+// trigger pages h.Alerter with event, doing nothing if no alerter is
+// configured.
+func (h *Handler) trigger(ctx context.Context, event alert.Event) {
+	if h.Alerter == nil {
+		return
+	}
+	_ = h.Alerter.Trigger(ctx, event)
+}
+
+// alertPublishFailure pages on-call when publishing an approved file to S3
+// fails, deduplicated per upload so repeated retries collapse into one
+// incident.
+func (h *Handler) alertPublishFailure(ctx context.Context, uploadID, message string) {
+	h.trigger(ctx, alert.Event{
+		Summary:  message,
+		Source:   "dis-imf-uploader",
+		Severity: alert.SeverityCritical,
+		DedupKey: "publish-failure:" + uploadID,
+	})
+}
+
+// alertStoreFailure pages on-call when the datastore is unavailable,
+// deduplicated so a run of failing requests only opens one incident.
+func (h *Handler) alertStoreFailure(ctx context.Context, message string) {
+	h.trigger(ctx, alert.Event{
+		Summary:  message,
+		Source:   "dis-imf-uploader",
+		Severity: alert.SeverityCritical,
+		DedupKey: "store-failure",
+	})
+}
+
+// alertCDNFailure records a CloudFront invalidation failure for targetKey
+// and pages on-call once cdnFailureThreshold consecutive failures have
+// occurred, rather than on every individual failure.
+func (h *Handler) alertCDNFailure(ctx context.Context, targetKey, message string) {
+	if h.cdnFailures.incr(targetKey) < cdnFailureThreshold {
+		return
+	}
+	h.trigger(ctx, alert.Event{
+		Summary:  message,
+		Source:   "dis-imf-uploader",
+		Severity: alert.SeverityError,
+		DedupKey: "cdn-failure:" + targetKey,
+	})
+}
+
+// resetCDNFailures clears the consecutive CloudFront failure count for
+// targetKey after a successful invalidation.
+func (h *Handler) resetCDNFailures(targetKey string) {
+	h.cdnFailures.reset(targetKey)
+}
@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/alert"
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+// fakeAlerter is a minimal alert.Alerter test double; alert.Alerter is
+// mocked by hand here rather than with moq since it is not declared in
+// this package.
+type fakeAlerter struct {
+	events []alert.Event
+}
+
+func (f *fakeAlerter) Trigger(ctx context.Context, event alert.Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestApprove_AlertsOnPublishFailure(t *testing.T) {
+	upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+	store := &storemock.StoreMock{
+		GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+	}
+	s3 := &apimock.S3ClientMock{
+		BackupFileFunc: func(ctx context.Context, key string) error { return errors.New("access denied") },
+	}
+	alerter := &fakeAlerter{}
+	h := api.NewHandler(store, api.WithS3(s3), api.WithAlerter(alerter))
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+	rec := httptest.NewRecorder()
+
+	h.Approve(rec, req)
+
+	if len(alerter.events) != 1 {
+		t.Fatalf("expected one alert, got %d", len(alerter.events))
+	}
+	if alerter.events[0].DedupKey != "publish-failure:1" {
+		t.Fatalf("expected publish-failure dedup key, got %q", alerter.events[0].DedupKey)
+	}
+	if alerter.events[0].Severity != alert.SeverityCritical {
+		t.Fatalf("expected critical severity, got %q", alerter.events[0].Severity)
+	}
+}
+
+func TestApprove_AlertsOnlyAfterRepeatedCDNFailures(t *testing.T) {
+	upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+	store := &storemock.StoreMock{
+		GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+	}
+	s3 := &apimock.S3ClientMock{
+		BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+		UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+	}
+	cf := &apimock.CloudFrontClientMock{
+		InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) {
+			return "", errors.New("throttled")
+		},
+	}
+	alerter := &fakeAlerter{}
+	h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithAlerter(alerter))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		h.Approve(httptest.NewRecorder(), req)
+	}
+	if len(alerter.events) != 0 {
+		t.Fatalf("expected no alert before threshold, got %d", len(alerter.events))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+	h.Approve(httptest.NewRecorder(), req)
+
+	if len(alerter.events) != 1 {
+		t.Fatalf("expected one alert once threshold is crossed, got %d", len(alerter.events))
+	}
+	if alerter.events[0].DedupKey != "cdn-failure:cpi/data.csv" {
+		t.Fatalf("expected cdn-failure dedup key, got %q", alerter.events[0].DedupKey)
+	}
+}
+
+func TestCreateUpload_AlertsOnStoreFailure(t *testing.T) {
+	store := &storemock.StoreMock{
+		CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+			return errors.New("connection refused")
+		},
+	}
+	alerter := &fakeAlerter{}
+	h := api.NewHandler(store, api.WithAlerter(alerter))
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{"dataset":"cpi"}`))
+	h.CreateUpload(httptest.NewRecorder(), req)
+
+	if len(alerter.events) != 1 {
+		t.Fatalf("expected one alert, got %d", len(alerter.events))
+	}
+	if alerter.events[0].DedupKey != "store-failure" {
+		t.Fatalf("expected store-failure dedup key, got %q", alerter.events[0].DedupKey)
+	}
+}
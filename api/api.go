@@ -0,0 +1,747 @@
+// Package api implements the HTTP handlers for the upload and review
+// workflow, depending only on interfaces so that AWS/Cloudflare/Slack
+// integrations can be faked in tests.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/alert"
+	"github.com/ONSdigital/dis-imf-uploader/digest"
+	"github.com/ONSdigital/dis-imf-uploader/events"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/preflight"
+	"github.com/ONSdigital/dis-imf-uploader/progress"
+	"github.com/ONSdigital/dis-imf-uploader/purge"
+	"github.com/ONSdigital/dis-imf-uploader/reconcile"
+	"github.com/ONSdigital/dis-imf-uploader/scheduler"
+	"github.com/ONSdigital/dis-imf-uploader/store"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+//go:generate moq -pkg mock -out mock/s3client.go . S3Client
+//go:generate moq -pkg mock -out mock/cloudfrontclient.go . CloudFrontClient
+//go:generate moq -pkg mock -out mock/cloudflareclient.go . CloudflareClient
+//go:generate moq -pkg mock -out mock/notifier.go . Notifier
+//go:generate moq -pkg mock -out mock/usermapper.go . UserMapper
+//go:generate moq -pkg mock -out mock/permissionchecker.go . PermissionChecker
+//go:generate moq -pkg mock -out mock/s3lister.go . S3Lister
+//go:generate moq -pkg mock -out mock/s3tagger.go . S3Tagger
+//go:generate moq -pkg mock -out mock/s3presigner.go . S3Presigner
+//go:generate moq -pkg mock -out mock/s3metadatasetter.go . S3MetadataSetter
+//go:generate moq -pkg mock -out mock/restorer.go . Restorer
+//go:generate moq -pkg mock -out mock/s3diagnoser.go . S3Diagnoser
+//go:generate moq -pkg mock -out mock/distributioninspector.go . DistributionInspector
+//go:generate moq -pkg mock -out mock/zoneinspector.go . ZoneInspector
+
+// Uploader puts a file's contents into permanent storage with the given
+// Content-Type, Content-Disposition and Cache-Control, so the CDN and
+// browsers serve it with correct handling instead of a generic
+// binary/octet-stream default and no caching policy. An empty
+// contentDisposition or cacheControl leaves the respective header unset.
+type Uploader interface {
+	UploadFile(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error
+}
+
+// Backuper copies the current object at key aside before it is overwritten.
+type Backuper interface {
+	BackupFile(ctx context.Context, key string) error
+}
+
+// Downloader fetches the current contents of an S3 object.
+type Downloader interface {
+	DownloadFile(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// S3Client is the subset of S3 behaviour the review workflow depends on.
+type S3Client interface {
+	Uploader
+	Backuper
+	Downloader
+}
+
+// Restorer restores the object at key from the copy BackupFile made aside
+// before it was last overwritten, undoing a publish. Handlers check for it
+// via a type assertion on S3Client, the same optional-capability pattern as
+// S3Lister; a backend that can't restore leaves a failed multi-file release
+// partially published, so PublishRelease pages on-call in that case rather
+// than silently accepting the drift.
+type Restorer interface {
+	RestoreFile(ctx context.Context, key string) error
+}
+
+// Invalidator triggers cache invalidation for the given paths and returns an
+// invalidation ID that can be used to track completion.
+type Invalidator interface {
+	InvalidatePaths(ctx context.Context, paths []string) (string, error)
+}
+
+// CloudFrontClient is the subset of CloudFront behaviour the review workflow
+// depends on.
+type CloudFrontClient interface {
+	Invalidator
+}
+
+// S3ObjectInfo describes a single object found in S3 by S3Lister.
+type S3ObjectInfo struct {
+	Key  string
+	Size int64
+}
+
+// S3Lister is implemented by S3 backends that can enumerate objects under a
+// prefix. Handlers check for it via a type assertion on S3Client, the same
+// optional-capability pattern used for temp.Lister.
+type S3Lister interface {
+	ListObjects(ctx context.Context, prefix string) ([]S3ObjectInfo, error)
+}
+
+// S3Tagger is implemented by S3 backends that can tag an object in place,
+// e.g. to quarantine a suspected orphan pending manual review without
+// deleting it outright. Handlers check for it via a type assertion on
+// S3Client, the same optional-capability pattern as S3Lister.
+type S3Tagger interface {
+	TagObject(ctx context.Context, key string, tags map[string]string) error
+}
+
+// S3Presigner is implemented by S3 backends that can generate a short-lived
+// signed URL for an object, so a reviewer or downstream consumer can fetch
+// it directly without needing bucket credentials. Handlers check for it via
+// a type assertion on S3Client, the same optional-capability pattern as
+// S3Lister.
+type S3Presigner interface {
+	PresignURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// S3MetadataSetter is implemented by S3 backends that can set user metadata
+// on an existing object, e.g. to publish its integrity digest so consumers
+// fetching the object directly from S3 can read it back without calling the
+// API. Handlers check for it via a type assertion on S3Client, the same
+// optional-capability pattern as S3Lister.
+type S3MetadataSetter interface {
+	SetObjectMetadata(ctx context.Context, key string, metadata map[string]string) error
+}
+
+// BucketChecker is implemented by S3 backends that can verify their target
+// bucket exists and is reachable, e.g. via HeadBucket. Handlers check for
+// it via a type assertion on S3Client, the same optional-capability
+// pattern as S3Lister. Used by Preflight.
+type BucketChecker interface {
+	CheckBucket(ctx context.Context) error
+}
+
+// DistributionChecker is implemented by CloudFront backends that can
+// verify their target distribution exists and is reachable. Handlers check
+// for it via a type assertion on CloudFrontClient, the same optional-
+// capability pattern as S3Lister. Used by Preflight.
+type DistributionChecker interface {
+	CheckDistribution(ctx context.Context) error
+}
+
+// ZoneChecker is implemented by Cloudflare backends that can verify access
+// to their target zone. Handlers check for it via a type assertion on
+// CloudflareClient, the same optional-capability pattern as S3Lister. Used
+// by Preflight.
+type ZoneChecker interface {
+	CheckZone(ctx context.Context) error
+}
+
+// S3PermissionsSummary reports which S3 operations the service's
+// credentials were verified able to perform on its target prefix, as
+// reported by S3Diagnoser.
+type S3PermissionsSummary struct {
+	Put    bool `json:"put"`
+	Get    bool `json:"get"`
+	Copy   bool `json:"copy"`
+	Delete bool `json:"delete"`
+}
+
+// S3DiagnosticsSummary reports the bucket-level configuration relevant to
+// upload safety, as reported by S3Diagnoser.
+type S3DiagnosticsSummary struct {
+	Permissions          S3PermissionsSummary `json:"permissions"`
+	Encryption           string               `json:"encryption,omitempty"`
+	BackupLifecycleRules []string             `json:"backup_lifecycle_rules,omitempty"`
+}
+
+// S3Diagnoser is implemented by S3 backends that can self-check their
+// effective permissions (Put/Get/Copy/Delete) on the target prefix, the
+// bucket's encryption settings, and the lifecycle rules covering the
+// backup prefix, so an operator can catch a misconfigured bucket policy
+// or a missing lifecycle rule before it causes a publish or backup
+// failure. Handlers check for it via a type assertion on S3Client, the
+// same optional-capability pattern as S3Lister. Used by
+// GetS3Diagnostics.
+type S3Diagnoser interface {
+	DiagnoseSelf(ctx context.Context) (S3DiagnosticsSummary, error)
+}
+
+// DistributionSummary describes a CloudFront distribution's current
+// configuration as reported by DistributionInspector.
+type DistributionSummary struct {
+	ID         string   `json:"id"`
+	DomainName string   `json:"domain_name"`
+	Status     string   `json:"status"`
+	Aliases    []string `json:"aliases,omitempty"`
+}
+
+// DistributionInspector is implemented by CloudFront backends that can
+// report a summary of their target distribution's configuration, e.g. so
+// an operator can confirm the service points at the right distribution
+// before a release. Handlers check for it via a type assertion on
+// CloudFrontClient, the same optional-capability pattern as S3Lister.
+// Used by GetCDNStatus.
+type DistributionInspector interface {
+	DistributionStatus(ctx context.Context) (DistributionSummary, error)
+}
+
+// ZoneSummary describes a Cloudflare zone's current settings as reported
+// by ZoneInspector.
+type ZoneSummary struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	DevelopmentMode bool   `json:"development_mode"`
+}
+
+// ZoneInspector is implemented by Cloudflare backends that can report a
+// summary of their target zone's settings, e.g. so an operator can
+// confirm the service points at the right zone before a release. Handlers
+// check for it via a type assertion on CloudflareClient, the same
+// optional-capability pattern as S3Lister. Used by GetCDNStatus.
+type ZoneInspector interface {
+	ZoneStatus(ctx context.Context) (ZoneSummary, error)
+}
+
+// Purger triggers a cache purge for the given URLs. Unlike Invalidator,
+// which purges CloudFront by path, urls must be the full public URL of
+// each object (see Handler.publicURLs) since Cloudflare purges by URL.
+type Purger interface {
+	PurgeCache(ctx context.Context, urls []string) error
+}
+
+// CloudflareClient is the subset of Cloudflare behaviour the review workflow
+// depends on.
+type CloudflareClient interface {
+	Purger
+}
+
+// Notifier sends a notification for a key event in the upload lifecycle.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// ApprovalNotifier sends a richer, interactive notification (e.g. a Slack
+// Block Kit message with Approve/Reject buttons) when an upload becomes
+// pending review. Handlers check for it via a type assertion on Notifier,
+// the same optional-capability pattern used for temp.Lister.
+type ApprovalNotifier interface {
+	NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error
+}
+
+// ErrorNotifier sends a notification immediately, bypassing any batching or
+// quiet-hours suppression a Notifier otherwise applies to Notify. Handlers
+// check for it via a type assertion on Notifier, the same optional-
+// capability pattern as ApprovalNotifier.
+type ErrorNotifier interface {
+	NotifyError(ctx context.Context, message string) error
+}
+
+// DatasetNotifier sends a notification scoped to a specific dataset, so a
+// Notifier that routes per dataset (e.g. slack.Router) can deliver it to
+// the owning team's channel rather than one global channel. Handlers check
+// for it via a type assertion on Notifier, the same optional-capability
+// pattern as ApprovalNotifier.
+type DatasetNotifier interface {
+	NotifyDataset(ctx context.Context, dataset, message string) error
+}
+
+// UserMapper maps an external identity, such as a Slack user ID, to the
+// service username recorded against a review action.
+type UserMapper interface {
+	MapUser(ctx context.Context, externalID string) (string, error)
+}
+
+// PermissionChecker reports whether the caller of r holds permission (e.g.
+// "imf:purge"), typically by inspecting an auth token on the request.
+// Handlers gate access to sensitive, non-upload-scoped operations behind it
+// via Handler.requirePermission.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, r *http.Request, permission string) (bool, error)
+}
+
+// Handler holds the dependencies required to serve the upload and review
+// API. All dependencies are interfaces so they can be substituted with mocks
+// in unit tests. Optional dependencies are set via Option functions passed
+// to NewHandler, so new integrations don't change the constructor signature.
+type Handler struct {
+	Store      store.Store
+	S3         S3Client
+	CloudFront CloudFrontClient
+	Cloudflare CloudflareClient
+	Notifier   Notifier
+	Temp       temp.Storage
+	TempTTL    time.Duration
+	Progress   *progress.Tracker
+	Events     *events.Bus
+	ZipPolicy      validate.ZipPolicy
+	PDFPolicy      validate.PDFPolicy
+	XLSXPolicy     validate.XLSXPolicy
+	FilenamePolicy validate.FilenamePolicy
+
+	// ApprovalChecklist lists the items a reviewer must acknowledge before
+	// Approve will publish a file, e.g. "figures verified". Empty means no
+	// checklist is enforced.
+	ApprovalChecklist []string
+
+	// SLAReviewTarget and SLAPublishTarget are the turnaround targets
+	// GetSLAReport measures uploads against when computing the percentage
+	// handled within target. Zero disables that percentage (reported as 0
+	// of a target that was never configured, rather than a misleading
+	// 100%).
+	SLAReviewTarget  time.Duration
+	SLAPublishTarget time.Duration
+
+	// PreventSelfApproval blocks Approve when ReviewedBy matches the
+	// upload's UploadedBy. Disable for small teams where the same person
+	// often uploads and reviews.
+	PreventSelfApproval bool
+
+	// RevalidateOnApprove re-runs content validation (validateByExtension)
+	// against the upload's staged bytes when Approve is called, so a
+	// validation rule tightened after the file was originally staged still
+	// blocks approval. Disabled by default since it requires reading the
+	// staged file back from Temp storage.
+	RevalidateOnApprove bool
+
+	// EnforceDatasetReviewers checks a dataset's models.DatasetProfile
+	// RequiredReviewers on Approve, when one is configured. Disabled by
+	// default so a Store without any dataset profiles isn't queried on
+	// every approval.
+	EnforceDatasetReviewers bool
+
+	// Preflight caches the result of the most recently run startup
+	// dependency check (see Preflight). Nil until RunPreflight is called
+	// at least once.
+	Preflight *preflight.Prober
+
+	// RequireReady gates every request behind requireReady once set,
+	// responding 503 until Preflight reports a ready result. Disabled by
+	// default since most deployments don't run a startup preflight at all.
+	RequireReady bool
+
+	// ServiceAuthToken is the shared secret an automated pipeline presents
+	// in the Service-Auth-Token header to call CreateUpload without a human
+	// JWT. Empty disables service-principal auth entirely.
+	ServiceAuthToken string
+	// ServiceAuthPrincipal is the name recorded as UploadedBy on uploads
+	// authenticated via ServiceAuthToken.
+	ServiceAuthPrincipal string
+
+	// CORSAllowedOrigins, CORSAllowedMethods and CORSAllowedHeaders
+	// configure the CORS headers CORS serves, so the review dashboard (a
+	// different origin) can call the API from the browser. Empty
+	// CORSAllowedOrigins disables CORS entirely. "*" allows any origin.
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+	// CORSMaxAge is how long a browser may cache a preflight response.
+	// Zero omits Access-Control-Max-Age.
+	CORSMaxAge time.Duration
+
+	// SecurityAllowedMethods lists the HTTP methods SecurityHeaders lets
+	// through; any other method is rejected with 405. Empty disables the
+	// method check.
+	SecurityAllowedMethods []string
+	// SecurityMaxHeaderBytes is the largest total request header size
+	// SecurityHeaders allows before rejecting with 431. Zero disables the
+	// check.
+	SecurityMaxHeaderBytes int
+	// SecurityHSTSMaxAge sets the max-age advertised in
+	// Strict-Transport-Security on TLS requests. Zero omits the header.
+	SecurityHSTSMaxAge time.Duration
+
+	// AuditPathPrefixes lists the request path prefixes AuditLog records
+	// mutating calls under, e.g. "/api/v1/admin". Empty disables auditing
+	// entirely.
+	AuditPathPrefixes []string
+
+	// UserMapper resolves the external identity on interactive callbacks
+	// (e.g. a Slack user ID) to the service username recorded as ReviewedBy.
+	UserMapper UserMapper
+	// SlackSigningSecret verifies that Slack interactivity and slash
+	// command callbacks genuinely came from Slack. Empty disables both
+	// endpoints.
+	SlackSigningSecret string
+
+	// Permissions checks caller permissions for endpoints gated by
+	// requirePermission. Nil leaves those endpoints open, consistent with
+	// other optional dependencies (e.g. UserMapper) defaulting to
+	// permissive behaviour when unconfigured.
+	Permissions PermissionChecker
+
+	// Alerter pages an on-call responder about critical failures (S3 publish
+	// failures, store unavailability, sustained CDN invalidation failures).
+	// Nil disables paging; Slack notifications are unaffected.
+	Alerter alert.Alerter
+
+	// cdnFailures counts consecutive CloudFront invalidation failures per
+	// target key, so alertCDNFailure only pages once a sustained outage
+	// crosses cdnFailureThreshold rather than on a single transient blip.
+	cdnFailures cdnFailureCounter
+
+	// CDNDatasetPrefixPattern is a fmt.Sprintf pattern, keyed by dataset,
+	// used by CreateInvalidation to build the wildcard path invalidated for
+	// a whole dataset, e.g. "/imf/%s/*". Empty disables the endpoint.
+	CDNDatasetPrefixPattern string
+
+	// PublicURLTemplates are fmt.Sprintf patterns, each keyed by the
+	// published TargetKey, used by Approve to build the public URL(s)
+	// returned to the caller. Empty omits public_urls from the response.
+	PublicURLTemplates []string
+
+	// ContentDispositionTemplate is an fmt.Sprintf pattern keyed by the
+	// published file's base name, used to set the Content-Disposition
+	// header when publishing to S3, e.g. "attachment; filename=%q" prompts
+	// a browser to download rather than render the file inline. Empty
+	// leaves Content-Disposition unset.
+	ContentDispositionTemplate string
+
+	// CacheControlByExtension maps a lowercased file extension (including
+	// the leading dot) to the Cache-Control header set when publishing a
+	// matching key to S3, e.g. ".pdf": "public, max-age=604800" for a
+	// stable file vs ".csv": "no-cache" for one that's revised often. An
+	// extension with no entry is published with no Cache-Control header.
+	// A non-empty DatasetProfile.CacheControl for the upload's dataset
+	// takes precedence over this.
+	CacheControlByExtension map[string]string
+
+	// Reconciler exposes the most recent background drift-check results via
+	// GetStats. Nil omits reconciliation stats from the response.
+	Reconciler *reconcile.Reconciler
+
+	// Digest posts the daily backlog summary when RunDigest is triggered.
+	// Nil disables the endpoint.
+	Digest *digest.Digest
+
+	// Scheduler exposes its jobs' run history via GetSchedulerJobs. Nil
+	// omits the endpoint's data, returning an empty list rather than an
+	// error, since a service with no scheduled jobs is a valid
+	// configuration.
+	Scheduler *scheduler.Scheduler
+
+	// PurgeRetrier retries Cloudflare purges that failed during publishing
+	// (see publishUpload) and lists pending retries via
+	// ListPendingPurgeRetries. Nil disables the endpoint; a failed purge is
+	// still recorded on the upload as a failed PublishStep either way.
+	// Intended to be run periodically via a scheduler.Job calling
+	// PurgeRetrier.RetryOnce.
+	PurgeRetrier *purge.Retrier
+
+	// S3ScanPrefix is the prefix ListOrphans scans for objects with no
+	// corresponding approved or published upload. Empty disables the
+	// endpoint, since scanning the whole bucket is rarely intended.
+	S3ScanPrefix string
+
+	// PublishWindow bounds the time of day during which Approve is allowed
+	// to publish immediately. Outside the window the upload is queued as
+	// models.StatusScheduled instead, unless the caller holds
+	// permissionPublishOverride. Nil disables the restriction, so Approve
+	// always publishes immediately by default.
+	PublishWindow *PublishWindow
+
+	// Environment names the deployment this Handler is running in, e.g.
+	// "staging" or "production", so a Slack message or API response can be
+	// told apart from the same alert firing in another environment. Empty
+	// omits it everywhere: the X-Environment response header, Slack message
+	// footers, and audit log entries.
+	Environment string
+
+	// MaxUploadsPerDay and MaxBytesPerDay cap how many uploads, and how many
+	// total bytes, a single UploadedBy identity may submit via CreateUpload
+	// within a rolling UTC day, protecting the review queue from runaway
+	// automation. Zero disables the corresponding limit. A caller holding
+	// permissionQuotaOverride bypasses both.
+	MaxUploadsPerDay int
+	MaxBytesPerDay   int64
+
+	// GreylistThreshold and GreylistWindow configure how CreateUpload
+	// greylists a repeatedly-failing uploader: once a single UploadedBy
+	// identity has GreylistThreshold validation failures within
+	// GreylistWindow, further uploads from them are blocked until an admin
+	// clears them via ClearGreylist or the failures causing the block age
+	// out of GreylistWindow on their own. Zero GreylistThreshold disables
+	// greylisting entirely.
+	GreylistThreshold int
+	GreylistWindow    time.Duration
+
+	// greylist tracks recent validation failures per identity for the
+	// greylisting enforced by CreateUpload.
+	greylist greylist
+}
+
+// Option configures optional Handler dependencies.
+type Option func(*Handler)
+
+// WithS3 sets the S3 client used to publish approved files.
+func WithS3(s3Client S3Client) Option {
+	return func(h *Handler) { h.S3 = s3Client }
+}
+
+// WithCloudFront sets the CloudFront client used to invalidate the cache.
+func WithCloudFront(cloudFrontClient CloudFrontClient) Option {
+	return func(h *Handler) { h.CloudFront = cloudFrontClient }
+}
+
+// WithCloudflare sets the Cloudflare client used to purge the cache.
+func WithCloudflare(cloudflareClient CloudflareClient) Option {
+	return func(h *Handler) { h.Cloudflare = cloudflareClient }
+}
+
+// WithNotifier sets the notifier used to announce lifecycle events.
+func WithNotifier(notifier Notifier) Option {
+	return func(h *Handler) { h.Notifier = notifier }
+}
+
+// WithTemp sets the temp storage backend and the TTL applied to newly
+// stored pending files.
+func WithTemp(tempStorage temp.Storage, ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.Temp = tempStorage
+		h.TempTTL = ttl
+	}
+}
+
+// WithZipPolicy sets the per-entry rules enforced when a zip upload
+// requests explode=true.
+func WithZipPolicy(policy validate.ZipPolicy) Option {
+	return func(h *Handler) { h.ZipPolicy = policy }
+}
+
+// WithPDFPolicy sets the rules enforced when a PDF upload is screened for
+// active content.
+func WithPDFPolicy(policy validate.PDFPolicy) Option {
+	return func(h *Handler) { h.PDFPolicy = policy }
+}
+
+// WithXLSXPolicy sets the rules enforced when an XLSX/XLSM upload is
+// screened for macros, external links and DDE formulas.
+func WithXLSXPolicy(policy validate.XLSXPolicy) Option {
+	return func(h *Handler) { h.XLSXPolicy = policy }
+}
+
+// WithFilenamePolicy sets the per-dataset naming convention enforced on an
+// uploaded file's filename.
+func WithFilenamePolicy(policy validate.FilenamePolicy) Option {
+	return func(h *Handler) { h.FilenamePolicy = policy }
+}
+
+// WithPreventSelfApproval sets whether Approve blocks a reviewer from
+// approving their own upload.
+func WithPreventSelfApproval(prevent bool) Option {
+	return func(h *Handler) { h.PreventSelfApproval = prevent }
+}
+
+// WithRevalidateOnApprove sets whether Approve re-runs content validation
+// against the upload's staged bytes before publishing.
+func WithRevalidateOnApprove(revalidate bool) Option {
+	return func(h *Handler) { h.RevalidateOnApprove = revalidate }
+}
+
+// WithEnforceDatasetReviewers sets whether Approve checks a dataset's
+// configured RequiredReviewers.
+func WithEnforceDatasetReviewers(enforce bool) Option {
+	return func(h *Handler) { h.EnforceDatasetReviewers = enforce }
+}
+
+// WithRequireReady sets whether every request is gated behind a passing
+// Preflight result once one has been run.
+func WithRequireReady(require bool) Option {
+	return func(h *Handler) { h.RequireReady = require }
+}
+
+// WithServiceAuth sets the shared token and attributed principal name for
+// automated pipelines calling CreateUpload without a human JWT.
+func WithServiceAuth(token, principal string) Option {
+	return func(h *Handler) {
+		h.ServiceAuthToken = token
+		h.ServiceAuthPrincipal = principal
+	}
+}
+
+// WithCORS sets the allowed origins, methods and headers served by CORS,
+// and how long a browser may cache a preflight response.
+func WithCORS(allowedOrigins, allowedMethods, allowedHeaders []string, maxAge time.Duration) Option {
+	return func(h *Handler) {
+		h.CORSAllowedOrigins = allowedOrigins
+		h.CORSAllowedMethods = allowedMethods
+		h.CORSAllowedHeaders = allowedHeaders
+		h.CORSMaxAge = maxAge
+	}
+}
+
+// WithSecurityHeaders sets the allowed methods, maximum header size and HSTS
+// max-age enforced and served by SecurityHeaders.
+func WithSecurityHeaders(allowedMethods []string, maxHeaderBytes int, hstsMaxAge time.Duration) Option {
+	return func(h *Handler) {
+		h.SecurityAllowedMethods = allowedMethods
+		h.SecurityMaxHeaderBytes = maxHeaderBytes
+		h.SecurityHSTSMaxAge = hstsMaxAge
+	}
+}
+
+// WithAuditLog sets the request path prefixes AuditLog records mutating
+// calls under.
+func WithAuditLog(pathPrefixes []string) Option {
+	return func(h *Handler) { h.AuditPathPrefixes = pathPrefixes }
+}
+
+// WithApprovalChecklist sets the items a reviewer must acknowledge before an
+// upload can be approved.
+func WithApprovalChecklist(items []string) Option {
+	return func(h *Handler) { h.ApprovalChecklist = items }
+}
+
+// WithSLATargets sets the review and publish turnaround targets
+// GetSLAReport measures uploads against.
+func WithSLATargets(reviewTarget, publishTarget time.Duration) Option {
+	return func(h *Handler) {
+		h.SLAReviewTarget = reviewTarget
+		h.SLAPublishTarget = publishTarget
+	}
+}
+
+// WithUserMapper sets the mapper used to resolve external identities on
+// interactive callbacks to service usernames.
+func WithUserMapper(mapper UserMapper) Option {
+	return func(h *Handler) { h.UserMapper = mapper }
+}
+
+// WithSlackSigningSecret sets the secret used to verify Slack interactivity
+// and slash command callbacks.
+func WithSlackSigningSecret(secret string) Option {
+	return func(h *Handler) { h.SlackSigningSecret = secret }
+}
+
+// WithAlerter sets the alerter used to page on-call on critical failures.
+func WithAlerter(alerter alert.Alerter) Option {
+	return func(h *Handler) { h.Alerter = alerter }
+}
+
+// WithCDNDatasetPrefixPattern sets the fmt.Sprintf pattern used to build the
+// wildcard path invalidated by CreateInvalidation for a whole dataset.
+func WithCDNDatasetPrefixPattern(pattern string) Option {
+	return func(h *Handler) { h.CDNDatasetPrefixPattern = pattern }
+}
+
+// WithEnvironment sets the deployment name reported in the X-Environment
+// response header, Slack message footers, and audit log entries.
+func WithEnvironment(environment string) Option {
+	return func(h *Handler) { h.Environment = environment }
+}
+
+// WithPermissionChecker sets the checker used to gate permission-restricted
+// endpoints such as the invalidations resource.
+func WithPermissionChecker(checker PermissionChecker) Option {
+	return func(h *Handler) { h.Permissions = checker }
+}
+
+// WithPublicURLTemplates sets the fmt.Sprintf patterns used to build the
+// public URL(s) returned by Approve.
+func WithPublicURLTemplates(templates []string) Option {
+	return func(h *Handler) { h.PublicURLTemplates = templates }
+}
+
+// WithContentDispositionTemplate sets the fmt.Sprintf pattern used to
+// build the Content-Disposition header set on published S3 objects.
+func WithContentDispositionTemplate(template string) Option {
+	return func(h *Handler) { h.ContentDispositionTemplate = template }
+}
+
+// WithCacheControlByExtension sets the per-extension Cache-Control headers
+// applied when publishing to S3.
+func WithCacheControlByExtension(byExtension map[string]string) Option {
+	return func(h *Handler) { h.CacheControlByExtension = byExtension }
+}
+
+// WithReconciler sets the background reconciler whose drift-check results
+// GetStats reports.
+func WithReconciler(reconciler *reconcile.Reconciler) Option {
+	return func(h *Handler) { h.Reconciler = reconciler }
+}
+
+// WithDigest sets the daily digest RunDigest posts.
+func WithDigest(d *digest.Digest) Option {
+	return func(h *Handler) { h.Digest = d }
+}
+
+// WithScheduler sets the scheduler whose job run history GetSchedulerJobs
+// reports.
+func WithScheduler(s *scheduler.Scheduler) Option {
+	return func(h *Handler) { h.Scheduler = s }
+}
+
+// WithPurgeRetrier sets the retrier ListPendingPurgeRetries reports on and
+// that a scheduler.Job should call RetryOnce on periodically.
+func WithPurgeRetrier(r *purge.Retrier) Option {
+	return func(h *Handler) { h.PurgeRetrier = r }
+}
+
+// WithS3ScanPrefix sets the prefix ListOrphans scans for objects with no
+// corresponding upload record.
+func WithS3ScanPrefix(prefix string) Option {
+	return func(h *Handler) { h.S3ScanPrefix = prefix }
+}
+
+// WithPublishWindow sets the daily window during which Approve publishes
+// immediately, queuing approvals outside it as models.StatusScheduled.
+func WithPublishWindow(window *PublishWindow) Option {
+	return func(h *Handler) { h.PublishWindow = window }
+}
+
+// WithUploadQuota sets the per-uploader daily upload count and byte limits
+// enforced by CreateUpload. Zero disables the corresponding limit.
+func WithUploadQuota(maxUploadsPerDay int, maxBytesPerDay int64) Option {
+	return func(h *Handler) {
+		h.MaxUploadsPerDay = maxUploadsPerDay
+		h.MaxBytesPerDay = maxBytesPerDay
+	}
+}
+
+// WithGreylist sets the failure count and time window CreateUpload
+// greylists a repeatedly-failing uploader over. Zero threshold disables
+// greylisting entirely.
+func WithGreylist(threshold int, window time.Duration) Option {
+	return func(h *Handler) {
+		h.GreylistThreshold = threshold
+		h.GreylistWindow = window
+	}
+}
+
+// NewHandler constructs a Handler backed by dataStore, applying any
+// optional dependencies passed via opts.
+func NewHandler(dataStore store.Store, opts ...Option) *Handler {
+	h := &Handler{Store: dataStore, Progress: progress.NewTracker(), Events: events.NewBus(), cdnFailures: newCDNFailureCounter(), greylist: newGreylist()}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	http.Error(w, message, status)
+}
+
+// writeValidationErrors responds 400 with a structured models.ValidationErrors
+// body, so callers such as the review dashboard can highlight exactly which
+// rule failed instead of parsing a flattened sentence out of a plain-text
+// error message.
+func writeValidationErrors(w http.ResponseWriter, errs ...models.ValidationError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(models.ValidationErrors{Errors: errs})
+}
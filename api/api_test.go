@@ -0,0 +1,867 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestCreateUpload(t *testing.T) {
+	t.Run("success notifies and returns 201", func(t *testing.T) {
+		notified := false
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+				return nil
+			},
+			CreateNotificationFunc: func(ctx context.Context, notification *models.Notification) error {
+				return nil
+			},
+		}
+		notifier := &apimock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, message string) error {
+				notified = true
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithNotifier(notifier))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{"dataset":"cpi","filename":"data.csv"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d", rec.Code)
+		}
+		if !notified {
+			t.Fatalf("expected notifier to be called")
+		}
+	})
+
+	t.Run("store failure returns 500", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+				return errors.New("connection refused")
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{"dataset":"cpi"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", rec.Code)
+		}
+	})
+}
+
+// closedWindowStart and closedWindowEnd bound a one-minute publish window
+// two hours from now (UTC), guaranteed closed at the moment the test runs.
+var closedWindowStart, closedWindowEnd = func() (string, string) {
+	now := time.Now().UTC().Add(2 * time.Hour)
+	end := now.Add(time.Minute)
+	return now.Format("15:04"), end.Format("15:04")
+}()
+
+func TestApprove(t *testing.T) {
+	t.Run("success publishes and invalidates", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		invalidated := false
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error {
+				if u.Status != models.StatusApproved {
+					t.Fatalf("expected status approved, got %s", u.Status)
+				}
+				return nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) {
+				invalidated = true
+				return "INV123", nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if !invalidated {
+			t.Fatalf("expected cloudfront invalidation to be triggered")
+		}
+		if upload.InvalidationID != "INV123" {
+			t.Fatalf("expected the invalidation ID to be recorded, got %q", upload.InvalidationID)
+		}
+	})
+
+	t.Run("presigned S3 backend includes a signed download URL in the notification", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &fakeS3WithCapabilities{
+			S3ClientMock: &apimock.S3ClientMock{
+				BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+				UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+			},
+			S3PresignerMock: &apimock.S3PresignerMock{
+				PresignURLFunc: func(ctx context.Context, key string, expiry time.Duration) (string, error) {
+					return "https://cdn.example.com/" + key + "?sig=abc", nil
+				},
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		var sentMessage string
+		notifier := &apimock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, message string) error {
+				sentMessage = message
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithNotifier(notifier))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if !strings.Contains(sentMessage, "https://cdn.example.com/cpi/data.csv") {
+			t.Fatalf("expected notification to include the signed URL, got %q", sentMessage)
+		}
+	})
+
+	t.Run("staged file yields an SRI integrity digest published as S3 metadata", func(t *testing.T) {
+		tempStorage := temp.NewInMemoryStorage(1<<20, 0)
+		if err := tempStorage.Store(context.Background(), "staged/1", strings.NewReader("hello"), 5, time.Hour); err != nil {
+			t.Fatalf("failed to seed temp storage: %v", err)
+		}
+
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", TempKey: "staged/1", Status: models.StatusPending}
+		var saved *models.Upload
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error {
+				saved = u
+				return nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		var metadataKey string
+		var metadata map[string]string
+		s3 := &fakeS3WithCapabilities{
+			S3ClientMock: &apimock.S3ClientMock{
+				BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+				UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+			},
+			S3MetadataSetterMock: &apimock.S3MetadataSetterMock{
+				SetObjectMetadataFunc: func(ctx context.Context, key string, m map[string]string) error {
+					metadataKey = key
+					metadata = m
+					return nil
+				},
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithTemp(tempStorage, time.Hour))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if saved == nil || !strings.HasPrefix(saved.Integrity, "sha384-") {
+			t.Fatalf("expected an sha384 integrity digest, got %+v", saved)
+		}
+		if metadataKey != "cpi/data.csv" || metadata["integrity"] != saved.Integrity {
+			t.Fatalf("expected the digest to be published as S3 metadata, got key=%q metadata=%v", metadataKey, metadata)
+		}
+	})
+
+	t.Run("published S3 metadata traces the object back to its upload and reviewer", func(t *testing.T) {
+		upload := &models.Upload{
+			ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending,
+			UploadedBy: "j.doe@example.com",
+		}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		var metadata map[string]string
+		s3 := &fakeS3WithCapabilities{
+			S3ClientMock: &apimock.S3ClientMock{
+				BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+				UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+			},
+			S3MetadataSetterMock: &apimock.S3MetadataSetterMock{
+				SetObjectMetadataFunc: func(ctx context.Context, key string, m map[string]string) error {
+					metadata = m
+					return nil
+				},
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", strings.NewReader(`{"reviewed_by":"a.reviewer@example.com"}`))
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if metadata["upload-id"] != "1" || metadata["uploaded-by"] != "j.doe@example.com" ||
+			metadata["reviewed-by"] != "a.reviewer@example.com" || metadata["source-service"] != "dis-imf-uploader" {
+			t.Fatalf("expected traceable S3 metadata, got %v", metadata)
+		}
+	})
+
+	t.Run("publishes with a Content-Type derived from the file extension and a configured Content-Disposition", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "weo/imf_weo_202401.xlsx", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		var gotContentType, gotContentDisposition string
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+				gotContentType = contentType
+				gotContentDisposition = contentDisposition
+				return nil
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf),
+			api.WithContentDispositionTemplate("attachment; filename=%q"))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if gotContentType != "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet" {
+			t.Fatalf("expected an XLSX content type, got %q", gotContentType)
+		}
+		if gotContentDisposition != `attachment; filename="imf_weo_202401.xlsx"` {
+			t.Fatalf("expected a Content-Disposition built from the template, got %q", gotContentDisposition)
+		}
+	})
+
+	t.Run("a dataset's Cache-Control override takes precedence over the per-extension default", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Dataset: "weo", TargetKey: "weo/imf_weo_202401.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			GetDatasetProfileFunc: func(ctx context.Context, dataset string) (*models.DatasetProfile, error) {
+				return &models.DatasetProfile{Dataset: dataset, CacheControl: "no-cache"}, nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		var gotCacheControl string
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+				gotCacheControl = cacheControl
+				return nil
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV124", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf),
+			api.WithCacheControlByExtension(map[string]string{".csv": "public, max-age=604800"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if gotCacheControl != "no-cache" {
+			t.Fatalf("expected the dataset's Cache-Control override, got %q", gotCacheControl)
+		}
+	})
+
+	t.Run("falls back to the per-extension Cache-Control when the dataset has no override", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Dataset: "weo", TargetKey: "weo/imf_weo_202401.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			GetDatasetProfileFunc: func(ctx context.Context, dataset string) (*models.DatasetProfile, error) {
+				return nil, errors.New("no profile for dataset")
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		var gotCacheControl string
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+				gotCacheControl = cacheControl
+				return nil
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV125", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf),
+			api.WithCacheControlByExtension(map[string]string{".csv": "public, max-age=604800"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if gotCacheControl != "public, max-age=604800" {
+			t.Fatalf("expected the per-extension Cache-Control, got %q", gotCacheControl)
+		}
+	})
+
+	t.Run("response includes public URLs built from the configured templates", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf),
+			api.WithPublicURLTemplates([]string{"https://cdn.example.com/%s", "https://ons-imf.s3.example.com/%s"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var resp api.ApproveResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		want := []string{"https://cdn.example.com/cpi/data.csv", "https://ons-imf.s3.example.com/cpi/data.csv"}
+		if len(resp.PublicURLs) != 2 || resp.PublicURLs[0] != want[0] || resp.PublicURLs[1] != want[1] {
+			t.Fatalf("expected public URLs %v, got %v", want, resp.PublicURLs)
+		}
+	})
+
+	t.Run("Cloudflare is purged with the full public URL, not a bare path", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		var purged []string
+		cloudflare := &apimock.CloudflareClientMock{
+			PurgeCacheFunc: func(ctx context.Context, urls []string) error { purged = urls; return nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithCloudflare(cloudflare),
+			api.WithPublicURLTemplates([]string{"https://cdn.example.com/%s"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		want := []string{"https://cdn.example.com/cpi/data.csv"}
+		if len(purged) != 1 || purged[0] != want[0] {
+			t.Fatalf("expected Cloudflare purged with %v, got %v", want, purged)
+		}
+	})
+
+	t.Run("Cloudflare purge is skipped when no public URL template is configured", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		cloudflare := &apimock.CloudflareClientMock{
+			PurgeCacheFunc: func(ctx context.Context, urls []string) error {
+				t.Fatalf("expected no purge attempt without a known public URL")
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithCloudflare(cloudflare))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("a failed Cloudflare purge is queued for retry instead of failing the publish", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		cloudflare := &apimock.CloudflareClientMock{
+			PurgeCacheFunc: func(ctx context.Context, urls []string) error { return errors.New("cloudflare unavailable") },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithCloudflare(cloudflare),
+			api.WithPublicURLTemplates([]string{"https://cdn.example.com/%s"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected the publish to succeed despite the purge failure, got status %d", rec.Code)
+		}
+		if upload.PurgeRetry == nil {
+			t.Fatal("expected a PurgeRetry to be queued")
+		}
+		if len(upload.PurgeRetry.URLs) != 1 || upload.PurgeRetry.URLs[0] != "https://cdn.example.com/cpi/data.csv" {
+			t.Fatalf("unexpected PurgeRetry URLs: %v", upload.PurgeRetry.URLs)
+		}
+		if upload.PurgeRetry.LastError != "cloudflare unavailable" {
+			t.Fatalf("expected the purge error to be recorded, got %q", upload.PurgeRetry.LastError)
+		}
+	})
+
+	t.Run("outside the publish window the upload is queued instead of published", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		var saved *models.Upload
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { saved = u; return nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error {
+				t.Fatalf("expected no attempt to publish outside the window")
+				return nil
+			},
+		}
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return false, nil
+			},
+		}
+		closedWindow := &api.PublishWindow{Start: closedWindowStart, End: closedWindowEnd, Location: time.UTC}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithPublishWindow(closedWindow), api.WithPermissionChecker(permissions))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected status 202, got %d", rec.Code)
+		}
+		if saved == nil || saved.Status != models.StatusScheduled {
+			t.Fatalf("expected the upload to be queued as scheduled, got %+v", saved)
+		}
+	})
+
+	t.Run("publish override permission publishes despite a closed window", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return true, nil
+			},
+		}
+		closedWindow := &api.PublishWindow{Start: closedWindowStart, End: closedWindowEnd, Location: time.UTC}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf),
+			api.WithPublishWindow(closedWindow), api.WithPermissionChecker(permissions))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if upload.Status != models.StatusApproved {
+			t.Fatalf("expected the override to publish immediately, got status %s", upload.Status)
+		}
+	})
+
+	t.Run("s3 failure returns 500 without invalidating", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return errors.New("access denied") },
+		}
+		h := api.NewHandler(store, api.WithS3(s3))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing checklist acknowledgement returns 400 without publishing", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error {
+				t.Fatalf("expected approval to be rejected before publishing")
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithApprovalChecklist([]string{"figures verified"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", strings.NewReader(`{"acknowledged":[]}`))
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		var body models.ValidationErrors
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Errors) != 1 || body.Errors[0].Rule != "acknowledgement_required" || body.Errors[0].Value != "figures verified" {
+			t.Fatalf("expected a structured acknowledgement_required error, got %+v", body.Errors)
+		}
+	})
+
+	t.Run("target_key override publishes under the new key and records the original", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		var publishedKey string
+		var savedUpload *models.Upload
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error {
+				savedUpload = u
+				return nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+				publishedKey = key
+				return nil
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", strings.NewReader(`{"target_key":"cpi/data-corrected.csv"}`))
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if publishedKey != "cpi/data-corrected.csv" {
+			t.Fatalf("expected publish under the corrected key, got %q", publishedKey)
+		}
+		if savedUpload.OriginalTargetKey != "cpi/data.csv" {
+			t.Fatalf("expected the original key to be recorded, got %q", savedUpload.OriginalTargetKey)
+		}
+	})
+
+	t.Run("target_key override outside the original directory returns 400", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error {
+				t.Fatalf("expected the rename to be rejected before publishing")
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", strings.NewReader(`{"target_key":"gdp/data.csv"}`))
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		var body models.ValidationErrors
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Errors) != 1 || body.Errors[0].Rule != "target_key_same_directory" || body.Errors[0].Value != "gdp/data.csv" {
+			t.Fatalf("expected a structured target_key_same_directory error, got %+v", body.Errors)
+		}
+	})
+
+	t.Run("blocks a reviewer from approving their own upload", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending, UploadedBy: "alex@example.com"}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error {
+				t.Fatalf("expected self-approval to be rejected before publishing")
+				return nil
+			},
+		}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithPreventSelfApproval(true))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", strings.NewReader(`{"reviewed_by":"Alex@Example.com"}`))
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		var body models.ValidationErrors
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Errors) != 1 || body.Errors[0].Rule != "self_approval_not_allowed" {
+			t.Fatalf("expected a structured self_approval_not_allowed error, got %+v", body.Errors)
+		}
+	})
+
+	t.Run("re-validation blocks approval when the staged file now fails a tightened rule", func(t *testing.T) {
+		tempStorage := temp.NewInMemoryStorage(1<<20, 0)
+		xlsm := "\x50\x4b\x03\x04" // enough of a zip signature to route to the xlsx validator; content itself doesn't matter here.
+		if err := tempStorage.Store(context.Background(), "staged/1", strings.NewReader(xlsm), int64(len(xlsm)), time.Hour); err != nil {
+			t.Fatalf("failed to seed temp storage: %v", err)
+		}
+
+		upload := &models.Upload{ID: "1", Filename: "data.xlsm", TargetKey: "cpi/data.xlsm", TempKey: "staged/1", Status: models.StatusPending}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error {
+				t.Fatalf("expected re-validation to reject the upload before publishing")
+				return nil
+			},
+		}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithTemp(tempStorage, time.Hour), api.WithRevalidateOnApprove(true))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		var body models.ValidationErrors
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Errors) == 0 {
+			t.Fatalf("expected findings from the current validator, got none")
+		}
+	})
+
+	t.Run("re-validation disabled by default lets a stale-but-clean staged file through", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestGetUpload(t *testing.T) {
+	t.Run("not found returns 404", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) {
+				return nil, errors.New("not found")
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/missing", nil)
+		req.SetPathValue("id", "missing")
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// permissionAPIKeys gates managing API keys, an operational action with a
+// much larger blast radius than any single review decision.
+const permissionAPIKeys = "imf:api-keys"
+
+// CreateAPIKeyResponse is the body returned by CreateAPIKey and
+// RotateAPIKey: the key record alongside the raw key, which is only ever
+// shown this once since only its hash is stored.
+type CreateAPIKeyResponse struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys, gated on permissionAPIKeys. It
+// generates a new key scoped to the given permissions, so an automation
+// client doesn't have to share the single service auth token.
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string   `json:"name"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rawKey := newAPIKey()
+	key := &models.APIKey{
+		ID:          newAPIKeyID(),
+		Name:        body.Name,
+		HashedKey:   hashAPIKey(rawKey),
+		Permissions: body.Permissions,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := h.Store.CreateAPIKey(r.Context(), key); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create api key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreateAPIKeyResponse{APIKey: key, Key: rawKey})
+}
+
+// ListAPIKeys handles GET /api/v1/api-keys, gated on permissionAPIKeys. The
+// raw key is never returned, only the metadata needed to audit and revoke.
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.Store.ListAPIKeys(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list api keys")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// RotateAPIKey handles POST /api/v1/api-keys/{id}/rotate, gated on
+// permissionAPIKeys. It replaces the key's hash with a freshly generated
+// one, invalidating the old raw key without disturbing its name or
+// permissions.
+func (h *Handler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	key, err := h.Store.GetAPIKey(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	rawKey := newAPIKey()
+	key.HashedKey = hashAPIKey(rawKey)
+	key.CreatedAt = time.Now()
+	key.RevokedAt = nil
+
+	if err := h.Store.UpdateAPIKey(r.Context(), key); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to rotate api key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CreateAPIKeyResponse{APIKey: key, Key: rawKey})
+}
+
+// RevokeAPIKey handles POST /api/v1/api-keys/{id}/revoke, gated on
+// permissionAPIKeys.
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	key, err := h.Store.GetAPIKey(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+
+	if err := h.Store.UpdateAPIKey(r.Context(), key); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to revoke api key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+// newAPIKeyID generates the record ID assigned to a new API key.
+func newAPIKeyID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// newAPIKey generates a new raw API key. Only its hash (see hashAPIKey) is
+// ever persisted.
+func newAPIKey() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// hashAPIKey returns the sha256 (hex-encoded) digest of a raw API key, the
+// form persisted by the store so a database compromise doesn't leak usable
+// keys.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	var created *models.APIKey
+	store := &storemock.StoreMock{
+		CreateAPIKeyFunc: func(ctx context.Context, key *models.APIKey) error {
+			created = key
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", strings.NewReader(`{"name":"weo-loader","permissions":["imf:purge"]}`))
+	rec := httptest.NewRecorder()
+
+	h.CreateAPIKey(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
+	if created == nil || created.Name != "weo-loader" || created.HashedKey == "" {
+		t.Fatalf("expected a hashed key to be persisted, got %+v", created)
+	}
+
+	var resp api.CreateAPIKeyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Key == "" {
+		t.Fatalf("expected the raw key to be returned once on creation")
+	}
+	if strings.Contains(rec.Body.String(), created.HashedKey) {
+		t.Fatalf("expected the hashed key never to be exposed in the response")
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	original := &models.APIKey{ID: "1", Name: "weo-loader", HashedKey: "old-hash", CreatedAt: time.Now().Add(-24 * time.Hour)}
+	var updated *models.APIKey
+	store := &storemock.StoreMock{
+		GetAPIKeyFunc:    func(ctx context.Context, id string) (*models.APIKey, error) { return original, nil },
+		UpdateAPIKeyFunc: func(ctx context.Context, key *models.APIKey) error { updated = key; return nil },
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys/1/rotate", nil)
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+
+	h.RotateAPIKey(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if updated == nil || updated.HashedKey == "old-hash" {
+		t.Fatalf("expected the key hash to be replaced, got %+v", updated)
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	key := &models.APIKey{ID: "1", Name: "weo-loader", HashedKey: "hash"}
+	var updated *models.APIKey
+	store := &storemock.StoreMock{
+		GetAPIKeyFunc:    func(ctx context.Context, id string) (*models.APIKey, error) { return key, nil },
+		UpdateAPIKeyFunc: func(ctx context.Context, k *models.APIKey) error { updated = k; return nil },
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys/1/revoke", nil)
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+
+	h.RevokeAPIKey(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if updated == nil || updated.RevokedAt == nil {
+		t.Fatalf("expected RevokedAt to be set, got %+v", updated)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// permissionAuditLog gates reading the audit log, since it can reveal
+// operational details (actors, request bodies) about every admin action.
+const permissionAuditLog = "imf:audit-log"
+
+// auditActorHeader carries the identity of the caller making a mutating
+// admin request, so AuditLog can attribute the change without depending on
+// endpoint-specific request bodies.
+const auditActorHeader = "Audit-Actor"
+
+// AuditLog wraps next, recording every mutating request whose path starts
+// with one of h.AuditPathPrefixes into the audit log (path, method, actor,
+// request body, response status). It is a best-effort record: a failure to
+// persist an entry never fails the request it's recording. Empty
+// h.AuditPathPrefixes disables auditing entirely, passing every request
+// straight through to next.
+func (h *Handler) AuditLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.auditable(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		entry := &models.AuditLogEntry{
+			Actor:       h.requestActor(r),
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Body:        string(body),
+			Status:      rec.status,
+			RecordedAt:  time.Now(),
+			Environment: h.Environment,
+		}
+		_ = h.Store.CreateAuditLogEntry(r.Context(), entry)
+	})
+}
+
+// requestActor builds the models.Actor attributed to r, so every audit
+// entry populates identity the same way regardless of which handler ran: a
+// service principal takes precedence (it's cryptographically verified via
+// Service-Auth-Token), then an explicit Audit-Actor override, then the
+// caller identity forwarded by a fronting proxy in X-User-Email. All three
+// may be empty, in which case AuthMethod is left blank too, so an entry
+// with no identity is distinguishable from one that failed to look it up.
+func (h *Handler) requestActor(r *http.Request) models.Actor {
+	if h.isServiceRequest(r) {
+		return models.Actor{ID: h.ServiceAuthPrincipal, DisplayName: h.ServiceAuthPrincipal, AuthMethod: "service"}
+	}
+	if actor := r.Header.Get(auditActorHeader); actor != "" {
+		return models.Actor{ID: actor, AuthMethod: "header"}
+	}
+	if email := callerIdentity(r); email != "" {
+		return models.Actor{Email: email, AuthMethod: "header"}
+	}
+	return models.Actor{}
+}
+
+// auditable reports whether r is a mutating request under one of
+// h.AuditPathPrefixes.
+func (h *Handler) auditable(r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		return false
+	}
+	for _, prefix := range h.AuditPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code
+// written by the handler it wraps, so AuditLog can record the outcome of a
+// request after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// ListAuditLogEntries handles GET /api/v1/admin/audit-log, gated on
+// permissionAuditLog.
+func (h *Handler) ListAuditLogEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.Store.ListAuditLogEntries(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list audit log entries")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
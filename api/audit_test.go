@@ -0,0 +1,135 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestAuditLog(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	t.Run("records a mutating request under an audited prefix", func(t *testing.T) {
+		var recorded *models.AuditLogEntry
+		store := &storemock.StoreMock{
+			CreateAuditLogEntryFunc: func(ctx context.Context, entry *models.AuditLogEntry) error {
+				recorded = entry
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithAuditLog([]string{"/api/v1/admin"}))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/maintenance", strings.NewReader(`{"enabled":true}`))
+		req.Header.Set("Audit-Actor", "jdoe")
+		rec := httptest.NewRecorder()
+
+		h.AuditLog(next).ServeHTTP(rec, req)
+
+		if recorded == nil {
+			t.Fatalf("expected an audit log entry to be recorded")
+		}
+		if recorded.Actor != (models.Actor{ID: "jdoe", AuthMethod: "header"}) || recorded.Method != http.MethodPut || recorded.Path != "/api/v1/admin/maintenance" {
+			t.Fatalf("unexpected entry: %+v", recorded)
+		}
+		if recorded.Status != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d", recorded.Status)
+		}
+		if recorded.Body != `{"enabled":true}` {
+			t.Fatalf("expected the request body to be captured, got %q", recorded.Body)
+		}
+	})
+
+	t.Run("falls back to the forwarded caller identity when no Audit-Actor is set", func(t *testing.T) {
+		var recorded *models.AuditLogEntry
+		store := &storemock.StoreMock{
+			CreateAuditLogEntryFunc: func(ctx context.Context, entry *models.AuditLogEntry) error {
+				recorded = entry
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithAuditLog([]string{"/api/v1/admin"}))
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/maintenance", nil)
+		req.Header.Set("X-User-Email", "jdoe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.AuditLog(next).ServeHTTP(rec, req)
+
+		if recorded == nil || recorded.Actor != (models.Actor{Email: "jdoe@example.com", AuthMethod: "header"}) {
+			t.Fatalf("unexpected entry: %+v", recorded)
+		}
+	})
+
+	t.Run("attributes a service-authenticated request to the configured principal", func(t *testing.T) {
+		var recorded *models.AuditLogEntry
+		store := &storemock.StoreMock{
+			CreateAuditLogEntryFunc: func(ctx context.Context, entry *models.AuditLogEntry) error {
+				recorded = entry
+				return nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithAuditLog([]string{"/api/v1/admin"}),
+			api.WithServiceAuth("secret-token", "pipeline:weo-loader"),
+		)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/maintenance", nil)
+		req.Header.Set("Service-Auth-Token", "secret-token")
+		rec := httptest.NewRecorder()
+
+		h.AuditLog(next).ServeHTTP(rec, req)
+
+		want := models.Actor{ID: "pipeline:weo-loader", DisplayName: "pipeline:weo-loader", AuthMethod: "service"}
+		if recorded == nil || recorded.Actor != want {
+			t.Fatalf("unexpected entry: %+v", recorded)
+		}
+	})
+
+	t.Run("does not record a request outside the audited prefixes", func(t *testing.T) {
+		called := false
+		store := &storemock.StoreMock{
+			CreateAuditLogEntryFunc: func(ctx context.Context, entry *models.AuditLogEntry) error {
+				called = true
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithAuditLog([]string{"/api/v1/admin"}))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.AuditLog(next).ServeHTTP(rec, req)
+
+		if called {
+			t.Fatalf("expected no audit log entry to be recorded")
+		}
+	})
+
+	t.Run("does not record a read-only request", func(t *testing.T) {
+		called := false
+		store := &storemock.StoreMock{
+			CreateAuditLogEntryFunc: func(ctx context.Context, entry *models.AuditLogEntry) error {
+				called = true
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithAuditLog([]string{"/api/v1/admin"}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/maintenance", nil)
+		rec := httptest.NewRecorder()
+
+		h.AuditLog(next).ServeHTTP(rec, req)
+
+		if called {
+			t.Fatalf("expected no audit log entry to be recorded")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// permissionCDNStatus gates the CDN status diagnostics endpoint, an
+// operational action distinct from the review permissions implied by
+// approving or rejecting an upload.
+const permissionCDNStatus = "imf:cdn-status"
+
+// CDNStatusResponse reports the CloudFront distribution and Cloudflare
+// zone configuration the service currently depends on. Either field is
+// nil when the configured backend doesn't support the corresponding
+// optional-capability interface (e.g. a fake used in tests, or no backend
+// configured at all), rather than reporting a misleading empty summary.
+type CDNStatusResponse struct {
+	CloudFront *DistributionSummary `json:"cloudfront,omitempty"`
+	Cloudflare *ZoneSummary         `json:"cloudflare,omitempty"`
+}
+
+// GetCDNStatus handles GET /api/v1/admin/cdn/status, gated on
+// permissionCDNStatus, fetching a live configuration summary from
+// whichever of h.CloudFront and h.Cloudflare support the corresponding
+// inspection capability, so an operator can confirm the service points at
+// the right distribution and zone before a release. It queries live
+// rather than serving a cached result, consistent with GetPreflight.
+func (h *Handler) GetCDNStatus(w http.ResponseWriter, r *http.Request) {
+	var resp CDNStatusResponse
+
+	if inspector, ok := h.CloudFront.(DistributionInspector); ok {
+		summary, err := inspector.DistributionStatus(r.Context())
+		if err != nil {
+			writeError(w, http.StatusBadGateway, "failed to fetch CloudFront distribution status")
+			return
+		}
+		resp.CloudFront = &summary
+	}
+
+	if inspector, ok := h.Cloudflare.(ZoneInspector); ok {
+		summary, err := inspector.ZoneStatus(r.Context())
+		if err != nil {
+			writeError(w, http.StatusBadGateway, "failed to fetch Cloudflare zone status")
+			return
+		}
+		resp.Cloudflare = &summary
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
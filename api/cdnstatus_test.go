@@ -0,0 +1,113 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+// fakeCloudFrontWithCapabilities embeds the moq-generated CloudFrontClientMock
+// alongside the optional DistributionInspector mock, mirroring
+// fakeS3WithCapabilities.
+type fakeCloudFrontWithCapabilities struct {
+	*apimock.CloudFrontClientMock
+	*apimock.DistributionInspectorMock
+}
+
+func newFakeCloudFront() *fakeCloudFrontWithCapabilities {
+	return &fakeCloudFrontWithCapabilities{
+		CloudFrontClientMock:      &apimock.CloudFrontClientMock{},
+		DistributionInspectorMock: &apimock.DistributionInspectorMock{},
+	}
+}
+
+// fakeCloudflareWithCapabilities embeds the moq-generated CloudflareClientMock
+// alongside the optional ZoneInspector mock, mirroring fakeS3WithCapabilities.
+type fakeCloudflareWithCapabilities struct {
+	*apimock.CloudflareClientMock
+	*apimock.ZoneInspectorMock
+}
+
+func newFakeCloudflare() *fakeCloudflareWithCapabilities {
+	return &fakeCloudflareWithCapabilities{
+		CloudflareClientMock: &apimock.CloudflareClientMock{},
+		ZoneInspectorMock:    &apimock.ZoneInspectorMock{},
+	}
+}
+
+func TestGetCDNStatus(t *testing.T) {
+	t.Run("reports a summary for each backend that supports inspection", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		cf := newFakeCloudFront()
+		cf.DistributionStatusFunc = func(context.Context) (api.DistributionSummary, error) {
+			return api.DistributionSummary{ID: "E123", DomainName: "d123.cloudfront.net", Status: "Deployed"}, nil
+		}
+		cloudflare := newFakeCloudflare()
+		cloudflare.ZoneStatusFunc = func(context.Context) (api.ZoneSummary, error) {
+			return api.ZoneSummary{ID: "z1", Name: "example.com", Status: "active"}, nil
+		}
+		h := api.NewHandler(store, api.WithCloudFront(cf), api.WithCloudflare(cloudflare))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/cdn/status", nil)
+		rec := httptest.NewRecorder()
+		h.GetCDNStatus(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var resp api.CDNStatusResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.CloudFront == nil || resp.CloudFront.ID != "E123" {
+			t.Fatalf("expected a CloudFront summary, got %+v", resp.CloudFront)
+		}
+		if resp.Cloudflare == nil || resp.Cloudflare.ID != "z1" {
+			t.Fatalf("expected a Cloudflare summary, got %+v", resp.Cloudflare)
+		}
+	})
+
+	t.Run("omits a backend that doesn't support inspection", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/cdn/status", nil)
+		rec := httptest.NewRecorder()
+		h.GetCDNStatus(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var resp api.CDNStatusResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.CloudFront != nil || resp.Cloudflare != nil {
+			t.Fatalf("expected no summaries when nothing is configured, got %+v", resp)
+		}
+	})
+
+	t.Run("returns 502 when a backend's inspection fails", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		cf := newFakeCloudFront()
+		cf.DistributionStatusFunc = func(context.Context) (api.DistributionSummary, error) {
+			return api.DistributionSummary{}, errors.New("access denied")
+		}
+		h := api.NewHandler(store, api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/cdn/status", nil)
+		rec := httptest.NewRecorder()
+		h.GetCDNStatus(rec, req)
+
+		if rec.Code != http.StatusBadGateway {
+			t.Fatalf("expected status 502, got %d", rec.Code)
+		}
+	})
+}
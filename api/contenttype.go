@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// contentTypeByExtension maps the lowercased file extension (including the
+// leading dot) of a published object's key to the Content-Type it should
+// be served with. An extension outside this table falls back to
+// "application/octet-stream", the same default S3 uses when no
+// Content-Type is set at all.
+var contentTypeByExtension = map[string]string{
+	".csv":  "text/csv",
+	".json": "application/json",
+	".pdf":  "application/pdf",
+	".xls":  "application/vnd.ms-excel",
+	".xlsm": "application/vnd.ms-excel.sheet.macroEnabled.12",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".zip":  "application/zip",
+}
+
+// contentTypeForKey returns the Content-Type to publish key with, derived
+// from its file extension.
+func contentTypeForKey(key string) string {
+	if ct, ok := contentTypeByExtension[strings.ToLower(path.Ext(key))]; ok {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// contentDispositionForKey returns the Content-Disposition to publish key
+// with, built from h.ContentDispositionTemplate, or "" if none is
+// configured.
+func (h *Handler) contentDispositionForKey(key string) string {
+	if h.ContentDispositionTemplate == "" {
+		return ""
+	}
+	return fmt.Sprintf(h.ContentDispositionTemplate, path.Base(key))
+}
+
+// cacheControlForKey returns the Cache-Control header to publish key with:
+// dataset's DatasetProfile.CacheControl if one is configured, else
+// h.CacheControlByExtension keyed by key's file extension, else "" to
+// leave Cache-Control unset. A nil h.CacheControlByExtension means the
+// feature is not configured at all, so the DatasetProfile lookup is
+// skipped entirely.
+func (h *Handler) cacheControlForKey(ctx context.Context, dataset, key string) string {
+	if h.CacheControlByExtension == nil {
+		return ""
+	}
+	if profile, err := h.Store.GetDatasetProfile(ctx, dataset); err == nil && profile.CacheControl != "" {
+		return profile.CacheControl
+	}
+	return h.CacheControlByExtension[strings.ToLower(path.Ext(key))]
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORS wraps next with CORS headers computed from h.CORSAllowedOrigins,
+// h.CORSAllowedMethods, h.CORSAllowedHeaders and h.CORSMaxAge, so the
+// review dashboard (a different origin) can call the API from the browser.
+// Empty h.CORSAllowedOrigins disables CORS entirely, passing every request
+// straight through to next.
+func (h *Handler) CORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !h.originAllowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if len(h.CORSAllowedMethods) > 0 {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(h.CORSAllowedMethods, ", "))
+		}
+		if len(h.CORSAllowedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(h.CORSAllowedHeaders, ", "))
+		}
+		if h.CORSMaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(h.CORSMaxAge.Seconds())))
+		}
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// originAllowed reports whether origin is present in h.CORSAllowedOrigins,
+// or "*" is configured to allow any origin.
+func (h *Handler) originAllowed(origin string) bool {
+	for _, allowed := range h.CORSAllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestCORS(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("adds headers and passes through a request from an allowed origin", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithCORS(
+			[]string{"https://dashboard.example.com"}, []string{"GET", "POST"}, []string{"Content-Type"}, 5*time.Minute,
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		req.Header.Set("Origin", "https://dashboard.example.com")
+		rec := httptest.NewRecorder()
+
+		h.CORS(next).ServeHTTP(rec, req)
+
+		if !called {
+			t.Fatalf("expected the wrapped handler to run")
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://dashboard.example.com" {
+			t.Fatalf("expected Access-Control-Allow-Origin to be echoed, got %q", got)
+		}
+		if got := rec.Header().Get("Access-Control-Max-Age"); got != "300" {
+			t.Fatalf("expected Access-Control-Max-Age of 300, got %q", got)
+		}
+	})
+
+	t.Run("short-circuits a preflight request with 204", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithCORS(
+			[]string{"https://dashboard.example.com"}, []string{"GET", "POST"}, []string{"Content-Type"}, 5*time.Minute,
+		))
+
+		req := httptest.NewRequest(http.MethodOptions, "/uploads", nil)
+		req.Header.Set("Origin", "https://dashboard.example.com")
+		rec := httptest.NewRecorder()
+
+		h.CORS(next).ServeHTTP(rec, req)
+
+		if called {
+			t.Fatalf("expected the wrapped handler not to run for a preflight request")
+		}
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", rec.Code)
+		}
+	})
+
+	t.Run("does not add headers for an origin that isn't allowed", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithCORS(
+			[]string{"https://dashboard.example.com"}, nil, nil, 0,
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec := httptest.NewRecorder()
+
+		h.CORS(next).ServeHTTP(rec, req)
+
+		if !called {
+			t.Fatalf("expected the wrapped handler to run")
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Fatalf("expected no Access-Control-Allow-Origin header, got %q", got)
+		}
+	})
+}
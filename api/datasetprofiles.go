@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// permissionDatasetProfiles gates managing per-dataset configuration
+// profiles.
+const permissionDatasetProfiles = "imf:dataset-profiles"
+
+// ListDatasetProfiles handles GET /api/v1/admin/dataset-profiles.
+func (h *Handler) ListDatasetProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.Store.ListDatasetProfiles(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list dataset profiles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+// SetDatasetProfile handles PUT /api/v1/admin/dataset-profiles/{dataset},
+// creating the profile if it doesn't already exist or replacing its fields
+// otherwise.
+func (h *Handler) SetDatasetProfile(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		S3Prefix            string   `json:"s3_prefix"`
+		CDNPathPrefix       string   `json:"cdn_path_prefix"`
+		RequiredReviewers   []string `json:"required_reviewers"`
+		NotificationChannel string   `json:"notification_channel"`
+		CacheControl        string   `json:"cache_control"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	profile := &models.DatasetProfile{
+		Dataset:             r.PathValue("dataset"),
+		S3Prefix:            body.S3Prefix,
+		CDNPathPrefix:       body.CDNPathPrefix,
+		RequiredReviewers:   body.RequiredReviewers,
+		NotificationChannel: body.NotificationChannel,
+		CacheControl:        body.CacheControl,
+	}
+	if err := h.Store.SetDatasetProfile(r.Context(), profile); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to set dataset profile")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+// DeleteDatasetProfile handles DELETE /api/v1/admin/dataset-profiles/{dataset}.
+func (h *Handler) DeleteDatasetProfile(w http.ResponseWriter, r *http.Request) {
+	if err := h.Store.DeleteDatasetProfile(r.Context(), r.PathValue("dataset")); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete dataset profile")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
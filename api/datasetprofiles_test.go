@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestListDatasetProfiles(t *testing.T) {
+	store := &storemock.StoreMock{
+		ListDatasetProfilesFunc: func(context.Context) ([]*models.DatasetProfile, error) {
+			return []*models.DatasetProfile{{Dataset: "gdp", S3Prefix: "datasets/gdp/"}}, nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/dataset-profiles", nil)
+	rec := httptest.NewRecorder()
+
+	h.ListDatasetProfiles(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var profiles []*models.DatasetProfile
+	if err := json.NewDecoder(rec.Body).Decode(&profiles); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Dataset != "gdp" {
+		t.Fatalf("unexpected profiles: %+v", profiles)
+	}
+}
+
+func TestSetDatasetProfile(t *testing.T) {
+	var recorded *models.DatasetProfile
+	store := &storemock.StoreMock{
+		SetDatasetProfileFunc: func(_ context.Context, profile *models.DatasetProfile) error {
+			recorded = profile
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/dataset-profiles/gdp",
+		strings.NewReader(`{"s3_prefix":"datasets/gdp/","required_reviewers":["reviewer@example.com"],"cache_control":"no-cache"}`))
+	req.SetPathValue("dataset", "gdp")
+	rec := httptest.NewRecorder()
+
+	h.SetDatasetProfile(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if recorded == nil || recorded.Dataset != "gdp" || recorded.S3Prefix != "datasets/gdp/" || len(recorded.RequiredReviewers) != 1 || recorded.CacheControl != "no-cache" {
+		t.Fatalf("unexpected profile recorded: %+v", recorded)
+	}
+}
+
+func TestDeleteDatasetProfile(t *testing.T) {
+	var deleted string
+	store := &storemock.StoreMock{
+		DeleteDatasetProfileFunc: func(_ context.Context, dataset string) error {
+			deleted = dataset
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/dataset-profiles/gdp", nil)
+	req.SetPathValue("dataset", "gdp")
+	rec := httptest.NewRecorder()
+
+	h.DeleteDatasetProfile(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if deleted != "gdp" {
+		t.Fatalf("expected gdp to be deleted, got %q", deleted)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// GetUploadDiff handles GET /api/v1/uploads/{id}/diff. It downloads the file
+// currently published at the upload's TargetKey, compares it structurally
+// against the staged replacement, and caches the result on the upload
+// record so repeat requests don't re-download and re-diff.
+func (h *Handler) GetUploadDiff(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if upload.DiffSummary != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(upload.DiffSummary)
+		return
+	}
+
+	if h.S3 == nil || h.Temp == nil {
+		writeError(w, http.StatusServiceUnavailable, "diffing is not configured")
+		return
+	}
+
+	published, err := readAllFrom(func() (io.ReadCloser, error) { return h.S3.DownloadFile(r.Context(), upload.TargetKey) })
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to download published file")
+		return
+	}
+
+	incoming, err := readAllFrom(func() (io.ReadCloser, error) { return h.Temp.Get(r.Context(), upload.TempKey) })
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read staged file")
+		return
+	}
+
+	summary := diffByExtension(upload.Filename, published, incoming)
+	upload.DiffSummary = &summary
+
+	if err := h.Store.UpdateUpload(r.Context(), upload); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to cache diff summary")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+func readAllFrom(open func() (io.ReadCloser, error)) ([]byte, error) {
+	rc, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func diffByExtension(filename string, published, incoming []byte) validate.DiffSummary {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return validate.DiffCSV(published, incoming)
+	case ".xlsx", ".xlsm":
+		return validate.DiffXLSX(published, incoming)
+	case ".pdf":
+		return validate.DiffPDF(published, incoming)
+	default:
+		return validate.DiffSummary{SizeDelta: int64(len(incoming)) - int64(len(published))}
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import "net/http"
+
+// permissionDigest gates manually triggering the daily digest, normally
+// posted by an external scheduler rather than a human caller.
+const permissionDigest = "imf:digest"
+
+// RunDigest handles POST /api/v1/digest/run, posting the daily backlog
+// summary (pending count, oldest pending upload, yesterday's
+// approvals/rejections, and current failures) via h.Digest. It is intended
+// to be triggered once a day by an external scheduler configured with
+// config.DigestConfig's cron expression and timezone, since this repo has
+// no long-running process wiring of its own yet — the same pattern
+// PublishScheduled uses for the publish window. 501 if no Digest is
+// configured.
+func (h *Handler) RunDigest(w http.ResponseWriter, r *http.Request) {
+	if h.Digest == nil {
+		writeError(w, http.StatusNotImplemented, "digest is not configured")
+		return
+	}
+
+	if err := h.Digest.Run(r.Context()); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to run daily digest")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// GetUploadFile handles GET /api/v1/uploads/{id}/file, streaming the
+// staged file for a pending upload so a reviewer can preview it without
+// waiting for the whole thing to download. A "bytes=start-end" Range
+// header is honoured, returning 206 Partial Content, when the configured
+// Temp backend supports temp.RangeReader; otherwise (no Range header, an
+// unsupported Range value, or a backend that can't range-read) the full
+// file is streamed with a 200.
+func (h *Handler) GetUploadFile(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if h.Temp == nil || upload.TempKey == "" {
+		writeError(w, http.StatusNotFound, "no staged file for this upload")
+		return
+	}
+
+	rangeReader, canRangeRead := h.Temp.(temp.RangeReader)
+	offset, length, wantsRange := parseRangeHeader(r.Header.Get("Range"))
+
+	if !wantsRange || !canRangeRead {
+		rc, err := h.Temp.Get(r.Context(), upload.TempKey)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to read staged file")
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", contentTypeForKey(upload.Filename))
+		_, _ = io.Copy(w, rc)
+		return
+	}
+
+	rc, err := rangeReader.GetRange(r.Context(), upload.TempKey, offset, length)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read staged file range")
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", contentTypeForKey(upload.Filename))
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/*", offset, offset+length-1))
+	w.WriteHeader(http.StatusPartialContent)
+	_, _ = io.Copy(w, rc)
+}
+
+// parseRangeHeader parses a single fully-specified "bytes=start-end" Range
+// header value into an offset and length. Anything else — no header, a
+// multi-range request, or an open-ended "bytes=start-" range — is reported
+// as no range (wantsRange false), falling back to a full response, since
+// Content-Range requires a known end to be well-formed.
+func parseRangeHeader(header string) (offset, length int64, wantsRange bool) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) || strings.Contains(header, ",") {
+		return 0, 0, false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(header, prefix), "-", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+
+	end, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+
+	return start, end - start + 1, true
+}
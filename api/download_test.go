@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestGetUploadFile(t *testing.T) {
+	t.Run("streams a byte range when the Temp backend supports it", func(t *testing.T) {
+		diskStorage, err := temp.NewDiskStorage(t.TempDir(), 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := diskStorage.Store(context.Background(), "staged/1.csv", strings.NewReader("hello world"), 11, time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		upload := &models.Upload{ID: "1", Filename: "1.csv", TempKey: "staged/1.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(diskStorage, time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/1/file", nil)
+		req.SetPathValue("id", "1")
+		req.Header.Set("Range", "bytes=6-10")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadFile(rec, req)
+
+		if rec.Code != http.StatusPartialContent {
+			t.Fatalf("expected status 206, got %d", rec.Code)
+		}
+		if rec.Body.String() != "world" {
+			t.Fatalf("expected the requested byte range, got %q", rec.Body.String())
+		}
+		if got := rec.Header().Get("Content-Range"); got != "bytes 6-10/*" {
+			t.Fatalf("unexpected Content-Range header: %q", got)
+		}
+	})
+
+	t.Run("falls back to the full file when the Temp backend can't range-read", func(t *testing.T) {
+		memStorage := temp.NewInMemoryStorage(1<<20, 0)
+		if err := memStorage.Store(context.Background(), "staged/1.csv", strings.NewReader("hello world"), 11, time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		upload := &models.Upload{ID: "1", Filename: "1.csv", TempKey: "staged/1.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(memStorage, time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/1/file", nil)
+		req.SetPathValue("id", "1")
+		req.Header.Set("Range", "bytes=6-10")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadFile(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if rec.Body.String() != "hello world" {
+			t.Fatalf("expected the full file, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("returns 404 when the upload has no staged file", func(t *testing.T) {
+		upload := &models.Upload{ID: "1"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/1/file", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadFile(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
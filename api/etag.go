@@ -0,0 +1,40 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// writeJSONWithETag encodes v as JSON, tags the response with a weak ETag
+// derived from the encoded bytes, and answers 304 Not Modified with no body
+// when r's If-None-Match already matches — letting polling dashboards and
+// the SDK's WaitForStatus (see GetUploadStatus) skip re-fetching a payload
+// that hasn't changed since their last request.
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	etag := etagFor(body)
+	w.Header().Set("ETag", etag)
+
+	if match := r.Header.Get("If-None-Match"); match != "" && match == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(body)
+	return err
+}
+
+// etagFor derives a weak ETag from a SHA-256 hash of body, so only
+// byte-identical encodings share a tag. json.Marshal sorts map keys, so
+// re-marshaling an unchanged value yields the same tag.
+func etagFor(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
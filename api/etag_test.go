@@ -0,0 +1,39 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestListUploads_ETag(t *testing.T) {
+	store := &storemock.StoreMock{
+		ListUploadsFunc: func(context.Context, string) ([]*models.Upload, error) {
+			return []*models.Upload{{ID: "1"}}, nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+	rec := httptest.NewRecorder()
+	h.ListUploads(rec, req)
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag header")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/uploads", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ListUploads(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304, got %d", rec.Code)
+	}
+}
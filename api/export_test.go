@@ -0,0 +1,98 @@
+package api_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestExportUploads(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	reviewed := created.Add(2 * time.Hour)
+	upload := &models.Upload{
+		ID: "1", Dataset: "weo", Status: models.StatusApproved, ReviewedBy: "reviewer@example.com",
+		CreatedAt: created, UpdatedAt: reviewed,
+	}
+
+	t.Run("streams matching uploads as CSV with the computed turnaround by default", func(t *testing.T) {
+		var gotFilter models.UploadFilter
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				gotFilter = filter
+				return []*models.Upload{upload}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/export?dataset=weo&status=approved", nil)
+		rec := httptest.NewRecorder()
+
+		h.ExportUploads(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if gotFilter.Dataset != "weo" || len(gotFilter.Statuses) != 1 || gotFilter.Statuses[0] != models.StatusApproved {
+			t.Fatalf("unexpected filter: %+v", gotFilter)
+		}
+
+		rows, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("failed to parse CSV: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("expected a header row and one data row, got %d rows", len(rows))
+		}
+		if got := rows[1][len(rows[1])-1]; got != "7200" {
+			t.Fatalf("expected review_turnaround_seconds of 7200, got %q", got)
+		}
+	})
+
+	t.Run("streams NDJSON when format=ndjson", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/export?format=ndjson", nil)
+		rec := httptest.NewRecorder()
+
+		h.ExportUploads(rec, req)
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		if len(lines) != 1 {
+			t.Fatalf("expected exactly one NDJSON line, got %d", len(lines))
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+			t.Fatalf("failed to decode NDJSON line: %v", err)
+		}
+		if record["id"] != "1" {
+			t.Fatalf("unexpected record: %+v", record)
+		}
+	})
+
+	t.Run("rejects an unrecognised format", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/export?format=xml", nil)
+		rec := httptest.NewRecorder()
+
+		h.ExportUploads(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
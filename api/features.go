@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Known feature flag names, gating behaviours risky enough to want a
+// per-environment kill switch rather than a redeploy.
+const (
+	FeatureAsyncApproval     = "async-approval"
+	FeatureVirusScanning     = "virus-scanning"
+	FeatureTwoPersonApproval = "two-person-approval"
+)
+
+// permissionFeatureFlags gates reading and toggling feature flags.
+const permissionFeatureFlags = "imf:feature-flags"
+
+// FeatureEnabled reports whether the named feature flag is enabled. A Store
+// error is treated as disabled, so a lookup failure fails safe rather than
+// silently turning on a risky feature.
+func (h *Handler) FeatureEnabled(ctx context.Context, name string) bool {
+	flag, err := h.Store.GetFeatureFlag(ctx, name)
+	if err != nil {
+		return false
+	}
+	return flag.Enabled
+}
+
+// ListFeatureFlags handles GET /api/v1/admin/feature-flags.
+func (h *Handler) ListFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.Store.ListFeatureFlags(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list feature flags")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flags)
+}
+
+// SetFeatureFlag handles PUT /api/v1/admin/feature-flags/{name}.
+func (h *Handler) SetFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Enabled   bool   `json:"enabled"`
+		UpdatedBy string `json:"updated_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	flag := &models.FeatureFlag{
+		Name:      r.PathValue("name"),
+		Enabled:   body.Enabled,
+		UpdatedBy: body.UpdatedBy,
+	}
+	if err := h.Store.SetFeatureFlag(r.Context(), flag); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to set feature flag")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flag)
+}
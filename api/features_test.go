@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestFeatureEnabled(t *testing.T) {
+	t.Run("reports enabled flags as on", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetFeatureFlagFunc: func(ctx context.Context, name string) (*models.FeatureFlag, error) {
+				return &models.FeatureFlag{Name: name, Enabled: name == api.FeatureVirusScanning}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		if !h.FeatureEnabled(context.Background(), api.FeatureVirusScanning) {
+			t.Fatalf("expected %s to be enabled", api.FeatureVirusScanning)
+		}
+		if h.FeatureEnabled(context.Background(), api.FeatureAsyncApproval) {
+			t.Fatalf("expected %s to be disabled", api.FeatureAsyncApproval)
+		}
+	})
+
+	t.Run("a store error fails safe as disabled", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetFeatureFlagFunc: func(ctx context.Context, name string) (*models.FeatureFlag, error) {
+				return nil, context.DeadlineExceeded
+			},
+		}
+		h := api.NewHandler(store)
+
+		if h.FeatureEnabled(context.Background(), api.FeatureTwoPersonApproval) {
+			t.Fatalf("expected a store error to be treated as disabled")
+		}
+	})
+}
+
+func TestSetFeatureFlag(t *testing.T) {
+	var recorded *models.FeatureFlag
+	store := &storemock.StoreMock{
+		SetFeatureFlagFunc: func(ctx context.Context, flag *models.FeatureFlag) error {
+			recorded = flag
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/feature-flags/"+api.FeatureAsyncApproval,
+		strings.NewReader(`{"enabled":true,"updated_by":"j.doe"}`))
+	req.SetPathValue("name", api.FeatureAsyncApproval)
+	rec := httptest.NewRecorder()
+
+	h.SetFeatureFlag(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if recorded == nil || recorded.Name != api.FeatureAsyncApproval || !recorded.Enabled {
+		t.Fatalf("expected the async-approval flag to be enabled, got %+v", recorded)
+	}
+
+	var resp models.FeatureFlag
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Name != api.FeatureAsyncApproval {
+		t.Fatalf("expected the response to echo the flag name, got %+v", resp)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// permissionGreylist gates viewing and clearing greylisted uploaders, an
+// operational action distinct from the review permissions implied by
+// approving or rejecting an upload.
+const permissionGreylist = "imf:greylist"
+
+// greylist tracks recent upload validation failures per identity (an
+// UploadedBy value), so CreateUpload can temporarily block an identity that
+// keeps submitting invalid files instead of letting them flood the review
+// queue and its notifications with repeated rejections.
+type greylist struct {
+	mu       sync.Mutex
+	failures map[string][]time.Time
+}
+
+func newGreylist() greylist {
+	return greylist{failures: make(map[string][]time.Time)}
+}
+
+// recordFailure appends a failure at now for identity and returns how many
+// failures remain within window of now, pruning any that have aged out.
+func (g *greylist) recordFailure(identity string, now time.Time, window time.Duration) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	failures := append(pruneBefore(g.failures[identity], now.Add(-window)), now)
+	g.failures[identity] = failures
+	return len(failures)
+}
+
+// blocked reports whether identity has at least threshold failures within
+// window of now, pruning any that have aged out along the way.
+func (g *greylist) blocked(identity string, now time.Time, window time.Duration, threshold int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	failures := pruneBefore(g.failures[identity], now.Add(-window))
+	g.failures[identity] = failures
+	return len(failures) >= threshold
+}
+
+// clear removes every recorded failure for identity, lifting any block on
+// them regardless of how recent those failures were.
+func (g *greylist) clear(identity string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.failures, identity)
+}
+
+// pruneBefore returns the subset of times that fall after cutoff.
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	var kept []time.Time
+	for _, t := range times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// recordUploadFailure records a validation failure for identity, and
+// notifies admins the moment it pushes identity's failure count within
+// h.GreylistWindow up to h.GreylistThreshold, so the block is flagged as
+// soon as it takes effect rather than being discovered only when the
+// uploader's next upload is rejected. A no-op when greylisting is
+// unconfigured or identity is unknown.
+func (h *Handler) recordUploadFailure(ctx context.Context, identity string) {
+	if h.GreylistThreshold <= 0 || identity == "" {
+		return
+	}
+	if count := h.greylist.recordFailure(identity, time.Now(), h.GreylistWindow); count == h.GreylistThreshold {
+		h.notifyError(ctx, "", "", fmt.Sprintf(
+			"uploads from %s greylisted after %d failed validations within %s", identity, count, h.GreylistWindow))
+	}
+}
+
+// ClearGreylist handles DELETE /api/v1/admin/greylist/{identity}, lifting a
+// block recordUploadFailure placed on identity so they can upload again
+// immediately, rather than waiting for GreylistWindow to age their
+// failures out on its own.
+func (h *Handler) ClearGreylist(w http.ResponseWriter, r *http.Request) {
+	h.greylist.clear(r.PathValue("identity"))
+	w.WriteHeader(http.StatusNoContent)
+}
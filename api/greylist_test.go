@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func newInvalidFilenameUploadRequest(t *testing.T, identity string) *http.Request {
+	t.Helper()
+	req := newCreateUploadRequest(t, "weo", "not-the-right-name.csv")
+	if identity != "" {
+		req.Header.Set("X-User-Email", identity)
+	}
+	return req
+}
+
+func TestCreateUpload_Greylist(t *testing.T) {
+	t.Run("blocks further uploads once the failure threshold is reached", func(t *testing.T) {
+		var notified []string
+		store := &storemock.StoreMock{
+			CreateNotificationFunc: func(ctx context.Context, notification *models.Notification) error { return nil },
+		}
+		notifier := &apimock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, message string) error {
+				notified = append(notified, message)
+				return nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithFilenamePolicy(weoFilenamePolicy()),
+			api.WithGreylist(2, time.Hour),
+			api.WithNotifier(notifier),
+		)
+
+		for i := 0; i < 2; i++ {
+			rec := httptest.NewRecorder()
+			h.CreateUpload(rec, newInvalidFilenameUploadRequest(t, "j.doe@example.com"))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400 on failing attempt %d, got %d", i+1, rec.Code)
+			}
+		}
+
+		if len(notified) != 1 {
+			t.Fatalf("expected admins to be notified exactly once, got %d notifications: %v", len(notified), notified)
+		}
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected status 429 once greylisted, got %d", rec.Code)
+		}
+	})
+
+	t.Run("does not block a different identity", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateNotificationFunc: func(ctx context.Context, notification *models.Notification) error { return nil },
+			CreateUploadFunc:       func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithFilenamePolicy(weoFilenamePolicy()),
+			api.WithGreylist(1, time.Hour),
+		)
+
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, newInvalidFilenameUploadRequest(t, "j.doe@example.com"))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "a.other@example.com")
+		rec = httptest.NewRecorder()
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 for an unaffected identity, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("ClearGreylist lifts the block", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateNotificationFunc: func(ctx context.Context, notification *models.Notification) error { return nil },
+			CreateUploadFunc:       func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithFilenamePolicy(weoFilenamePolicy()),
+			api.WithGreylist(1, time.Hour),
+		)
+
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, newInvalidFilenameUploadRequest(t, "j.doe@example.com"))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+
+		clearReq := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/greylist/j.doe@example.com", nil)
+		clearReq.SetPathValue("identity", "j.doe@example.com")
+		clearRec := httptest.NewRecorder()
+		h.ClearGreylist(clearRec, clearReq)
+		if clearRec.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", clearRec.Code)
+		}
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec = httptest.NewRecorder()
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 after clearing, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HealthResponse reports whether the service is ready to accept writes.
+type HealthResponse struct {
+	Status             string `json:"status"`
+	Environment        string `json:"environment,omitempty"`
+	MaintenanceMode    bool   `json:"maintenance_mode"`
+	MaintenanceMessage string `json:"maintenance_message,omitempty"`
+	StoreOK            bool   `json:"store_ok"`
+}
+
+// GetHealth handles GET /api/v1/health, surfacing store connectivity and
+// maintenance mode so operators and load balancers can tell writes are
+// deliberately paused apart from an actual outage.
+func (h *Handler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	resp := HealthResponse{Status: "OK", Environment: h.Environment, StoreOK: true}
+
+	if err := h.Store.Checker(r.Context()); err != nil {
+		resp.Status = "CRITICAL"
+		resp.StoreOK = false
+	}
+
+	mode, err := h.Store.GetMaintenanceMode(r.Context())
+	if err != nil {
+		resp.Status = "CRITICAL"
+	} else if mode.Enabled {
+		resp.MaintenanceMode = true
+		resp.MaintenanceMessage = mode.Message
+		if resp.Status == "OK" {
+			resp.Status = "WARNING"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// cdnProviderCloudFront identifies CloudFront as the Provider of an
+// Invalidation record. It's the only provider CreateInvalidation supports
+// today; Cloudflare purges aren't audited here.
+const cdnProviderCloudFront = "cloudfront"
+
+// CreateInvalidation handles POST /api/v1/invalidations, gated on
+// permissionPurge. It triggers either a whole-dataset-prefix invalidation
+// (e.g. "/imf/weo/*", built from the configured CDNDatasetPrefixPattern) or,
+// when paths are given explicitly, an ad-hoc invalidation for an
+// operational scenario not tied to any upload or dataset. Either way it
+// records an audit trail of who triggered it, what was invalidated, and the
+// outcome.
+func (h *Handler) CreateInvalidation(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Dataset     string   `json:"dataset"`
+		Paths       []string `json:"paths"`
+		TriggeredBy string   `json:"triggered_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	paths := body.Paths
+	if len(paths) == 0 {
+		if body.Dataset == "" {
+			writeError(w, http.StatusBadRequest, "dataset or paths must be set")
+			return
+		}
+		if h.CDNDatasetPrefixPattern == "" {
+			writeError(w, http.StatusServiceUnavailable, "dataset invalidation is not configured")
+			return
+		}
+		paths = []string{fmt.Sprintf(h.CDNDatasetPrefixPattern, body.Dataset)}
+	}
+
+	invalidation := &models.Invalidation{
+		ID:          newInvalidationID(),
+		Provider:    cdnProviderCloudFront,
+		Dataset:     body.Dataset,
+		Paths:       paths,
+		TriggeredBy: body.TriggeredBy,
+		CreatedAt:   time.Now(),
+	}
+
+	invalidationID, err := h.CloudFront.InvalidatePaths(r.Context(), paths)
+	if err != nil {
+		invalidation.Status = models.InvalidationStatusFailed
+		invalidation.Error = err.Error()
+		_ = h.Store.CreateInvalidation(r.Context(), invalidation)
+		writeError(w, http.StatusInternalServerError, "failed to invalidate cache")
+		return
+	}
+
+	invalidation.Status = models.InvalidationStatusCompleted
+	invalidation.InvalidationID = invalidationID
+
+	if err := h.Store.CreateInvalidation(r.Context(), invalidation); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to record invalidation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invalidation)
+}
+
+// ListInvalidations handles GET /api/v1/invalidations, gated on
+// permissionPurge. It returns every recorded invalidation, or those scoped
+// to a dataset when the "dataset" query parameter is set.
+func (h *Handler) ListInvalidations(w http.ResponseWriter, r *http.Request) {
+	invalidations, err := h.Store.ListInvalidations(r.Context(), r.URL.Query().Get("dataset"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list invalidations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invalidations)
+}
+
+func newInvalidationID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,161 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestCreateInvalidation(t *testing.T) {
+	t.Run("dataset invalidates the configured prefix and records an audit entry", func(t *testing.T) {
+		var invalidatedPaths []string
+		var recorded *models.Invalidation
+		store := &storemock.StoreMock{
+			CreateInvalidationFunc: func(ctx context.Context, invalidation *models.Invalidation) error {
+				recorded = invalidation
+				return nil
+			},
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) {
+				invalidatedPaths = paths
+				return "INV456", nil
+			},
+		}
+		h := api.NewHandler(store, api.WithCloudFront(cf), api.WithCDNDatasetPrefixPattern("/imf/%s/*"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invalidations", strings.NewReader(`{"dataset":"weo","triggered_by":"j.doe"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvalidation(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(invalidatedPaths) != 1 || invalidatedPaths[0] != "/imf/weo/*" {
+			t.Fatalf("expected invalidation of /imf/weo/*, got %v", invalidatedPaths)
+		}
+		if recorded == nil || recorded.InvalidationID != "INV456" || recorded.Provider != "cloudfront" || recorded.Status != models.InvalidationStatusCompleted {
+			t.Fatalf("expected a completed audit record for the weo invalidation, got %+v", recorded)
+		}
+
+		var resp models.Invalidation
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.ID == "" {
+			t.Fatalf("expected response to include the generated invalidation ID")
+		}
+	})
+
+	t.Run("explicit paths trigger an ad-hoc invalidation not tied to a dataset", func(t *testing.T) {
+		var invalidatedPaths []string
+		store := &storemock.StoreMock{
+			CreateInvalidationFunc: func(ctx context.Context, invalidation *models.Invalidation) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) {
+				invalidatedPaths = paths
+				return "INV789", nil
+			},
+		}
+		h := api.NewHandler(store, api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invalidations", strings.NewReader(`{"paths":["/imf/weo/2024.csv"],"triggered_by":"ops"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvalidation(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(invalidatedPaths) != 1 || invalidatedPaths[0] != "/imf/weo/2024.csv" {
+			t.Fatalf("expected invalidation of the explicit path, got %v", invalidatedPaths)
+		}
+	})
+
+	t.Run("missing dataset and paths returns 400", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithCDNDatasetPrefixPattern("/imf/%s/*"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invalidations", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvalidation(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unconfigured dataset pattern returns 503", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invalidations", strings.NewReader(`{"dataset":"weo"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvalidation(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("denied by permission checker returns 403", func(t *testing.T) {
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return false, nil
+			},
+		}
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithPermissionChecker(permissions))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invalidations", strings.NewReader(`{"dataset":"weo"}`))
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", rec.Code)
+		}
+	})
+}
+
+func TestListInvalidations(t *testing.T) {
+	t.Run("returns invalidations filtered by dataset", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListInvalidationsFunc: func(ctx context.Context, dataset string) ([]*models.Invalidation, error) {
+				if dataset != "weo" {
+					t.Fatalf("expected dataset filter weo, got %q", dataset)
+				}
+				return []*models.Invalidation{{ID: "1", Dataset: "weo"}}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/invalidations?dataset=weo", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListInvalidations(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var resp []*models.Invalidation
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 1 || resp[0].ID != "1" {
+			t.Fatalf("expected one invalidation, got %v", resp)
+		}
+	})
+}
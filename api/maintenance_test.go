@@ -0,0 +1,157 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestSetMaintenanceMode(t *testing.T) {
+	var recorded *models.MaintenanceMode
+	store := &storemock.StoreMock{
+		SetMaintenanceModeFunc: func(ctx context.Context, mode *models.MaintenanceMode) error {
+			recorded = mode
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/maintenance",
+		strings.NewReader(`{"enabled":true,"message":"paused for S3 migration","updated_by":"j.doe"}`))
+	rec := httptest.NewRecorder()
+
+	h.SetMaintenanceMode(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if recorded == nil || !recorded.Enabled || recorded.Message != "paused for S3 migration" {
+		t.Fatalf("expected maintenance mode to be enabled with the given message, got %+v", recorded)
+	}
+}
+
+func TestRequireNotInMaintenance(t *testing.T) {
+	t.Run("write endpoints are rejected while maintenance mode is enabled", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{Enabled: true, Message: "paused for S3 migration"}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "paused for S3 migration") {
+			t.Fatalf("expected the configured message in the response body, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("read endpoints stay available while maintenance mode is enabled", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{Enabled: true, Message: "paused for S3 migration"}, nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return nil, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestGetHealth(t *testing.T) {
+	t.Run("reports WARNING when maintenance mode is enabled", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CheckerFunc: func(ctx context.Context) error { return nil },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{Enabled: true, Message: "paused for S3 migration"}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetHealth(rec, req)
+
+		var resp api.HealthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Status != "WARNING" || !resp.MaintenanceMode || resp.MaintenanceMessage != "paused for S3 migration" {
+			t.Fatalf("expected a WARNING health response reflecting maintenance mode, got %+v", resp)
+		}
+	})
+
+	t.Run("reports CRITICAL when the store is unreachable", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CheckerFunc: func(ctx context.Context) error { return context.DeadlineExceeded },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetHealth(rec, req)
+
+		var resp api.HealthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Status != "CRITICAL" || resp.StoreOK {
+			t.Fatalf("expected a CRITICAL health response, got %+v", resp)
+		}
+	})
+
+	t.Run("reports the configured environment", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CheckerFunc: func(ctx context.Context) error { return nil },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{}, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithEnvironment("staging"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetHealth(rec, req)
+
+		var resp api.HealthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Environment != "staging" {
+			t.Fatalf("expected environment %q, got %q", "staging", resp.Environment)
+		}
+	})
+}
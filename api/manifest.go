@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// manifestObjectKey is the S3 key the publish manifest is uploaded to after
+// each approval, so downstream consumers can fetch it directly from S3
+// without calling GetManifest.
+const manifestObjectKey = "manifest.json"
+
+// ManifestEntry describes a single currently published file in the manifest
+// returned by GetManifest and uploaded to manifestObjectKey.
+type ManifestEntry struct {
+	Key         string    `json:"key"`
+	Checksum    string    `json:"checksum,omitempty"`
+	SizeBytes   int64     `json:"size_bytes"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// GetManifest handles GET /api/v1/manifest, reporting the same content most
+// recently uploaded to manifestObjectKey by publishManifest.
+func (h *Handler) GetManifest(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.buildManifest(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to build manifest")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// buildManifest lists every currently approved or published upload as a
+// ManifestEntry, keyed by TargetKey.
+func (h *Handler) buildManifest(ctx context.Context) ([]ManifestEntry, error) {
+	uploads, err := h.Store.ListUploads(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ManifestEntry, 0, len(uploads))
+	for _, upload := range uploads {
+		if upload.Status != models.StatusApproved && upload.Status != models.StatusPublished {
+			continue
+		}
+		entries = append(entries, ManifestEntry{
+			Key:         upload.TargetKey,
+			Checksum:    upload.Checksum,
+			SizeBytes:   upload.Size,
+			PublishedAt: upload.UpdatedAt,
+		})
+	}
+	return entries, nil
+}
+
+// publishManifest regenerates the manifest and uploads it to manifestObjectKey
+// so downstream consumers can verify what's live. It is best-effort: a
+// failure here doesn't fail the approval that triggered it, since publishing
+// of the approved file has already succeeded.
+func (h *Handler) publishManifest(ctx context.Context) {
+	entries, err := h.buildManifest(ctx)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return
+	}
+
+	_ = h.S3.UploadFile(ctx, manifestObjectKey, bytes.NewReader(data), "application/json", "", "")
+}
@@ -0,0 +1,46 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetManifest(t *testing.T) {
+	t.Run("lists approved and published uploads, excluding pending and rejected", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{
+					{ID: "1", Status: models.StatusPublished, TargetKey: "imf/weo/2024.csv", Checksum: "abc", Size: 100},
+					{ID: "2", Status: models.StatusApproved, TargetKey: "imf/weo/2023.csv", Checksum: "def", Size: 200},
+					{ID: "3", Status: models.StatusPending, TargetKey: "imf/weo/pending.csv"},
+					{ID: "4", Status: models.StatusRejected, TargetKey: "imf/weo/rejected.csv"},
+				}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/manifest", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetManifest(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var entries []api.ManifestEntry
+		if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 manifest entries, got %d: %+v", len(entries), entries)
+		}
+	})
+}
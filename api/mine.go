@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// callerIdentityHeader carries the authenticated caller's identity, as
+// forwarded by the fronting proxy/API gateway that validates the JWT; this
+// service does not decode JWTs itself.
+const callerIdentityHeader = "X-User-Email"
+
+// callerIdentity returns the identity of the human caller of r, or "" if
+// none was forwarded.
+func callerIdentity(r *http.Request) string {
+	return r.Header.Get(callerIdentityHeader)
+}
+
+// MyUploadsResponse is the body returned by ListMyUploads: the caller's own
+// uploads alongside a count of how many are in each status, so the
+// dashboard's personal view can render a summary without re-deriving it
+// client-side.
+type MyUploadsResponse struct {
+	Uploads       []*models.Upload      `json:"uploads"`
+	StatusSummary map[models.Status]int `json:"status_summary"`
+}
+
+// ListMyUploads handles GET /api/v1/uploads/mine, returning only the
+// uploads whose UploadedBy matches the caller identified by
+// callerIdentityHeader. An empty or unrecognised identity returns an empty
+// list rather than the full upload history.
+func (h *Handler) ListMyUploads(w http.ResponseWriter, r *http.Request) {
+	identity := callerIdentity(r)
+
+	uploads, err := h.Store.ListUploads(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	resp := MyUploadsResponse{
+		Uploads:       []*models.Upload{},
+		StatusSummary: map[models.Status]int{},
+	}
+	if identity == "" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	for _, upload := range uploads {
+		if !strings.EqualFold(upload.UploadedBy, identity) {
+			continue
+		}
+		resp.Uploads = append(resp.Uploads, upload)
+		resp.StatusSummary[upload.Status]++
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,61 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestListMyUploads(t *testing.T) {
+	uploads := []*models.Upload{
+		{ID: "1", UploadedBy: "jdoe@example.com", Status: models.StatusPending},
+		{ID: "2", UploadedBy: "jdoe@example.com", Status: models.StatusApproved},
+		{ID: "3", UploadedBy: "asmith@example.com", Status: models.StatusPending},
+	}
+	store := &storemock.StoreMock{
+		ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+			return uploads, nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	t.Run("returns only the caller's uploads with a status summary", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/mine", nil)
+		req.Header.Set("X-User-Email", "jdoe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.ListMyUploads(rec, req)
+
+		var resp api.MyUploadsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Uploads) != 2 {
+			t.Fatalf("expected 2 uploads, got %d", len(resp.Uploads))
+		}
+		if resp.StatusSummary[models.StatusPending] != 1 || resp.StatusSummary[models.StatusApproved] != 1 {
+			t.Fatalf("unexpected status summary: %+v", resp.StatusSummary)
+		}
+	})
+
+	t.Run("returns an empty list when no identity is forwarded", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/mine", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListMyUploads(rec, req)
+
+		var resp api.MyUploadsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Uploads) != 0 {
+			t.Fatalf("expected no uploads, got %d", len(resp.Uploads))
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// GetUploadNotifications handles GET /api/v1/uploads/{id}/notifications,
+// returning every notification delivery attempt recorded for the upload.
+func (h *Handler) GetUploadNotifications(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	notifications, err := h.Store.ListNotifications(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list notifications")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notifications)
+}
+
+// ResendNotification handles
+// POST /api/v1/uploads/{id}/notifications/{notification_id}/resend. It
+// re-sends a previously recorded notification's message and updates its
+// delivery status, so a notification that failed to send doesn't require
+// re-triggering the whole upload/review action.
+func (h *Handler) ResendNotification(w http.ResponseWriter, r *http.Request) {
+	uploadID := r.PathValue("id")
+	notificationID := r.PathValue("notification_id")
+
+	notification, err := h.Store.GetNotification(r.Context(), notificationID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "notification not found")
+		return
+	}
+	if notification.UploadID != uploadID {
+		writeError(w, http.StatusNotFound, "notification not found")
+		return
+	}
+
+	if h.Notifier == nil {
+		writeError(w, http.StatusServiceUnavailable, "no notifier configured")
+		return
+	}
+
+	sendErr := h.Notifier.Notify(r.Context(), notification.Message)
+
+	notification.Attempts++
+	notification.UpdatedAt = time.Now()
+	if sendErr != nil {
+		notification.Status = models.NotificationStatusFailed
+		notification.Error = sendErr.Error()
+	} else {
+		notification.Status = models.NotificationStatusSent
+		notification.Error = ""
+	}
+
+	if err := h.Store.UpdateNotification(r.Context(), notification); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update notification")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(notification)
+}
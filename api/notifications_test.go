@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetUploadNotifications(t *testing.T) {
+	t.Run("returns the notifications recorded for the upload", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListNotificationsFunc: func(ctx context.Context, uploadID string) ([]*models.Notification, error) {
+				return []*models.Notification{{ID: "n1", UploadID: uploadID, Status: models.NotificationStatusFailed}}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/1/notifications", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadNotifications(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestResendNotification(t *testing.T) {
+	t.Run("success resends and records the new attempt", func(t *testing.T) {
+		notification := &models.Notification{ID: "n1", UploadID: "1", Message: "upload rejected: 1", Status: models.NotificationStatusFailed, Attempts: 1}
+		store := &storemock.StoreMock{
+			GetNotificationFunc: func(ctx context.Context, id string) (*models.Notification, error) { return notification, nil },
+			UpdateNotificationFunc: func(ctx context.Context, n *models.Notification) error {
+				if n.Status != models.NotificationStatusSent {
+					t.Fatalf("expected status sent, got %s", n.Status)
+				}
+				if n.Attempts != 2 {
+					t.Fatalf("expected attempts 2, got %d", n.Attempts)
+				}
+				return nil
+			},
+		}
+		notifier := &apimock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, message string) error { return nil },
+		}
+		h := api.NewHandler(store, api.WithNotifier(notifier))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/1/notifications/n1/resend", nil)
+		req.SetPathValue("id", "1")
+		req.SetPathValue("notification_id", "n1")
+		rec := httptest.NewRecorder()
+
+		h.ResendNotification(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("delivery failure keeps the notification failed", func(t *testing.T) {
+		notification := &models.Notification{ID: "n1", UploadID: "1", Message: "upload rejected: 1", Status: models.NotificationStatusFailed}
+		store := &storemock.StoreMock{
+			GetNotificationFunc: func(ctx context.Context, id string) (*models.Notification, error) { return notification, nil },
+			UpdateNotificationFunc: func(ctx context.Context, n *models.Notification) error {
+				if n.Status != models.NotificationStatusFailed || n.Error == "" {
+					t.Fatalf("expected failed status with an error recorded, got %+v", n)
+				}
+				return nil
+			},
+		}
+		notifier := &apimock.NotifierMock{
+			NotifyFunc: func(ctx context.Context, message string) error { return errors.New("webhook unreachable") },
+		}
+		h := api.NewHandler(store, api.WithNotifier(notifier))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/1/notifications/n1/resend", nil)
+		req.SetPathValue("id", "1")
+		req.SetPathValue("notification_id", "n1")
+		rec := httptest.NewRecorder()
+
+		h.ResendNotification(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("notification belonging to a different upload returns 404", func(t *testing.T) {
+		notification := &models.Notification{ID: "n1", UploadID: "2"}
+		store := &storemock.StoreMock{
+			GetNotificationFunc: func(ctx context.Context, id string) (*models.Notification, error) { return notification, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/1/notifications/n1/resend", nil)
+		req.SetPathValue("id", "1")
+		req.SetPathValue("notification_id", "n1")
+		rec := httptest.NewRecorder()
+
+		h.ResendNotification(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// slackChannel identifies the delivery mechanism recorded against a
+// Notification, i.e. how it was sent, not the Slack channel name.
+const slackChannel = "slack"
+
+// notify sends message via h.Notifier's DatasetNotifier capability when
+// dataset is non-empty and the configured notifier supports routing by
+// dataset, falling back to a plain Notify otherwise. It persists a record
+// of the attempt so a failed delivery is visible via GetUploadNotifications
+// instead of being silently swallowed.
+func (h *Handler) notify(ctx context.Context, uploadID, dataset, message string) {
+	if h.Notifier == nil {
+		return
+	}
+
+	datasetNotifier, ok := h.Notifier.(DatasetNotifier)
+	if !ok || dataset == "" {
+		err := h.Notifier.Notify(ctx, message)
+		h.recordNotification(ctx, uploadID, message, err)
+		return
+	}
+
+	err := datasetNotifier.NotifyDataset(ctx, dataset, message)
+	h.recordNotification(ctx, uploadID, message, err)
+}
+
+// notifyApprovalRequest sends the richer approval notification via
+// h.Notifier's ApprovalNotifier capability, falling back to a plain Notify
+// when the configured notifier doesn't support it, and records the attempt.
+// A notifier that also routes by dataset (e.g. slack.Router) does so from
+// within its own ApprovalNotifier implementation, since upload.Dataset is
+// already available there.
+func (h *Handler) notifyApprovalRequest(ctx context.Context, upload *models.Upload) {
+	if h.Notifier == nil {
+		return
+	}
+
+	approvalNotifier, ok := h.Notifier.(ApprovalNotifier)
+	if !ok {
+		h.notify(ctx, upload.ID, upload.Dataset, "new upload pending review: "+upload.ID)
+		return
+	}
+
+	err := approvalNotifier.NotifyApprovalRequest(ctx, upload, h.ApprovalChecklist)
+	message := fmt.Sprintf("approval request: %s (%s)", upload.Filename, upload.Dataset)
+	h.recordNotification(ctx, upload.ID, message, err)
+}
+
+// notifyError sends message via h.Notifier's DatasetNotifier capability
+// when dataset is non-empty, so the owning team's channel sees it, falling
+// back to the ErrorNotifier capability, if it has one, so a failure a
+// reviewer needs to see immediately isn't held back by digest batching or
+// quiet hours. It falls back further to a plain Notify, and records the
+// attempt like notify does.
+func (h *Handler) notifyError(ctx context.Context, uploadID, dataset, message string) {
+	if h.Notifier == nil {
+		return
+	}
+
+	if datasetNotifier, ok := h.Notifier.(DatasetNotifier); ok && dataset != "" {
+		err := datasetNotifier.NotifyDataset(ctx, dataset, message)
+		h.recordNotification(ctx, uploadID, message, err)
+		return
+	}
+
+	errorNotifier, ok := h.Notifier.(ErrorNotifier)
+	if !ok {
+		h.notify(ctx, uploadID, dataset, message)
+		return
+	}
+
+	err := errorNotifier.NotifyError(ctx, message)
+	h.recordNotification(ctx, uploadID, message, err)
+}
+
+// recordNotification persists a Notification describing a single delivery
+// attempt for uploadID. Failure to persist is not surfaced to the caller,
+// consistent with notifications themselves being best-effort.
+func (h *Handler) recordNotification(ctx context.Context, uploadID, message string, sendErr error) {
+	hash := sha256.Sum256([]byte(message))
+	now := time.Now()
+
+	notification := &models.Notification{
+		ID:          newNotificationID(),
+		UploadID:    uploadID,
+		Channel:     slackChannel,
+		Message:     message,
+		PayloadHash: hex.EncodeToString(hash[:]),
+		Status:      models.NotificationStatusSent,
+		Attempts:    1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if sendErr != nil {
+		notification.Status = models.NotificationStatusFailed
+		notification.Error = sendErr.Error()
+	}
+
+	_ = h.Store.CreateNotification(ctx, notification)
+}
+
+func newNotificationID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
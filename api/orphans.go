@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// quarantineTagKey is the S3 object tag QuarantineOrphan sets on an object
+// flagged as an orphan, so it can be found and reviewed later without being
+// deleted outright.
+const quarantineTagKey = "imf-quarantine"
+
+type orphanObject struct {
+	Key       string `json:"key"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// ListOrphans handles GET /api/v1/orphans, reporting objects under
+// h.S3ScanPrefix that have no corresponding approved or published upload
+// record, e.g. manual uploads or leftovers from a since-renamed publish.
+func (h *Handler) ListOrphans(w http.ResponseWriter, r *http.Request) {
+	lister, ok := h.S3.(S3Lister)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "configured S3 backend does not support listing")
+		return
+	}
+
+	if h.S3ScanPrefix == "" {
+		writeError(w, http.StatusServiceUnavailable, "orphan scanning is not configured")
+		return
+	}
+
+	objects, err := lister.ListObjects(r.Context(), h.S3ScanPrefix)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list S3 objects")
+		return
+	}
+
+	uploads, err := h.Store.ListUploads(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	known := make(map[string]bool, len(uploads))
+	for _, upload := range uploads {
+		if upload.Status == models.StatusApproved || upload.Status == models.StatusPublished {
+			known[upload.TargetKey] = true
+		}
+	}
+
+	orphans := make([]orphanObject, 0)
+	for _, obj := range objects {
+		if !known[obj.Key] {
+			orphans = append(orphans, orphanObject{Key: obj.Key, SizeBytes: obj.Size})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orphans)
+}
+
+// QuarantineOrphan handles POST /api/v1/orphans/{key}/quarantine, tagging
+// the object at key so it is flagged for manual review instead of being
+// deleted outright.
+func (h *Handler) QuarantineOrphan(w http.ResponseWriter, r *http.Request) {
+	tagger, ok := h.S3.(S3Tagger)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "configured S3 backend does not support tagging")
+		return
+	}
+
+	key := r.PathValue("key")
+
+	if err := tagger.TagObject(r.Context(), key, map[string]string{quarantineTagKey: "orphan"}); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to quarantine object")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+// fakeS3WithCapabilities embeds the moq-generated S3ClientMock alongside the
+// mocks of every optional S3 capability (S3Lister, S3Tagger, S3Presigner,
+// S3MetadataSetter, Restorer, S3Diagnoser), so a single value satisfies
+// api.S3Client and can be type-asserted to any of them, mirroring how a
+// real S3 backend implements them all on one struct.
+type fakeS3WithCapabilities struct {
+	*apimock.S3ClientMock
+	*apimock.S3ListerMock
+	*apimock.S3TaggerMock
+	*apimock.S3PresignerMock
+	*apimock.S3MetadataSetterMock
+	*apimock.RestorerMock
+	*apimock.S3DiagnoserMock
+}
+
+func newFakeS3() *fakeS3WithCapabilities {
+	return &fakeS3WithCapabilities{
+		S3ClientMock:         &apimock.S3ClientMock{},
+		S3ListerMock:         &apimock.S3ListerMock{},
+		S3TaggerMock:         &apimock.S3TaggerMock{},
+		S3PresignerMock:      &apimock.S3PresignerMock{},
+		S3MetadataSetterMock: &apimock.S3MetadataSetterMock{},
+		RestorerMock:         &apimock.RestorerMock{},
+		S3DiagnoserMock:      &apimock.S3DiagnoserMock{},
+	}
+}
+
+func TestListOrphans(t *testing.T) {
+	t.Run("reports objects with no known upload", func(t *testing.T) {
+		s3 := newFakeS3()
+		s3.ListObjectsFunc = func(ctx context.Context, prefix string) ([]api.S3ObjectInfo, error) {
+			if prefix != "imf/" {
+				t.Fatalf("expected scan prefix imf/, got %q", prefix)
+			}
+			return []api.S3ObjectInfo{
+				{Key: "imf/weo/2024.csv", Size: 100},
+				{Key: "imf/weo/leftover.csv", Size: 50},
+			}, nil
+		}
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{
+					{ID: "1", Status: models.StatusPublished, TargetKey: "imf/weo/2024.csv"},
+				}, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithS3ScanPrefix("imf/"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orphans", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListOrphans(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var orphans []struct {
+			Key string `json:"key"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&orphans); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(orphans) != 1 || orphans[0].Key != "imf/weo/leftover.csv" {
+			t.Fatalf("expected only the leftover object to be reported, got %v", orphans)
+		}
+	})
+
+	t.Run("unconfigured scan prefix returns 503", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithS3(newFakeS3()))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orphans", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListOrphans(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("backend without listing support returns 501", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithS3(&apimock.S3ClientMock{}), api.WithS3ScanPrefix("imf/"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orphans", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListOrphans(rec, req)
+
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("expected status 501, got %d", rec.Code)
+		}
+	})
+}
+
+func TestQuarantineOrphan(t *testing.T) {
+	t.Run("tags the object as quarantined", func(t *testing.T) {
+		s3 := newFakeS3()
+		var taggedKey string
+		var taggedTags map[string]string
+		s3.TagObjectFunc = func(ctx context.Context, key string, tags map[string]string) error {
+			taggedKey = key
+			taggedTags = tags
+			return nil
+		}
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithS3(s3))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orphans/imf/weo/leftover.csv/quarantine", nil)
+		req.SetPathValue("key", "imf/weo/leftover.csv")
+		rec := httptest.NewRecorder()
+
+		h.QuarantineOrphan(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", rec.Code)
+		}
+		if taggedKey != "imf/weo/leftover.csv" {
+			t.Fatalf("expected the leftover object to be tagged, got %q", taggedKey)
+		}
+		if taggedTags["imf-quarantine"] != "orphan" {
+			t.Fatalf("expected an imf-quarantine tag, got %v", taggedTags)
+		}
+	})
+
+	t.Run("backend without tagging support returns 501", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithS3(&apimock.S3ClientMock{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orphans/imf/weo/leftover.csv/quarantine", nil)
+		req.SetPathValue("key", "imf/weo/leftover.csv")
+		rec := httptest.NewRecorder()
+
+		h.QuarantineOrphan(rec, req)
+
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("expected status 501, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// permissionEditAnyUploadMetadata gates PatchUpload for a caller other than
+// the upload's own uploader, e.g. an admin fixing a typo on someone else's
+// behalf.
+const permissionEditAnyUploadMetadata = "imf:uploads:edit-metadata"
+
+// PatchUpload handles PATCH /api/v1/uploads/{id}, letting the uploader (or,
+// with permissionEditAnyUploadMetadata, an admin) correct a pending
+// upload's metadata before it's reviewed. Only Title, Description, Dataset,
+// Tags and TargetKey may be changed; every other field, including the
+// staged file itself, is untouched. Each successful edit is recorded in the
+// audit log with both the before and after state, independent of
+// h.AuditPathPrefixes, since /api/v1/uploads isn't normally an audited
+// prefix.
+func (h *Handler) PatchUpload(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if upload.Status != models.StatusPending {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "not_pending",
+			Message: "only a pending upload's metadata can be edited",
+			Value:   string(upload.Status),
+		})
+		return
+	}
+
+	identity := callerIdentity(r)
+	if !strings.EqualFold(identity, upload.UploadedBy) {
+		if granted, err := h.hasPermission(r, permissionEditAnyUploadMetadata); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to check permission")
+			return
+		} else if !granted {
+			writeError(w, http.StatusForbidden, "only the uploader or an admin can edit this upload's metadata")
+			return
+		}
+	}
+
+	var body struct {
+		Title       *string  `json:"title"`
+		Description *string  `json:"description"`
+		Dataset     *string  `json:"dataset"`
+		Tags        []string `json:"tags"`
+		TargetKey   *string  `json:"target_key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	before, err := json.Marshal(upload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to record audit entry")
+		return
+	}
+
+	if body.TargetKey != nil && *body.TargetKey != upload.TargetKey {
+		if verr := validateTargetKeyOverride(upload.TargetKey, *body.TargetKey); verr != nil {
+			writeValidationErrors(w, *verr)
+			return
+		}
+		upload.TargetKey = *body.TargetKey
+	}
+	if body.Title != nil {
+		upload.Title = *body.Title
+	}
+	if body.Description != nil {
+		upload.Description = *body.Description
+	}
+	if body.Dataset != nil {
+		upload.Dataset = *body.Dataset
+	}
+	if body.Tags != nil {
+		upload.Tags = body.Tags
+	}
+
+	if err := h.Store.UpdateUpload(r.Context(), upload); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update upload")
+		return
+	}
+
+	after, _ := json.Marshal(upload)
+	_ = h.Store.CreateAuditLogEntry(r.Context(), &models.AuditLogEntry{
+		Actor:       h.requestActor(r),
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Before:      string(before),
+		Body:        string(after),
+		Status:      http.StatusOK,
+		RecordedAt:  time.Now(),
+		Environment: h.Environment,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(upload)
+}
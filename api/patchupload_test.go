@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestPatchUpload(t *testing.T) {
+	t.Run("uploader can edit metadata on their own pending upload", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Dataset: "weo", UploadedBy: "uploader@example.com", TargetKey: "weo/data.csv", Status: models.StatusPending}
+		var updated *models.Upload
+		var audited *models.AuditLogEntry
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(_ context.Context, u *models.Upload) error {
+				updated = u
+				return nil
+			},
+			CreateAuditLogEntryFunc: func(_ context.Context, entry *models.AuditLogEntry) error {
+				audited = entry
+				return nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/uploads/1", strings.NewReader(`{"title":"Q3 revision"}`))
+		req.Header.Set("X-User-Email", "uploader@example.com")
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.PatchUpload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if updated == nil || updated.Title != "Q3 revision" {
+			t.Fatalf("unexpected updated upload: %+v", updated)
+		}
+		if audited == nil || audited.Before == "" || audited.Body == "" {
+			t.Fatalf("expected an audit entry with before/after state, got %+v", audited)
+		}
+	})
+
+	t.Run("rejects a caller who is neither the uploader nor permitted", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", UploadedBy: "uploader@example.com", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithPermissionChecker(&apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(context.Context, *http.Request, string) (bool, error) { return false, nil },
+		}))
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/uploads/1", strings.NewReader(`{"title":"typo fix"}`))
+		req.Header.Set("X-User-Email", "someone-else@example.com")
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.PatchUpload(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("rejects editing a non-pending upload", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", UploadedBy: "uploader@example.com", Status: models.StatusApproved}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/uploads/1", strings.NewReader(`{"title":"typo fix"}`))
+		req.Header.Set("X-User-Email", "uploader@example.com")
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.PatchUpload(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("validates a target_key override", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", UploadedBy: "uploader@example.com", TargetKey: "weo/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/uploads/1", strings.NewReader(`{"target_key":"other/data.csv"}`))
+		req.Header.Set("X-User-Email", "uploader@example.com")
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.PatchUpload(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
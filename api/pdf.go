@@ -0,0 +1,37 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// screenPDFUpload scans file for active content according to h.PDFPolicy. If
+// the file is rejected it writes the validation result as the response and
+// returns ok=false; callers must stop processing the request in that case.
+// Under PDFActionSanitize the returned body has offending objects stripped.
+func (h *Handler) screenPDFUpload(w http.ResponseWriter, file multipart.File) (body io.Reader, size int64, ok bool) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read uploaded file")
+		return nil, 0, false
+	}
+
+	result := validate.ValidatePDF(data, h.PDFPolicy)
+	if !result.Valid {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(result)
+		return nil, 0, false
+	}
+
+	if h.PDFPolicy.Action == validate.PDFActionSanitize && len(result.Findings) > 0 {
+		data = validate.SanitizePDF(data)
+	}
+
+	return bytes.NewReader(data), int64(len(data)), true
+}
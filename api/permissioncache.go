@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachingPermissionChecker wraps a PermissionChecker with a short TTL
+// cache, so a hot path like approve/reject or purge that calls
+// requirePermission on every request doesn't hit the underlying checker's
+// backing store (typically a DB lookup keyed by the caller's identity) each
+// time. Entries are keyed by the identity read from IdentityHeader, so
+// distinct callers never share a cached grant.
+type CachingPermissionChecker struct {
+	Checker PermissionChecker
+	TTL     time.Duration
+	// IdentityHeader is the request header used to key cache entries, e.g.
+	// "Authorization" or "X-User-Email".
+	IdentityHeader string
+
+	mu      sync.Mutex
+	entries map[string]cachedPermission
+}
+
+type cachedPermission struct {
+	granted   bool
+	expiresAt time.Time
+}
+
+// NewCachingPermissionChecker wraps checker with a TTL cache keyed on the
+// value of identityHeader.
+func NewCachingPermissionChecker(checker PermissionChecker, ttl time.Duration, identityHeader string) *CachingPermissionChecker {
+	return &CachingPermissionChecker{
+		Checker:        checker,
+		TTL:            ttl,
+		IdentityHeader: identityHeader,
+		entries:        map[string]cachedPermission{},
+	}
+}
+
+// HasPermission implements PermissionChecker, serving a cached grant when
+// one is present and unexpired, and falling through to Checker otherwise.
+func (c *CachingPermissionChecker) HasPermission(ctx context.Context, r *http.Request, permission string) (bool, error) {
+	key := c.cacheKey(r, permission)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.granted, nil
+	}
+
+	granted, err := c.Checker.HasPermission(ctx, r, permission)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cachedPermission{granted: granted, expiresAt: time.Now().Add(c.TTL)}
+	c.mu.Unlock()
+
+	return granted, nil
+}
+
+// Invalidate drops every cached entry for identity, so a permission change
+// (a user's role updated or revoked) takes effect on their next request
+// instead of waiting out the TTL. identity is the same value that appears
+// in IdentityHeader on that user's requests.
+func (c *CachingPermissionChecker) Invalidate(identity string) {
+	prefix := identity + "|"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *CachingPermissionChecker) cacheKey(r *http.Request, permission string) string {
+	return r.Header.Get(c.IdentityHeader) + "|" + permission
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+)
+
+func TestCachingPermissionChecker(t *testing.T) {
+	t.Run("caches a grant for the TTL instead of calling the underlying checker again", func(t *testing.T) {
+		calls := 0
+		checker := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				calls++
+				return true, nil
+			},
+		}
+		cache := api.NewCachingPermissionChecker(checker, time.Minute, "X-User-Email")
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-User-Email", "reviewer@example.com")
+
+		for i := 0; i < 3; i++ {
+			granted, err := cache.HasPermission(context.Background(), req, "imf:purge")
+			if err != nil || !granted {
+				t.Fatalf("expected permission to be granted, got %v, %v", granted, err)
+			}
+		}
+
+		if calls != 1 {
+			t.Fatalf("expected the underlying checker to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("does not share cached grants between distinct callers", func(t *testing.T) {
+		calls := 0
+		checker := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				calls++
+				return true, nil
+			},
+		}
+		cache := api.NewCachingPermissionChecker(checker, time.Minute, "X-User-Email")
+
+		reqA := httptest.NewRequest(http.MethodPost, "/", nil)
+		reqA.Header.Set("X-User-Email", "a@example.com")
+		reqB := httptest.NewRequest(http.MethodPost, "/", nil)
+		reqB.Header.Set("X-User-Email", "b@example.com")
+
+		if _, err := cache.HasPermission(context.Background(), reqA, "imf:purge"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cache.HasPermission(context.Background(), reqB, "imf:purge"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if calls != 2 {
+			t.Fatalf("expected each caller to trigger its own check, got %d calls", calls)
+		}
+	})
+
+	t.Run("Invalidate forces a fresh check for that identity on the next call", func(t *testing.T) {
+		calls := 0
+		checker := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				calls++
+				return true, nil
+			},
+		}
+		cache := api.NewCachingPermissionChecker(checker, time.Minute, "X-User-Email")
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-User-Email", "reviewer@example.com")
+
+		if _, err := cache.HasPermission(context.Background(), req, "imf:purge"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cache.Invalidate("reviewer@example.com")
+		if _, err := cache.HasPermission(context.Background(), req, "imf:purge"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if calls != 2 {
+			t.Fatalf("expected invalidation to force a second check, got %d calls", calls)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package api
+
+import "net/http"
+
+// permissionPurge gates ad-hoc and dataset-wide CDN invalidation, an
+// operational action distinct from the review permissions implied by
+// approving or rejecting an upload.
+const permissionPurge = "imf:purge"
+
+// permissionPublishOverride lets a reviewer publish immediately even when
+// h.publishWindowOpen reports the configured publish window is closed, e.g.
+// for an urgent correction that can't wait for the next window.
+const permissionPublishOverride = "imf:publish-override"
+
+// permissionMaintenance gates toggling maintenance mode, an operational
+// action with a much larger blast radius than any single review decision.
+const permissionMaintenance = "imf:maintenance"
+
+// permissionQuotaOverride lets a caller exceed h.MaxUploadsPerDay/
+// h.MaxBytesPerDay, e.g. for a backfill or an automated pipeline that
+// legitimately needs to submit more than the configured daily quota.
+const permissionQuotaOverride = "imf:quota-override"
+
+// requirePermission wraps next so it only runs once h.Permissions grants
+// permission for the incoming request. A nil h.Permissions leaves next open.
+func (h *Handler) requirePermission(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		granted, err := h.hasPermission(r, permission)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to check permission")
+			return
+		}
+		if !granted {
+			writeError(w, http.StatusForbidden, "missing required permission: "+permission)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// hasPermission reports whether the caller of r has been granted
+// permission, for a handler that needs to check mid-request rather than
+// gate its entire route. A nil h.Permissions grants everything.
+func (h *Handler) hasPermission(r *http.Request, permission string) (bool, error) {
+	if h.Permissions == nil {
+		return true, nil
+	}
+	return h.Permissions.HasPermission(r.Context(), r, permission)
+}
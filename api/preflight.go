@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/preflight"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// permissionPreflight gates the preflight readiness endpoint, an
+// operational action distinct from the review permissions implied by
+// approving or rejecting an upload.
+const permissionPreflight = "imf:preflight"
+
+// preflightChecks builds the set of dependency checks Preflight and
+// RunPreflight run, from whichever of h.S3, h.CloudFront, h.Cloudflare and
+// h.Temp support the corresponding optional capability. A dependency that
+// doesn't support it (e.g. a fake used in tests) is silently skipped rather
+// than reported as failing.
+func (h *Handler) preflightChecks() []preflight.Check {
+	checks := []preflight.Check{
+		{Name: "store", Check: h.Store.Checker},
+	}
+
+	if checker, ok := h.S3.(BucketChecker); ok {
+		checks = append(checks, preflight.Check{Name: "s3", Check: checker.CheckBucket})
+	}
+	if checker, ok := h.CloudFront.(DistributionChecker); ok {
+		checks = append(checks, preflight.Check{Name: "cloudfront", Check: checker.CheckDistribution})
+	}
+	if checker, ok := h.Cloudflare.(ZoneChecker); ok {
+		checks = append(checks, preflight.Check{Name: "cloudflare", Check: checker.CheckZone})
+	}
+	if checker, ok := h.Temp.(temp.Pinger); ok {
+		checks = append(checks, preflight.Check{Name: "temp", Check: checker.Ping})
+	}
+
+	return checks
+}
+
+// RunPreflight runs every configured dependency check once, caching the
+// result on h.Preflight for requireReady and GetPreflight to consult. It is
+// intended to be called once at startup (and optionally on a timer), not
+// per-request.
+func (h *Handler) RunPreflight(ctx context.Context) preflight.Report {
+	if h.Preflight == nil {
+		h.Preflight = preflight.NewProber(h.preflightChecks()...)
+	}
+	return h.Preflight.RunOnce(ctx)
+}
+
+// GetPreflight handles GET /api/v1/admin/preflight, gated on
+// permissionPreflight, returning the fresh report from a call to
+// RunPreflight. It re-runs the checks live rather than serving a
+// potentially stale cache, since operators calling this endpoint are
+// usually trying to diagnose a problem right now.
+func (h *Handler) GetPreflight(w http.ResponseWriter, r *http.Request) {
+	report := h.RunPreflight(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// requireReady wraps next so it only runs once RunPreflight has reported a
+// ready result, responding 503 otherwise. It is a no-op when h.RequireReady
+// is false or RunPreflight has never been called, consistent with other
+// optional gates (e.g. requireNotInMaintenance) defaulting to open.
+func (h *Handler) requireReady(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.RequireReady || h.Preflight == nil {
+			next(w, r)
+			return
+		}
+
+		if report := h.Preflight.Report(); !report.Ready {
+			writeError(w, http.StatusServiceUnavailable, "service is not ready: one or more dependencies failed their preflight check")
+			return
+		}
+
+		next(w, r)
+	}
+}
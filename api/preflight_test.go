@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetPreflight(t *testing.T) {
+	t.Run("reports ready when every configured check succeeds", func(t *testing.T) {
+		store := &storemock.StoreMock{CheckerFunc: func(context.Context) error { return nil }}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/preflight", nil)
+		rec := httptest.NewRecorder()
+		h.GetPreflight(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var report struct {
+			Ready   bool `json:"ready"`
+			Results []struct {
+				Name string `json:"name"`
+				OK   bool   `json:"ok"`
+			} `json:"results"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !report.Ready {
+			t.Fatalf("expected the report to be ready, got %+v", report)
+		}
+		if len(report.Results) != 1 || report.Results[0].Name != "store" || !report.Results[0].OK {
+			t.Fatalf("expected a single passing store check, got %+v", report.Results)
+		}
+	})
+
+	t.Run("reports not ready when a check fails", func(t *testing.T) {
+		store := &storemock.StoreMock{CheckerFunc: func(context.Context) error { return errors.New("connection refused") }}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/preflight", nil)
+		rec := httptest.NewRecorder()
+		h.GetPreflight(rec, req)
+
+		var report struct {
+			Ready bool `json:"ready"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if report.Ready {
+			t.Fatalf("expected the report not to be ready")
+		}
+	})
+}
+
+func TestRequireReady(t *testing.T) {
+	t.Run("write endpoints stay open when RequireReady is disabled, even with a failing dependency", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CheckerFunc: func(context.Context) error { return errors.New("down") },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{}, nil
+			},
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		h := api.NewHandler(store)
+		h.RunPreflight(context.Background())
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code == http.StatusServiceUnavailable {
+			t.Fatalf("expected the request not to be rejected for readiness, got 503")
+		}
+	})
+
+	t.Run("write endpoints are rejected once RequireReady is enabled and a dependency fails", func(t *testing.T) {
+		store := &storemock.StoreMock{CheckerFunc: func(context.Context) error { return errors.New("down") }}
+		h := api.NewHandler(store, api.WithRequireReady(true))
+		h.RunPreflight(context.Background())
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("write endpoints stay open once RequireReady is enabled and every dependency is ready", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CheckerFunc: func(context.Context) error { return nil },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{}, nil
+			},
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		h := api.NewHandler(store, api.WithRequireReady(true))
+		h.RunPreflight(context.Background())
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code == http.StatusServiceUnavailable {
+			t.Fatalf("expected the request to pass the readiness gate, got 503")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type uploadProgressResponse struct {
+	Token         string  `json:"token"`
+	TotalBytes    int64   `json:"total_bytes"`
+	BytesReceived int64   `json:"bytes_received"`
+	PercentDone   float64 `json:"percent_done"`
+	Done          bool    `json:"done"`
+}
+
+// GetUploadProgress handles GET /api/v1/uploads/in-progress/{token}/progress,
+// reporting how many bytes of a large, still-uploading file have been
+// received so far. Once the client has observed a done response, the entry
+// is forgotten so a finished upload's Progress doesn't linger forever in
+// Tracker's process-lifetime map.
+func (h *Handler) GetUploadProgress(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+
+	p, ok := h.Progress.Get(token)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no upload in progress for this token")
+		return
+	}
+
+	resp := uploadProgressResponse{
+		Token:         token,
+		TotalBytes:    p.TotalSize,
+		BytesReceived: p.BytesReceived(),
+		Done:          p.Done(),
+	}
+	if p.TotalSize > 0 {
+		resp.PercentDone = float64(p.BytesReceived()) / float64(p.TotalSize) * 100
+	}
+
+	if resp.Done {
+		defer h.Progress.Forget(token)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,53 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetUploadProgress(t *testing.T) {
+	t.Run("forgets the entry once the client observes it as done", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+		h.Progress.Start("token-1", 10, strings.NewReader("0123456789"))
+		h.Progress.Finish("token-1")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/in-progress/token-1/progress", nil)
+		req.SetPathValue("token", "token-1")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadProgress(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		req2 := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/in-progress/token-1/progress", nil)
+		req2.SetPathValue("token", "token-1")
+		rec2 := httptest.NewRecorder()
+
+		h.GetUploadProgress(rec2, req2)
+
+		if rec2.Code != http.StatusNotFound {
+			t.Fatalf("expected the entry to have been forgotten, got status %d", rec2.Code)
+		}
+	})
+
+	t.Run("returns 404 for an unknown token", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/in-progress/unknown/progress", nil)
+		req.SetPathValue("token", "unknown")
+		rec := httptest.NewRecorder()
+
+		h.GetUploadProgress(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ListPendingPurgeRetries handles GET /api/v1/admin/purge-retries, gated on
+// permissionPurge, reporting every upload with a Cloudflare purge queued
+// for retry after failing during publishing. 501 if no PurgeRetrier is
+// configured.
+func (h *Handler) ListPendingPurgeRetries(w http.ResponseWriter, r *http.Request) {
+	if h.PurgeRetrier == nil {
+		writeError(w, http.StatusNotImplemented, "purge retry is not configured")
+		return
+	}
+
+	pending, err := h.PurgeRetrier.Pending(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list pending purge retries")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pending)
+}
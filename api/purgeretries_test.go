@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/purge"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+type fakeCloudflare struct{}
+
+func (fakeCloudflare) PurgeCache(context.Context, []string) error { return nil }
+
+func TestListPendingPurgeRetries(t *testing.T) {
+	t.Run("reports uploads with a queued purge retry", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:         "upload-1",
+			PurgeRetry: &models.PurgeRetry{URLs: []string{"https://example.com/a.csv"}, NextRetryAt: time.Now().Add(time.Hour)},
+		}
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(context.Context, string) ([]*models.Upload, error) {
+				return []*models.Upload{upload}, nil
+			},
+		}
+		retrier := purge.NewRetrier(store, fakeCloudflare{}, time.Minute)
+		h := api.NewHandler(store, api.WithPurgeRetrier(retrier))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/purge-retries", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListPendingPurgeRetries(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var resp []models.Upload
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 1 || resp[0].ID != "upload-1" {
+			t.Fatalf("expected upload-1 to be reported pending, got %+v", resp)
+		}
+	})
+
+	t.Run("returns 501 when no PurgeRetrier is configured", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/purge-retries", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListPendingPurgeRetries(rec, req)
+
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("expected status 501, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// QuotaStatus reports a caller's upload quota usage and configured limits,
+// as surfaced by GetStats. A zero MaxUploads/MaxBytes (omitted from the
+// JSON) means that limit is unconfigured.
+type QuotaStatus struct {
+	UploadsToday int   `json:"uploads_today"`
+	BytesToday   int64 `json:"bytes_today"`
+	MaxUploads   int   `json:"max_uploads,omitempty"`
+	MaxBytes     int64 `json:"max_bytes,omitempty"`
+}
+
+// hasQuotaOverride reports whether r's caller holds permissionQuotaOverride,
+// letting them exceed h.MaxUploadsPerDay/h.MaxBytesPerDay. A nil
+// h.Permissions grants the override, consistent with requirePermission
+// treating an unconfigured checker as unrestricted.
+func (h *Handler) hasQuotaOverride(r *http.Request) bool {
+	if h.Permissions == nil {
+		return true
+	}
+
+	granted, err := h.Permissions.HasPermission(r.Context(), r, permissionQuotaOverride)
+	return err == nil && granted
+}
+
+// quotaUsage sums the number of uploads, and their total Size, that
+// identity has submitted since the start of the current UTC day, so
+// CreateUpload and GetStats can measure usage against h.MaxUploadsPerDay/
+// h.MaxBytesPerDay without maintaining a separate counter that could drift
+// from the upload records themselves.
+func (h *Handler) quotaUsage(ctx context.Context, identity string) (count int, bytes int64, err error) {
+	startOfDay := time.Now().UTC().Truncate(24 * time.Hour)
+
+	uploads, err := h.Store.ListUploadsFiltered(ctx, models.UploadFilter{
+		UploadedBy:   identity,
+		CreatedAfter: startOfDay,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, upload := range uploads {
+		count++
+		bytes += upload.Size
+	}
+	return count, bytes, nil
+}
+
+// quotaExceeded reports whether identity has already used up
+// h.MaxUploadsPerDay or h.MaxBytesPerDay for the current UTC day, and the
+// usage counted along the way (so callers that go on to report it, e.g.
+// GetStats, don't need a second query). Both limits are ignored, and
+// exceeded is always false, when identity is empty: quotas are enforced per
+// identified uploader, not against anonymous callers.
+func (h *Handler) quotaExceeded(ctx context.Context, identity string) (exceeded bool, usage QuotaStatus, err error) {
+	usage = QuotaStatus{MaxUploads: h.MaxUploadsPerDay, MaxBytes: h.MaxBytesPerDay}
+	if identity == "" || (h.MaxUploadsPerDay <= 0 && h.MaxBytesPerDay <= 0) {
+		return false, usage, nil
+	}
+
+	count, bytes, err := h.quotaUsage(ctx, identity)
+	if err != nil {
+		return false, usage, err
+	}
+	usage.UploadsToday = count
+	usage.BytesToday = bytes
+
+	if h.MaxUploadsPerDay > 0 && count >= h.MaxUploadsPerDay {
+		return true, usage, nil
+	}
+	if h.MaxBytesPerDay > 0 && bytes >= h.MaxBytesPerDay {
+		return true, usage, nil
+	}
+	return false, usage, nil
+}
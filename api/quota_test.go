@@ -0,0 +1,176 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestCreateUpload_Quota(t *testing.T) {
+	t.Run("rejects with 429 once the daily upload count is reached", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{{ID: "1"}, {ID: "2"}}, nil
+			},
+		}
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return false, nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithUploadQuota(2, 0),
+			api.WithPermissionChecker(permissions),
+		)
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected status 429, got %d", rec.Code)
+		}
+	})
+
+	t.Run("rejects with 429 once the daily byte total is reached", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{{ID: "1", Size: 1000}}, nil
+			},
+		}
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return false, nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithUploadQuota(0, 1000),
+			api.WithPermissionChecker(permissions),
+		)
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected status 429, got %d", rec.Code)
+		}
+	})
+
+	t.Run("permissionQuotaOverride bypasses an exceeded quota", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{{ID: "1"}, {ID: "2"}}, nil
+			},
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		permissions := &apimock.PermissionCheckerMock{
+			HasPermissionFunc: func(ctx context.Context, r *http.Request, permission string) (bool, error) {
+				return true, nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithUploadQuota(2, 0),
+			api.WithPermissionChecker(permissions),
+		)
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("an unidentified caller is not quota-limited", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error { return nil },
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithUploadQuota(2, 0),
+		)
+
+		req := newCreateUploadRequest(t, "weo", "imf_weo_202401.xlsx")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestGetStats_Quota(t *testing.T) {
+	t.Run("reports the caller's quota usage when identified", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return nil, nil
+			},
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{{ID: "1", Size: 500}}, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithUploadQuota(10, 5000))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+		req.Header.Set("X-User-Email", "j.doe@example.com")
+		rec := httptest.NewRecorder()
+
+		h.GetStats(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var resp api.StatsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Quota == nil || resp.Quota.UploadsToday != 1 || resp.Quota.BytesToday != 500 || resp.Quota.MaxUploads != 10 || resp.Quota.MaxBytes != 5000 {
+			t.Fatalf("expected quota usage to be reported, got %+v", resp.Quota)
+		}
+	})
+
+	t.Run("omits quota for an unidentified caller", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return nil, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithUploadQuota(10, 5000))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetStats(rec, req)
+
+		var resp api.StatsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Quota != nil {
+			t.Fatalf("expected no quota to be reported, got %+v", resp.Quota)
+		}
+	})
+}
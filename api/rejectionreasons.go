@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// permissionRejectionReasons gates managing the rejection reason taxonomy.
+const permissionRejectionReasons = "imf:rejection-reasons"
+
+// ListRejectionReasons handles GET /api/v1/admin/rejection-reasons.
+func (h *Handler) ListRejectionReasons(w http.ResponseWriter, r *http.Request) {
+	reasons, err := h.Store.ListRejectionReasons(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list rejection reasons")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reasons)
+}
+
+// SetRejectionReason handles PUT /api/v1/admin/rejection-reasons/{code},
+// creating the reason if it doesn't already exist or updating its label and
+// active state otherwise.
+func (h *Handler) SetRejectionReason(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Label  string `json:"label"`
+		Active *bool  `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if body.Label == "" {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "required",
+			Message: "label is required",
+			Value:   "label",
+		})
+		return
+	}
+
+	active := true
+	if body.Active != nil {
+		active = *body.Active
+	}
+
+	reason := &models.RejectionReason{
+		Code:   r.PathValue("code"),
+		Label:  body.Label,
+		Active: active,
+	}
+	if err := h.Store.SetRejectionReason(r.Context(), reason); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to set rejection reason")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reason)
+}
+
+// DeleteRejectionReason handles DELETE /api/v1/admin/rejection-reasons/{code}.
+func (h *Handler) DeleteRejectionReason(w http.ResponseWriter, r *http.Request) {
+	if err := h.Store.DeleteRejectionReason(r.Context(), r.PathValue("code")); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete rejection reason")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
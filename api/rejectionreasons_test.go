@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestListRejectionReasons(t *testing.T) {
+	store := &storemock.StoreMock{
+		ListRejectionReasonsFunc: func(context.Context) ([]*models.RejectionReason, error) {
+			return []*models.RejectionReason{{Code: "incomplete-data", Label: "Incomplete data", Active: true}}, nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/rejection-reasons", nil)
+	rec := httptest.NewRecorder()
+
+	h.ListRejectionReasons(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var reasons []*models.RejectionReason
+	if err := json.NewDecoder(rec.Body).Decode(&reasons); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(reasons) != 1 || reasons[0].Code != "incomplete-data" {
+		t.Fatalf("unexpected reasons: %+v", reasons)
+	}
+}
+
+func TestSetRejectionReason(t *testing.T) {
+	t.Run("creates or updates the reason keyed by code", func(t *testing.T) {
+		var recorded *models.RejectionReason
+		store := &storemock.StoreMock{
+			SetRejectionReasonFunc: func(_ context.Context, reason *models.RejectionReason) error {
+				recorded = reason
+				return nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/rejection-reasons/incomplete-data",
+			strings.NewReader(`{"label":"Incomplete data"}`))
+		req.SetPathValue("code", "incomplete-data")
+		rec := httptest.NewRecorder()
+
+		h.SetRejectionReason(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if recorded == nil || recorded.Code != "incomplete-data" || !recorded.Active {
+			t.Fatalf("expected an active reason to be recorded, got %+v", recorded)
+		}
+	})
+
+	t.Run("rejects a missing label", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/rejection-reasons/incomplete-data", strings.NewReader(`{}`))
+		req.SetPathValue("code", "incomplete-data")
+		rec := httptest.NewRecorder()
+
+		h.SetRejectionReason(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestDeleteRejectionReason(t *testing.T) {
+	var deleted string
+	store := &storemock.StoreMock{
+		DeleteRejectionReasonFunc: func(_ context.Context, code string) error {
+			deleted = code
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/rejection-reasons/incomplete-data", nil)
+	req.SetPathValue("code", "incomplete-data")
+	rec := httptest.NewRecorder()
+
+	h.DeleteRejectionReason(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if deleted != "incomplete-data" {
+		t.Fatalf("expected incomplete-data to be deleted, got %q", deleted)
+	}
+}
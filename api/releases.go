@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// CreateRelease handles POST /api/v1/releases, grouping the given upload IDs
+// into a release that PublishRelease later publishes together atomically.
+func (h *Handler) CreateRelease(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Dataset    string   `json:"dataset"`
+		UploadIDs  []string `json:"upload_ids"`
+		ReviewedBy string   `json:"reviewed_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(body.UploadIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "upload_ids must not be empty")
+		return
+	}
+
+	now := time.Now()
+	release := &models.Release{
+		ID:         newReleaseID(),
+		Dataset:    body.Dataset,
+		UploadIDs:  body.UploadIDs,
+		Status:     models.ReleaseStatusPending,
+		ReviewedBy: body.ReviewedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := h.Store.CreateRelease(r.Context(), release); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create release")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(release)
+}
+
+// GetRelease handles GET /api/v1/releases/{id}.
+func (h *Handler) GetRelease(w http.ResponseWriter, r *http.Request) {
+	release, err := h.Store.GetRelease(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "release not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(release)
+}
+
+// ListReleases handles GET /api/v1/releases.
+func (h *Handler) ListReleases(w http.ResponseWriter, r *http.Request) {
+	releases, err := h.Store.ListReleases(r.Context(), r.URL.Query().Get("dataset"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list releases")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(releases)
+}
+
+// PublishRelease handles POST /api/v1/releases/{id}/publish. Every upload in
+// the release is backed up and published to S3 in turn; if any of them
+// fails, every upload already published as part of this release is restored
+// from its backup and the release is marked failed, so a half-published
+// dataset never appears on the website. Only once every upload has
+// published successfully is the cache invalidated for all of them together.
+func (h *Handler) PublishRelease(w http.ResponseWriter, r *http.Request) {
+	release, err := h.Store.GetRelease(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "release not found")
+		return
+	}
+	if release.Status != models.ReleaseStatusPending {
+		writeError(w, http.StatusBadRequest, "release is already "+string(release.Status))
+		return
+	}
+
+	uploads := make([]*models.Upload, 0, len(release.UploadIDs))
+	for _, uploadID := range release.UploadIDs {
+		upload, err := h.Store.GetUpload(r.Context(), uploadID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "upload not found: "+uploadID)
+			return
+		}
+		uploads = append(uploads, upload)
+	}
+
+	published, publishErr := h.publishReleaseFiles(r.Context(), uploads)
+	if publishErr != nil {
+		h.rollbackRelease(r.Context(), release, published)
+		writeError(w, http.StatusInternalServerError, "failed to publish release")
+		return
+	}
+
+	h.invalidateRelease(r.Context(), release, uploads)
+
+	release.Status = models.ReleaseStatusPublished
+	release.UpdatedAt = time.Now()
+	if err := h.Store.UpdateRelease(r.Context(), release); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update release")
+		return
+	}
+	h.publishManifest(r.Context())
+
+	h.notify(r.Context(), release.ID, release.Dataset, fmt.Sprintf("release %s published: %d files", release.ID, len(uploads)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(release)
+}
+
+// publishReleaseFiles backs up and publishes each upload's TargetKey in
+// turn, stopping at the first failure. It returns every upload successfully
+// published so far, which the caller must roll back if err is non-nil.
+func (h *Handler) publishReleaseFiles(ctx context.Context, uploads []*models.Upload) (published []*models.Upload, err error) {
+	for _, upload := range uploads {
+		if err := h.S3.BackupFile(ctx, upload.TargetKey); err != nil {
+			return published, fmt.Errorf("failed to back up %s: %w", upload.TargetKey, err)
+		}
+		if err := h.S3.UploadFile(ctx, upload.TargetKey, bytes.NewReader(nil),
+			contentTypeForKey(upload.TargetKey), h.contentDispositionForKey(upload.TargetKey),
+			h.cacheControlForKey(ctx, upload.Dataset, upload.TargetKey)); err != nil {
+			return published, fmt.Errorf("failed to publish %s: %w", upload.TargetKey, err)
+		}
+		published = append(published, upload)
+	}
+	return published, nil
+}
+
+// rollbackRelease restores every upload in published from its backup and
+// marks release failed, so a partially-published release doesn't leave a
+// half-updated dataset live. If the configured S3 backend can't restore,
+// on-call is paged immediately since the rollback itself needs manual
+// intervention.
+func (h *Handler) rollbackRelease(ctx context.Context, release *models.Release, published []*models.Upload) {
+	restorer, ok := h.S3.(Restorer)
+	if !ok {
+		h.alertPublishFailure(ctx, release.ID, "release "+release.ID+" failed but the configured S3 backend cannot restore backups: manual rollback required")
+	} else {
+		for _, upload := range published {
+			if err := restorer.RestoreFile(ctx, upload.TargetKey); err != nil {
+				h.alertPublishFailure(ctx, release.ID, "failed to restore "+upload.TargetKey+" while rolling back release "+release.ID+": "+err.Error())
+			}
+		}
+	}
+
+	release.Status = models.ReleaseStatusFailed
+	release.UpdatedAt = time.Now()
+	_ = h.Store.UpdateRelease(ctx, release)
+
+	h.notifyError(ctx, release.ID, release.Dataset, "release "+release.ID+" failed and was rolled back")
+}
+
+// invalidateRelease invalidates every published upload's TargetKey in a
+// single CloudFront call and records the resulting invalidation ID against
+// each upload. A failure here doesn't roll back the release: every file is
+// already live and correct, just not yet reflected at the edge.
+func (h *Handler) invalidateRelease(ctx context.Context, release *models.Release, uploads []*models.Upload) {
+	paths := make([]string, len(uploads))
+	for i, upload := range uploads {
+		paths[i] = "/" + upload.TargetKey
+	}
+
+	invalidationID, err := h.CloudFront.InvalidatePaths(ctx, paths)
+	if err != nil {
+		h.notifyError(ctx, release.ID, release.Dataset, "failed to invalidate cache for release "+release.ID+": "+err.Error())
+		invalidationID = ""
+	}
+
+	for _, upload := range uploads {
+		upload.InvalidationID = invalidationID
+		upload.Status = models.StatusApproved
+		upload.Checksum, upload.Size, upload.Integrity = h.stagedFileMetadata(ctx, upload)
+		_ = h.Store.UpdateUpload(ctx, upload)
+		h.publishObjectMetadata(ctx, upload)
+	}
+}
+
+func newReleaseID() string {
+	return newInvalidationID()
+}
@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestCreateRelease(t *testing.T) {
+	t.Run("groups the given upload IDs into a pending release", func(t *testing.T) {
+		var recorded *models.Release
+		store := &storemock.StoreMock{
+			CreateReleaseFunc: func(ctx context.Context, release *models.Release) error {
+				recorded = release
+				return nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases", strings.NewReader(`{"dataset":"weo","upload_ids":["1","2"],"reviewed_by":"j.doe"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateRelease(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d", rec.Code)
+		}
+		if recorded == nil || recorded.Status != models.ReleaseStatusPending || len(recorded.UploadIDs) != 2 {
+			t.Fatalf("expected a pending release grouping both uploads, got %+v", recorded)
+		}
+
+		var resp models.Release
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.ID == "" {
+			t.Fatalf("expected response to include the generated release ID")
+		}
+	})
+
+	t.Run("empty upload_ids returns 400", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases", strings.NewReader(`{"dataset":"weo"}`))
+		rec := httptest.NewRecorder()
+
+		h.CreateRelease(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestPublishRelease(t *testing.T) {
+	t.Run("publishes every upload and invalidates them together", func(t *testing.T) {
+		release := &models.Release{ID: "rel-1", Dataset: "weo", Status: models.ReleaseStatusPending, UploadIDs: []string{"1", "2"}}
+		uploads := map[string]*models.Upload{
+			"1": {ID: "1", Dataset: "weo", TargetKey: "imf/weo/2024.csv"},
+			"2": {ID: "2", Dataset: "weo", TargetKey: "imf/weo/2025.csv"},
+		}
+		var updated *models.Release
+		var published []string
+		var invalidatedPaths []string
+		store := &storemock.StoreMock{
+			GetReleaseFunc: func(ctx context.Context, id string) (*models.Release, error) { return release, nil },
+			GetUploadFunc:  func(ctx context.Context, id string) (*models.Upload, error) { return uploads[id], nil },
+			UpdateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+				return nil
+			},
+			UpdateReleaseFunc: func(ctx context.Context, r *models.Release) error {
+				updated = r
+				return nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return nil, nil
+			},
+		}
+		s3 := newFakeS3()
+		s3.BackupFileFunc = func(ctx context.Context, key string) error { return nil }
+		s3.UploadFileFunc = func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+			if key == "manifest.json" {
+				return nil
+			}
+			published = append(published, key)
+			return nil
+		}
+		s3.SetObjectMetadataFunc = func(ctx context.Context, key string, metadata map[string]string) error { return nil }
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) {
+				invalidatedPaths = paths
+				return "INV-REL", nil
+			},
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases/rel-1/publish", nil)
+		req.SetPathValue("id", "rel-1")
+		rec := httptest.NewRecorder()
+
+		h.PublishRelease(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(published) != 2 {
+			t.Fatalf("expected both uploads to be published, got %v", published)
+		}
+		if len(invalidatedPaths) != 2 {
+			t.Fatalf("expected a single batched invalidation covering both uploads, got %v", invalidatedPaths)
+		}
+		if updated == nil || updated.Status != models.ReleaseStatusPublished {
+			t.Fatalf("expected the release to be marked published, got %+v", updated)
+		}
+	})
+
+	t.Run("rolls back already-published uploads when one fails to publish", func(t *testing.T) {
+		release := &models.Release{ID: "rel-2", Dataset: "weo", Status: models.ReleaseStatusPending, UploadIDs: []string{"1", "2"}}
+		uploads := map[string]*models.Upload{
+			"1": {ID: "1", Dataset: "weo", TargetKey: "imf/weo/2024.csv"},
+			"2": {ID: "2", Dataset: "weo", TargetKey: "imf/weo/2025.csv"},
+		}
+		var updated *models.Release
+		var restored []string
+		store := &storemock.StoreMock{
+			GetReleaseFunc:    func(ctx context.Context, id string) (*models.Release, error) { return release, nil },
+			GetUploadFunc:     func(ctx context.Context, id string) (*models.Upload, error) { return uploads[id], nil },
+			UpdateReleaseFunc: func(ctx context.Context, r *models.Release) error { updated = r; return nil },
+		}
+		s3 := newFakeS3()
+		s3.BackupFileFunc = func(ctx context.Context, key string) error { return nil }
+		s3.UploadFileFunc = func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+			if key == "imf/weo/2025.csv" {
+				return errors.New("access denied")
+			}
+			return nil
+		}
+		s3.RestoreFileFunc = func(ctx context.Context, key string) error {
+			restored = append(restored, key)
+			return nil
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithAlerter(&fakeAlerter{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases/rel-2/publish", nil)
+		req.SetPathValue("id", "rel-2")
+		rec := httptest.NewRecorder()
+
+		h.PublishRelease(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", rec.Code)
+		}
+		if len(restored) != 1 || restored[0] != "imf/weo/2024.csv" {
+			t.Fatalf("expected only the already-published upload to be restored, got %v", restored)
+		}
+		if updated == nil || updated.Status != models.ReleaseStatusFailed {
+			t.Fatalf("expected the release to be marked failed, got %+v", updated)
+		}
+	})
+
+	t.Run("re-publishing an already-published release returns 400", func(t *testing.T) {
+		release := &models.Release{ID: "rel-3", Status: models.ReleaseStatusPublished}
+		store := &storemock.StoreMock{
+			GetReleaseFunc: func(ctx context.Context, id string) (*models.Release, error) { return release, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases/rel-3/publish", nil)
+		req.SetPathValue("id", "rel-3")
+		rec := httptest.NewRecorder()
+
+		h.PublishRelease(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("release referencing a missing upload returns 404", func(t *testing.T) {
+		release := &models.Release{ID: "rel-4", Status: models.ReleaseStatusPending, UploadIDs: []string{"missing"}}
+		store := &storemock.StoreMock{
+			GetReleaseFunc: func(ctx context.Context, id string) (*models.Release, error) { return release, nil },
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) {
+				return nil, errors.New("upload not found")
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/releases/rel-4/publish", nil)
+		req.SetPathValue("id", "rel-4")
+		rec := httptest.NewRecorder()
+
+		h.PublishRelease(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// SLAReport summarises review and publish turnaround for uploads created in
+// [From, To), so team leads can track review performance for a given
+// period, e.g. a calendar month.
+type SLAReport struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	TotalUploads int `json:"total_uploads"`
+	// Reviewed counts uploads that have been approved or rejected, i.e.
+	// have a non-zero ReviewedAt. An upload still pending isn't included in
+	// any of the average or percentage figures below.
+	Reviewed  int `json:"reviewed"`
+	Published int `json:"published"`
+
+	// AvgTimeToFirstReviewSeconds and AvgTimeToPublishSeconds are 0 when
+	// Reviewed or Published is 0, rather than a division-by-zero NaN.
+	AvgTimeToFirstReviewSeconds float64 `json:"avg_time_to_first_review_seconds"`
+	AvgTimeToPublishSeconds     float64 `json:"avg_time_to_publish_seconds"`
+
+	// PercentWithinReviewTarget and PercentWithinPublishTarget are the
+	// share of Reviewed/Published uploads handled within
+	// h.SLAReviewTarget/h.SLAPublishTarget. Both are 0 when the
+	// corresponding target isn't configured.
+	PercentWithinReviewTarget  float64 `json:"percent_within_review_target"`
+	PercentWithinPublishTarget float64 `json:"percent_within_publish_target"`
+}
+
+// GetSLAReport handles GET /api/v1/reports/sla, computing SLA metrics for
+// uploads created within the ?from and ?to date-range query parameters
+// (RFC 3339; both optional, an unset bound is unconstrained).
+func (h *Handler) GetSLAReport(w http.ResponseWriter, r *http.Request) {
+	filter := models.UploadFilter{}
+
+	if from := r.URL.Query().Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			writeValidationErrors(w, models.ValidationError{Rule: "invalid_date", Message: "from must be an RFC 3339 timestamp", Value: from})
+			return
+		}
+		filter.CreatedAfter = parsed
+	}
+	if to := r.URL.Query().Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			writeValidationErrors(w, models.ValidationError{Rule: "invalid_date", Message: "to must be an RFC 3339 timestamp", Value: to})
+			return
+		}
+		filter.CreatedBefore = parsed
+	}
+
+	uploads, err := h.Store.ListUploadsFiltered(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	report := SLAReport{From: filter.CreatedAfter, To: filter.CreatedBefore, TotalUploads: len(uploads)}
+
+	var (
+		reviewDurations  []time.Duration
+		publishDurations []time.Duration
+	)
+	for _, upload := range uploads {
+		if upload.ReviewedAt.IsZero() {
+			continue
+		}
+		report.Reviewed++
+		reviewDurations = append(reviewDurations, upload.ReviewedAt.Sub(upload.CreatedAt))
+
+		if upload.PublishedAt.IsZero() {
+			continue
+		}
+		report.Published++
+		publishDurations = append(publishDurations, upload.PublishedAt.Sub(upload.CreatedAt))
+	}
+
+	report.AvgTimeToFirstReviewSeconds = averageSeconds(reviewDurations)
+	report.AvgTimeToPublishSeconds = averageSeconds(publishDurations)
+	if h.SLAReviewTarget > 0 {
+		report.PercentWithinReviewTarget = percentWithin(reviewDurations, h.SLAReviewTarget)
+	}
+	if h.SLAPublishTarget > 0 {
+		report.PercentWithinPublishTarget = percentWithin(publishDurations, h.SLAPublishTarget)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func averageSeconds(durations []time.Duration) float64 {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total.Seconds() / float64(len(durations))
+}
+
+func percentWithin(durations []time.Duration, target time.Duration) float64 {
+	if len(durations) == 0 {
+		return 0
+	}
+	var within int
+	for _, d := range durations {
+		if d <= target {
+			within++
+		}
+	}
+	return 100 * float64(within) / float64(len(durations))
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetSLAReport(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("computes averages and within-target percentages", func(t *testing.T) {
+		fast := &models.Upload{
+			ID: "1", CreatedAt: created,
+			ReviewedAt: created.Add(time.Hour), PublishedAt: created.Add(time.Hour),
+		}
+		slow := &models.Upload{
+			ID: "2", CreatedAt: created,
+			ReviewedAt: created.Add(48 * time.Hour), PublishedAt: created.Add(48 * time.Hour),
+		}
+		pending := &models.Upload{ID: "3", CreatedAt: created}
+
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(context.Context, models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{fast, slow, pending}, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithSLATargets(24*time.Hour, 24*time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/sla", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetSLAReport(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var report api.SLAReport
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if report.TotalUploads != 3 || report.Reviewed != 2 || report.Published != 2 {
+			t.Fatalf("unexpected counts: %+v", report)
+		}
+		if report.PercentWithinReviewTarget != 50 || report.PercentWithinPublishTarget != 50 {
+			t.Fatalf("expected 50%% within target for both, got %+v", report)
+		}
+	})
+
+	t.Run("rejects an invalid date filter", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/sla?from=not-a-date", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetSLAReport(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("reports zero percentages when no target is configured", func(t *testing.T) {
+		reviewed := &models.Upload{ID: "1", CreatedAt: created, ReviewedAt: created.Add(time.Hour), PublishedAt: created.Add(time.Hour)}
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(context.Context, models.UploadFilter) ([]*models.Upload, error) {
+				return []*models.Upload{reviewed}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/sla", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetSLAReport(rec, req)
+
+		var report api.SLAReport
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if report.PercentWithinReviewTarget != 0 || report.PercentWithinPublishTarget != 0 {
+			t.Fatalf("expected 0%% when no target is configured, got %+v", report)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header every response carries a request ID under,
+// and the header an inbound request can set to have that ID propagated
+// through instead of one being generated.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDContextKey struct{}
+
+// RequestID wraps next, ensuring every response carries an X-Request-Id
+// header: the one supplied on the inbound request if present, or a freshly
+// generated one otherwise. Handlers can retrieve it via
+// RequestIDFromContext, e.g. to include in a logged or alerted error so a
+// user can quote it in a support request.
+func (h *Handler) RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id)))
+	})
+}
+
+// RequestIDFromContext returns the request ID set by RequestID, or "" if
+// the request was never routed through it.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
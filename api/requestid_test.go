@@ -0,0 +1,51 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = api.RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	h := api.NewHandler(&storemock.StoreMock{})
+
+	t.Run("generates a request ID when the request doesn't supply one", func(t *testing.T) {
+		seen = ""
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.RequestID(next).ServeHTTP(rec, req)
+
+		got := rec.Header().Get(api.RequestIDHeader)
+		if got == "" {
+			t.Fatalf("expected an %s response header", api.RequestIDHeader)
+		}
+		if seen != got {
+			t.Fatalf("expected the handler's context to carry the same ID as the response header, got %q vs %q", seen, got)
+		}
+	})
+
+	t.Run("propagates a request ID supplied by the caller", func(t *testing.T) {
+		seen = ""
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		req.Header.Set(api.RequestIDHeader, "caller-supplied-id")
+		rec := httptest.NewRecorder()
+
+		h.RequestID(next).ServeHTTP(rec, req)
+
+		if got := rec.Header().Get(api.RequestIDHeader); got != "caller-supplied-id" {
+			t.Fatalf("expected the caller's request ID to be echoed, got %q", got)
+		}
+		if seen != "caller-supplied-id" {
+			t.Fatalf("expected the handler's context to carry the caller's request ID, got %q", seen)
+		}
+	})
+}
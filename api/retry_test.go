@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestPublishScheduled_MarksFailedOnError(t *testing.T) {
+	scheduled := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", TempKey: "tmp/1", Status: models.StatusScheduled}
+	var updated *models.Upload
+	store := &storemock.StoreMock{
+		ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+			return []*models.Upload{scheduled}, nil
+		},
+		UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { updated = u; return nil },
+	}
+	s3 := &apimock.S3ClientMock{
+		BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+		UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return errors.New("s3 unreachable") },
+	}
+	h := api.NewHandler(store, api.WithS3(s3))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduled-publishes/run", nil)
+	rec := httptest.NewRecorder()
+
+	h.PublishScheduled(rec, req)
+
+	if updated == nil || updated.Status != models.StatusFailed || updated.FailureReason == "" {
+		t.Fatalf("expected the upload to be marked failed with a reason, got %+v", updated)
+	}
+	if updated.TempKey != "tmp/1" {
+		t.Fatalf("expected TempKey to be left untouched, got %q", updated.TempKey)
+	}
+
+	if len(updated.PublishSteps) != 2 {
+		t.Fatalf("expected backup and s3_upload steps to be recorded, got %+v", updated.PublishSteps)
+	}
+	if updated.PublishSteps[0].Name != models.PublishStepBackup || updated.PublishSteps[0].Status != models.PublishStepSucceeded {
+		t.Fatalf("expected backup to have succeeded, got %+v", updated.PublishSteps[0])
+	}
+	if updated.PublishSteps[1].Name != models.PublishStepS3Upload || updated.PublishSteps[1].Status != models.PublishStepFailed {
+		t.Fatalf("expected s3_upload to have failed, got %+v", updated.PublishSteps[1])
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("republishes a failed upload", func(t *testing.T) {
+		failed := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusFailed, FailureReason: "boom"}
+		var updated *models.Upload
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return failed, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { updated = u; return nil },
+			ListUploadsFunc:  func(ctx context.Context, dataset string) ([]*models.Upload, error) { return nil, nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV1", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/retry", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Retry(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if updated == nil || updated.Status != models.StatusApproved || updated.FailureReason != "" {
+			t.Fatalf("expected the upload to be republished with FailureReason cleared, got %+v", updated)
+		}
+		if len(updated.PublishSteps) != 5 {
+			t.Fatalf("expected all five publish steps to be recorded, got %+v", updated.PublishSteps)
+		}
+		if updated.PublishSteps[3].Name != models.PublishStepCloudflare || updated.PublishSteps[3].Status != models.PublishStepSkipped {
+			t.Fatalf("expected cloudflare to be skipped when no client is configured, got %+v", updated.PublishSteps[3])
+		}
+	})
+
+	t.Run("rejects an upload that is not failed", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) {
+				return &models.Upload{ID: "1", Status: models.StatusApproved}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/retry", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Retry(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("returns 404 for an unknown upload", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) {
+				return nil, errors.New("not found")
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/retry", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.Retry(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
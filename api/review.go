@@ -0,0 +1,643 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// presignExpiry is how long a signed download URL included in an approval
+// notification remains valid, giving a reviewer or consumer time to click
+// through without leaving the link usable indefinitely.
+const presignExpiry = 24 * time.Hour
+
+// purgeRetryBackoff is how long purge.Retrier waits before its first retry
+// of a failed Cloudflare purge, recorded as the initial PurgeRetry.NextRetryAt.
+const purgeRetryBackoff = 5 * time.Minute
+
+// Approve handles POST /uploads/{id}/approve. The request body must
+// acknowledge every item in h.ApprovalChecklist before the file is
+// published to S3, CloudFront is invalidated, and interested parties are
+// notified. TargetKey optionally overrides the upload's destination key,
+// e.g. to fix a typo in the filename, so a reviewer doesn't have to reject
+// and ask for a re-upload over a cosmetic mistake.
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var body struct {
+		Acknowledged []string `json:"acknowledged"`
+		ReviewedBy   string   `json:"reviewed_by"`
+		TargetKey    string   `json:"target_key"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	h.approveUpload(w, r, id, body.Acknowledged, body.ReviewedBy, body.TargetKey)
+}
+
+// approveUpload runs the approval workflow for id, requiring acknowledged
+// to cover h.ApprovalChecklist. It is shared by the HTTP Approve handler
+// and the Slack interactivity callback, which supplies reviewedBy from the
+// clicking Slack user and an empty targetKeyOverride, since renaming isn't
+// exposed through Slack buttons.
+func (h *Handler) approveUpload(w http.ResponseWriter, r *http.Request, id string, acknowledged []string, reviewedBy, targetKeyOverride string) {
+	if missing := missingChecklistItems(h.ApprovalChecklist, acknowledged); len(missing) > 0 {
+		errs := make([]models.ValidationError, len(missing))
+		for i, item := range missing {
+			errs[i] = models.ValidationError{
+				Rule:    "acknowledgement_required",
+				Message: "required checklist item was not acknowledged",
+				Value:   item,
+			}
+		}
+		writeValidationErrors(w, errs...)
+		return
+	}
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if upload.Status == models.StatusApproved || upload.Status == models.StatusPublished {
+		if isRepeatApproval(upload, acknowledged, reviewedBy, targetKeyOverride) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ApproveResponse{
+				Upload:     upload,
+				PublicURLs: h.publicURLs(upload.TargetKey),
+			})
+			return
+		}
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "upload_already_reviewed",
+			Message: "upload has already been approved with different parameters",
+			Value:   string(upload.Status),
+		})
+		return
+	}
+
+	if h.PreventSelfApproval && selfApproval(upload.UploadedBy, reviewedBy) {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "self_approval_not_allowed",
+			Message: "a reviewer cannot approve their own upload",
+			Value:   reviewedBy,
+		})
+		return
+	}
+
+	if h.EnforceDatasetReviewers {
+		if profile, err := h.Store.GetDatasetProfile(r.Context(), upload.Dataset); err == nil && len(profile.RequiredReviewers) > 0 && !containsString(profile.RequiredReviewers, reviewedBy) {
+			writeValidationErrors(w, models.ValidationError{
+				Rule:    "reviewer_not_authorized_for_dataset",
+				Message: "this dataset requires approval from one of its designated reviewers",
+				Value:   reviewedBy,
+			})
+			return
+		}
+	}
+
+	if h.RevalidateOnApprove {
+		if errs := h.revalidateUpload(r.Context(), upload); len(errs) > 0 {
+			writeValidationErrors(w, errs...)
+			return
+		}
+	}
+
+	if targetKeyOverride != "" && targetKeyOverride != upload.TargetKey {
+		if verr := validateTargetKeyOverride(upload.TargetKey, targetKeyOverride); verr != nil {
+			writeValidationErrors(w, *verr)
+			return
+		}
+		upload.OriginalTargetKey = upload.TargetKey
+		upload.TargetKey = targetKeyOverride
+	}
+
+	if !h.publishWindowOpen(time.Now()) && !h.hasPublishOverride(r) {
+		upload.Status = models.StatusScheduled
+		upload.Acknowledgements = acknowledged
+		upload.ReviewedBy = reviewedBy
+		if err := h.Store.UpdateUpload(r.Context(), upload); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to update upload")
+			return
+		}
+		h.Events.Publish(upload.ID)
+		h.notify(r.Context(), upload.ID, upload.Dataset, "upload approved and queued for the next publish window: "+upload.ID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(upload)
+		return
+	}
+
+	if err := h.publishUpload(r.Context(), upload, acknowledged, reviewedBy); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to publish upload")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ApproveResponse{
+		Upload:     upload,
+		PublicURLs: h.publicURLs(upload.TargetKey),
+	})
+}
+
+// publishUpload backs up and publishes upload.TargetKey to S3, invalidates
+// the CDN cache for it, records the resulting checksum/size/integrity and
+// invalidation ID, and notifies interested parties. It is shared by
+// approveUpload's immediate-publish path and PublishScheduled, which
+// publishes uploads queued while the publish window was closed.
+func (h *Handler) publishUpload(ctx context.Context, upload *models.Upload, acknowledged []string, reviewedBy string) error {
+	upload.PublishSteps = nil
+
+	startPublishStep(upload, models.PublishStepBackup)
+	if err := h.S3.BackupFile(ctx, upload.TargetKey); err != nil {
+		completePublishStep(upload, models.PublishStepBackup, err)
+		message := "failed to back up " + upload.TargetKey + " before publishing: " + err.Error()
+		h.notifyError(ctx, upload.ID, upload.Dataset, message)
+		h.alertPublishFailure(ctx, upload.ID, message)
+		h.markPublishFailed(ctx, upload, message)
+		return fmt.Errorf("failed to back up existing file: %w", err)
+	}
+	completePublishStep(upload, models.PublishStepBackup, nil)
+
+	startPublishStep(upload, models.PublishStepS3Upload)
+	if err := h.S3.UploadFile(ctx, upload.TargetKey, bytes.NewReader(nil),
+		contentTypeForKey(upload.TargetKey), h.contentDispositionForKey(upload.TargetKey),
+		h.cacheControlForKey(ctx, upload.Dataset, upload.TargetKey)); err != nil {
+		completePublishStep(upload, models.PublishStepS3Upload, err)
+		message := "failed to publish " + upload.TargetKey + ": " + err.Error()
+		h.notifyError(ctx, upload.ID, upload.Dataset, message)
+		h.alertPublishFailure(ctx, upload.ID, message)
+		h.markPublishFailed(ctx, upload, message)
+		return fmt.Errorf("failed to publish file: %w", err)
+	}
+	completePublishStep(upload, models.PublishStepS3Upload, nil)
+
+	startPublishStep(upload, models.PublishStepCloudFront)
+	invalidationID, err := h.CloudFront.InvalidatePaths(ctx, []string{"/" + upload.TargetKey})
+	if err != nil {
+		completePublishStep(upload, models.PublishStepCloudFront, err)
+		message := "failed to invalidate cache for " + upload.TargetKey + ": " + err.Error()
+		h.notifyError(ctx, upload.ID, upload.Dataset, message)
+		h.alertCDNFailure(ctx, upload.TargetKey, "repeated CloudFront invalidation failures for "+upload.TargetKey+": "+err.Error())
+		h.markPublishFailed(ctx, upload, message)
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+	completePublishStep(upload, models.PublishStepCloudFront, nil)
+	h.resetCDNFailures(upload.TargetKey)
+	upload.InvalidationID = invalidationID
+
+	// Cloudflare is a secondary CDN: purging it is best-effort and never
+	// fails the publish, since CloudFront invalidation above already covers
+	// cache correctness for the primary CDN. Unlike CloudFront, Cloudflare
+	// purges by full URL rather than by path, so this reuses
+	// h.publicURLs instead of upload.TargetKey directly; with no
+	// PublicURLTemplates configured there is no known public URL to purge,
+	// so the step is skipped rather than sent an empty purge.
+	if urls := h.publicURLs(upload.TargetKey); h.Cloudflare != nil && len(urls) > 0 {
+		startPublishStep(upload, models.PublishStepCloudflare)
+		if err := h.Cloudflare.PurgeCache(ctx, urls); err != nil {
+			completePublishStep(upload, models.PublishStepCloudflare, err)
+			upload.PurgeRetry = &models.PurgeRetry{URLs: urls, LastError: err.Error(), NextRetryAt: time.Now().Add(purgeRetryBackoff)}
+		} else {
+			completePublishStep(upload, models.PublishStepCloudflare, nil)
+		}
+	} else {
+		skipPublishStep(upload, models.PublishStepCloudflare)
+	}
+
+	startPublishStep(upload, models.PublishStepVerify)
+	upload.Checksum, upload.Size, upload.Integrity = h.stagedFileMetadata(ctx, upload)
+	completePublishStep(upload, models.PublishStepVerify, nil)
+
+	upload.Status = models.StatusApproved
+	upload.Acknowledgements = acknowledged
+	upload.ReviewedBy = reviewedBy
+	if upload.ReviewedAt.IsZero() {
+		upload.ReviewedAt = time.Now()
+	}
+	upload.PublishedAt = time.Now()
+	if err := h.Store.UpdateUpload(ctx, upload); err != nil {
+		return fmt.Errorf("failed to update upload: %w", err)
+	}
+	h.Events.Publish(upload.ID)
+	h.publishManifest(ctx)
+	h.publishObjectMetadata(ctx, upload)
+
+	message := "upload approved and published: " + upload.ID
+	if upload.OriginalTargetKey != "" {
+		message += fmt.Sprintf(" (renamed from %s to %s)", upload.OriginalTargetKey, upload.TargetKey)
+	}
+	if url := h.presignedDownloadURL(ctx, upload.TargetKey); url != "" {
+		message += "\n" + url
+	}
+	h.notify(ctx, upload.ID, upload.Dataset, message)
+
+	return nil
+}
+
+// markPublishFailed records a mid-publish failure on upload as
+// models.StatusFailed with reason, so a reviewer can see why it stalled and
+// retry via Retry once the underlying issue (an S3 or CloudFront problem)
+// is resolved. upload.TempKey is left untouched, since the staged file is
+// still needed for the retry. It is best-effort: a failure to persist the
+// failure itself doesn't change the error already being returned to the
+// caller.
+func (h *Handler) markPublishFailed(ctx context.Context, upload *models.Upload, reason string) {
+	upload.Status = models.StatusFailed
+	upload.FailureReason = reason
+	_ = h.Store.UpdateUpload(ctx, upload)
+	h.Events.Publish(upload.ID)
+}
+
+// startPublishStep appends a running models.PublishStep named name to
+// upload.PublishSteps, so a client polling the upload mid-publish can see
+// exactly which step is in flight.
+func startPublishStep(upload *models.Upload, name models.PublishStepName) {
+	upload.PublishSteps = append(upload.PublishSteps, models.PublishStep{
+		Name:      name,
+		Status:    models.PublishStepRunning,
+		StartedAt: time.Now(),
+	})
+}
+
+// completePublishStep marks the most recently started step named name as
+// succeeded, or failed with stepErr's message if stepErr is non-nil.
+func completePublishStep(upload *models.Upload, name models.PublishStepName, stepErr error) {
+	for i := len(upload.PublishSteps) - 1; i >= 0; i-- {
+		if upload.PublishSteps[i].Name != name {
+			continue
+		}
+		upload.PublishSteps[i].CompletedAt = time.Now()
+		if stepErr != nil {
+			upload.PublishSteps[i].Status = models.PublishStepFailed
+			upload.PublishSteps[i].Error = stepErr.Error()
+			return
+		}
+		upload.PublishSteps[i].Status = models.PublishStepSucceeded
+		return
+	}
+}
+
+// skipPublishStep appends a skipped models.PublishStep named name to
+// upload.PublishSteps, for a step that doesn't apply to this publish, e.g.
+// no Cloudflare client is configured.
+func skipPublishStep(upload *models.Upload, name models.PublishStepName) {
+	upload.PublishSteps = append(upload.PublishSteps, models.PublishStep{Name: name, Status: models.PublishStepSkipped})
+}
+
+// ApproveResponse is the body returned by Approve: the updated upload
+// alongside the resolved public URL(s) it is now reachable at, so clients
+// don't have to hardcode host mappings themselves.
+type ApproveResponse struct {
+	*models.Upload
+	PublicURLs []string `json:"public_urls,omitempty"`
+}
+
+// publicURLs builds the public URL(s) for key from h.PublicURLTemplates,
+// each a fmt.Sprintf pattern keyed by key. Empty h.PublicURLTemplates
+// returns nil, so public_urls is omitted from the response.
+func (h *Handler) publicURLs(key string) []string {
+	if len(h.PublicURLTemplates) == 0 {
+		return nil
+	}
+
+	urls := make([]string, len(h.PublicURLTemplates))
+	for i, template := range h.PublicURLTemplates {
+		urls[i] = fmt.Sprintf(template, key)
+	}
+	return urls
+}
+
+// stagedFileMetadata returns the sha256 (hex-encoded) checksum, byte size and
+// Subresource-Integrity-style sha384 digest of upload's staged file content,
+// so background reconciliation, the publish manifest and integrity
+// publication have something to work from, or ("", 0, "") if no Temp backend
+// is configured or the file can't be read. It is best-effort: a failure here
+// doesn't fail the approval, since publishing has already succeeded.
+func (h *Handler) stagedFileMetadata(ctx context.Context, upload *models.Upload) (checksum string, size int64, integrity string) {
+	if h.Temp == nil || upload.TempKey == "" {
+		return "", 0, ""
+	}
+
+	rc, err := h.Temp.Get(ctx, upload.TempKey)
+	if err != nil {
+		return "", 0, ""
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return "", 0, ""
+	}
+
+	sum := sha256.Sum256(content)
+	digest := sha512.Sum384(content)
+	integrity = "sha384-" + base64.StdEncoding.EncodeToString(digest[:])
+	return hex.EncodeToString(sum[:]), int64(len(content)), integrity
+}
+
+// publishObjectMetadata sets S3 object metadata on upload.TargetKey
+// tracing it back to the approval that published it, so anyone examining
+// the bucket directly can identify the upload ID, uploader, reviewer,
+// checksum and integrity digest of a published file without calling the
+// API or touching the database. It is best-effort: a failure here doesn't
+// fail the approval, and nothing is set if the configured S3 backend
+// doesn't support metadata.
+func (h *Handler) publishObjectMetadata(ctx context.Context, upload *models.Upload) {
+	setter, ok := h.S3.(S3MetadataSetter)
+	if !ok {
+		return
+	}
+
+	metadata := map[string]string{
+		"upload-id":      upload.ID,
+		"source-service": "dis-imf-uploader",
+	}
+	if upload.UploadedBy != "" {
+		metadata["uploaded-by"] = upload.UploadedBy
+	}
+	if upload.ReviewedBy != "" {
+		metadata["reviewed-by"] = upload.ReviewedBy
+	}
+	if upload.Checksum != "" {
+		metadata["checksum"] = upload.Checksum
+	}
+	if upload.Integrity != "" {
+		metadata["integrity"] = upload.Integrity
+	}
+
+	_ = setter.SetObjectMetadata(ctx, upload.TargetKey, metadata)
+}
+
+// presignedDownloadURL returns a short-lived signed URL for key so an
+// approval notification can link straight to the published file, or "" if
+// the configured S3 backend doesn't support presigning or generating one
+// fails. It is best-effort: a failure here doesn't fail the approval.
+func (h *Handler) presignedDownloadURL(ctx context.Context, key string) string {
+	presigner, ok := h.S3.(S3Presigner)
+	if !ok {
+		return ""
+	}
+
+	url, err := presigner.PresignURL(ctx, key, presignExpiry)
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+// validateTargetKeyOverride checks that override is a safe replacement for
+// original: non-empty, free of path traversal, and within the same
+// directory as original so a rename can only fix a filename, not move the
+// file to an unrelated dataset's prefix.
+func validateTargetKeyOverride(original, override string) *models.ValidationError {
+	if strings.TrimSpace(override) == "" {
+		return &models.ValidationError{Rule: "target_key_required", Message: "target_key must not be empty", Value: override}
+	}
+	if strings.Contains(override, "..") {
+		return &models.ValidationError{Rule: "target_key_no_traversal", Message: "target_key must not contain '..'", Value: override}
+	}
+	if path.Dir(override) != path.Dir(original) {
+		return &models.ValidationError{
+			Rule:    "target_key_same_directory",
+			Message: fmt.Sprintf("target_key must stay within %s/", path.Dir(original)),
+			Value:   override,
+		}
+	}
+	return nil
+}
+
+// revalidateUpload re-runs content validation against upload's staged bytes
+// using the currently configured policies, so a rule tightened since the
+// file was originally staged still blocks approval. It returns nil (no
+// findings) if no Temp backend is configured, TempKey is empty, the staged
+// file can no longer be read, or the file passes.
+func (h *Handler) revalidateUpload(ctx context.Context, upload *models.Upload) []models.ValidationError {
+	if h.Temp == nil || upload.TempKey == "" {
+		return nil
+	}
+
+	rc, err := h.Temp.Get(ctx, upload.TempKey)
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil
+	}
+
+	result := h.validateByExtension(upload.Filename, data)
+	if result.Valid {
+		return nil
+	}
+
+	errs := make([]models.ValidationError, len(result.Findings))
+	for i, finding := range result.Findings {
+		errs[i] = models.ValidationError{Rule: finding.Code, Message: finding.Message, Value: upload.Filename}
+	}
+	return errs
+}
+
+// selfApproval reports whether uploadedBy and reviewedBy identify the same
+// person, so a reviewer can't approve their own upload. Comparison is
+// case-insensitive since both are typically email addresses.
+func selfApproval(uploadedBy, reviewedBy string) bool {
+	if uploadedBy == "" || reviewedBy == "" {
+		return false
+	}
+	return strings.EqualFold(uploadedBy, reviewedBy)
+}
+
+// isRepeatApproval reports whether upload, already approved or published,
+// was approved with the same reviewer, checklist acknowledgements and
+// target key override as this request, so a double-clicked or retried
+// Approve can be answered idempotently instead of either erroring or
+// re-running the publish workflow against an already-published upload.
+func isRepeatApproval(upload *models.Upload, acknowledged []string, reviewedBy, targetKeyOverride string) bool {
+	if !strings.EqualFold(upload.ReviewedBy, reviewedBy) {
+		return false
+	}
+	if targetKeyOverride != "" && targetKeyOverride != upload.TargetKey {
+		return false
+	}
+	return len(missingChecklistItems(acknowledged, upload.Acknowledgements)) == 0 &&
+		len(missingChecklistItems(upload.Acknowledgements, acknowledged)) == 0
+}
+
+// containsString reports whether value is present in list, comparing
+// case-insensitively since reviewer identifiers are typically email
+// addresses.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingChecklistItems returns the entries of required that are not
+// present in acknowledged.
+func missingChecklistItems(required, acknowledged []string) []string {
+	got := make(map[string]bool, len(acknowledged))
+	for _, item := range acknowledged {
+		got[item] = true
+	}
+
+	var missing []string
+	for _, item := range required {
+		if !got[item] {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// Reject handles POST /uploads/{id}/reject. ReasonCode must reference an
+// active models.RejectionReason; Notes is optional free text alongside it.
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var body struct {
+		ReasonCode string `json:"reason_code"`
+		Notes      string `json:"notes"`
+		ReviewedBy string `json:"reviewed_by"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	reason, err := h.Store.GetRejectionReason(r.Context(), body.ReasonCode)
+	if err != nil || !reason.Active {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "unknown_reason_code",
+			Message: "reason_code must reference an active rejection reason",
+			Value:   body.ReasonCode,
+		})
+		return
+	}
+
+	h.rejectUpload(w, r, id, reason.Code, body.Notes, body.ReviewedBy)
+}
+
+// rejectUpload runs the rejection workflow for id, shared by the HTTP
+// Reject handler and the Slack interactivity callback. reasonCode is
+// recorded as-is without validation, since only the Reject handler has
+// collected and validated one against the managed taxonomy; the Slack
+// callback doesn't yet have a way to prompt for one.
+func (h *Handler) rejectUpload(w http.ResponseWriter, r *http.Request, id, reasonCode, notes, reviewedBy string) {
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	upload.Status = models.StatusRejected
+	upload.RejectionReasonCode = reasonCode
+	upload.ReviewNotes = notes
+	upload.ReviewedBy = reviewedBy
+	if upload.ReviewedAt.IsZero() {
+		upload.ReviewedAt = time.Now()
+	}
+
+	if err := h.Store.UpdateUpload(r.Context(), upload); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update upload")
+		return
+	}
+	h.Events.Publish(upload.ID)
+
+	message := "upload rejected: " + upload.ID
+	if reason, err := h.Store.GetRejectionReason(r.Context(), reasonCode); err == nil {
+		message += " (" + reason.Label + ")"
+	}
+	h.notify(r.Context(), upload.ID, upload.Dataset, message)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(upload)
+}
+
+// ExtendTTL handles POST /uploads/{id}/extend-ttl, resetting the temp
+// storage countdown on a pending upload's staged file back to h.TempTTL,
+// e.g. when a reviewer picks it up but needs longer than the TTL to finish
+// reviewing it. Without this, an upload under active review can still lose
+// its underlying bytes at the original TTL, forcing a re-upload for a
+// purely administrative delay.
+func (h *Handler) ExtendTTL(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if upload.Status != models.StatusPending {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "not_pending",
+			Message: "only a pending upload's temp storage TTL can be extended",
+			Value:   string(upload.Status),
+		})
+		return
+	}
+
+	if h.Temp == nil || upload.TempKey == "" {
+		writeError(w, http.StatusNotImplemented, "temp storage is not configured for this upload")
+		return
+	}
+
+	if err := h.Temp.SetTTL(r.Context(), upload.TempKey, h.TempTTL); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to extend temp storage TTL")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.decorateTempAvailability(r.Context(), upload))
+}
+
+// Retry handles POST /uploads/{id}/retry, re-running publishUpload for an
+// upload left in models.StatusFailed after a mid-approval S3 or CloudFront
+// failure. Since a failed publish leaves TempKey untouched, retrying just
+// resumes from the backup step rather than requiring a fresh upload.
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if upload.Status != models.StatusFailed {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "not_failed",
+			Message: "only an upload in the failed status can be retried",
+			Value:   string(upload.Status),
+		})
+		return
+	}
+
+	upload.FailureReason = ""
+	if err := h.publishUpload(r.Context(), upload, upload.Acknowledgements, upload.ReviewedBy); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to publish upload")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ApproveResponse{
+		Upload:     upload,
+		PublicURLs: h.publicURLs(upload.TargetKey),
+	})
+}
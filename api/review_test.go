@@ -0,0 +1,258 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestReject(t *testing.T) {
+	t.Run("rejects a request with an unknown reason code", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetRejectionReasonFunc: func(context.Context, string) (*models.RejectionReason, error) {
+				return nil, context.DeadlineExceeded
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/reject", strings.NewReader(`{"reason_code":"bogus"}`))
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.Reject(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("rejects a request against an inactive reason code", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetRejectionReasonFunc: func(context.Context, string) (*models.RejectionReason, error) {
+				return &models.RejectionReason{Code: "retired", Label: "Retired", Active: false}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/reject", strings.NewReader(`{"reason_code":"retired"}`))
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.Reject(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("records the reason code on a valid rejection", func(t *testing.T) {
+		upload := &models.Upload{ID: "upload-1", Status: models.StatusPending}
+		var updated *models.Upload
+		store := &storemock.StoreMock{
+			GetRejectionReasonFunc: func(context.Context, string) (*models.RejectionReason, error) {
+				return &models.RejectionReason{Code: "incomplete-data", Label: "Incomplete data", Active: true}, nil
+			},
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) {
+				return upload, nil
+			},
+			UpdateUploadFunc: func(_ context.Context, u *models.Upload) error {
+				updated = u
+				return nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/reject", strings.NewReader(`{"reason_code":"incomplete-data"}`))
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.Reject(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if updated == nil || updated.RejectionReasonCode != "incomplete-data" || updated.Status != models.StatusRejected {
+			t.Fatalf("unexpected updated upload: %+v", updated)
+		}
+
+		var resp models.Upload
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.RejectionReasonCode != "incomplete-data" {
+			t.Fatalf("expected the response to echo the reason code, got %+v", resp)
+		}
+	})
+}
+
+func TestApproveEnforcesDatasetReviewers(t *testing.T) {
+	upload := &models.Upload{ID: "upload-1", Dataset: "gdp", Status: models.StatusPending}
+	store := &storemock.StoreMock{
+		GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		GetDatasetProfileFunc: func(context.Context, string) (*models.DatasetProfile, error) {
+			return &models.DatasetProfile{Dataset: "gdp", RequiredReviewers: []string{"designated@example.com"}}, nil
+		},
+	}
+	h := api.NewHandler(store, api.WithEnforceDatasetReviewers(true))
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/approve", strings.NewReader(`{"reviewed_by":"someone-else@example.com"}`))
+	req.SetPathValue("id", "upload-1")
+	rec := httptest.NewRecorder()
+
+	h.Approve(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestApprove_Idempotent(t *testing.T) {
+	t.Run("a repeat approval with the same reviewer and acknowledgements returns 200 without republishing", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:               "upload-1",
+			Status:           models.StatusApproved,
+			TargetKey:        "gdp/1.csv",
+			ReviewedBy:       "reviewer@example.com",
+			Acknowledgements: []string{"checked-source"},
+		}
+		var backedUp bool
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+		h.S3 = backupTrackingUploader{backedUp: &backedUp}
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/approve",
+			strings.NewReader(`{"reviewed_by":"reviewer@example.com","acknowledged":["checked-source"]}`))
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if backedUp {
+			t.Fatal("expected the publish workflow not to run again for a repeat approval")
+		}
+
+		var resp api.ApproveResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Upload.ID != "upload-1" {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("a second approval with different acknowledgements is rejected", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:               "upload-1",
+			Status:           models.StatusApproved,
+			ReviewedBy:       "reviewer@example.com",
+			Acknowledgements: []string{"checked-source"},
+		}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/approve",
+			strings.NewReader(`{"reviewed_by":"reviewer@example.com","acknowledged":["a-different-item"]}`))
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.Approve(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestExtendTTL(t *testing.T) {
+	t.Run("resets the temp storage TTL for a pending upload", func(t *testing.T) {
+		tempStorage := temp.NewInMemoryStorage(1<<20, 0)
+		if err := tempStorage.Store(context.Background(), "temp/upload-1", strings.NewReader("data"), 4, time.Millisecond); err != nil {
+			t.Fatalf("failed to stage temp file: %v", err)
+		}
+
+		upload := &models.Upload{ID: "upload-1", Status: models.StatusPending, TempKey: "temp/upload-1"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(tempStorage, time.Hour))
+
+		time.Sleep(5 * time.Millisecond)
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/extend-ttl", nil)
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.ExtendTTL(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var resp api.UploadStatus
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !resp.TempAvailable {
+			t.Fatal("expected the staged file to still be available after extending its TTL")
+		}
+		if resp.TempExpiresInSeconds == nil || *resp.TempExpiresInSeconds < 3500 {
+			t.Fatalf("expected the TTL to have been reset to close to an hour, got %+v", resp.TempExpiresInSeconds)
+		}
+	})
+
+	t.Run("rejects extending a non-pending upload", func(t *testing.T) {
+		upload := &models.Upload{ID: "upload-1", Status: models.StatusApproved}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/upload-1/extend-ttl", nil)
+		req.SetPathValue("id", "upload-1")
+		rec := httptest.NewRecorder()
+
+		h.ExtendTTL(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+// backupTrackingUploader is a minimal api.S3Client fake that only tracks
+// whether BackupFile was called, so idempotency tests can assert the
+// publish workflow didn't run again.
+type backupTrackingUploader struct {
+	backedUp *bool
+}
+
+func (u backupTrackingUploader) UploadFile(context.Context, string, io.Reader, string, string, string) error {
+	return nil
+}
+
+func (u backupTrackingUploader) BackupFile(context.Context, string) error {
+	*u.backedUp = true
+	return nil
+}
+
+func (u backupTrackingUploader) DownloadFile(context.Context, string) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
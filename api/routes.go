@@ -0,0 +1,88 @@
+package api
+
+import "net/http"
+
+// Routes registers every upload and review endpoint on mux. It is the
+// single composition root for this service's HTTP surface: there is no
+// second, legacy route table to keep in sync, so permission and middleware
+// wrapping (requirePermission, requireHumanReviewer, requireReady,
+// requireNotInMaintenance) only needs to be reasoned about here. Endpoints
+// with no wrapper are intentionally open to any caller that can reach the
+// service; if that changes, wrap them here rather than introducing an
+// alternate registration path.
+func (h *Handler) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /uploads", h.ListUploads)
+	mux.HandleFunc("GET /api/v1/uploads/mine", h.ListMyUploads)
+	mux.HandleFunc("GET /api/v1/uploads/counts", h.GetUploadCounts)
+	mux.HandleFunc("GET /api/v1/uploads/export", h.ExportUploads)
+	mux.HandleFunc("POST /uploads", h.requireReady(h.requireNotInMaintenance(h.CreateUpload)))
+	mux.HandleFunc("GET /uploads/{id}", h.GetUpload)
+	mux.HandleFunc("PATCH /api/v1/uploads/{id}", h.PatchUpload)
+	mux.HandleFunc("POST /uploads/{id}/approve", h.requireReady(h.requireHumanReviewer(h.requireNotInMaintenance(h.Approve))))
+	mux.HandleFunc("POST /uploads/{id}/reject", h.requireHumanReviewer(h.requireNotInMaintenance(h.Reject)))
+	mux.HandleFunc("POST /uploads/{id}/retry", h.requireReady(h.requireHumanReviewer(h.requireNotInMaintenance(h.Retry))))
+	mux.HandleFunc("POST /uploads/{id}/extend-ttl", h.requireHumanReviewer(h.requireNotInMaintenance(h.ExtendTTL)))
+	mux.HandleFunc("PATCH /uploads/{id}/tags", h.requireHumanReviewer(h.UpdateUploadTags))
+
+	mux.HandleFunc("POST /api/v1/uploads/validate", h.ValidateUpload)
+
+	mux.HandleFunc("GET /api/v1/uploads/in-progress/{token}/progress", h.GetUploadProgress)
+	mux.HandleFunc("GET /api/v1/uploads/{id}/diff", h.GetUploadDiff)
+	mux.HandleFunc("GET /api/v1/uploads/{id}/file", h.GetUploadFile)
+	mux.HandleFunc("GET /api/v1/uploads/{id}/notifications", h.GetUploadNotifications)
+	mux.HandleFunc("POST /api/v1/uploads/{id}/notifications/{notification_id}/resend", h.ResendNotification)
+
+	mux.HandleFunc("GET /api/v1/invalidations", h.requirePermission(permissionPurge, h.ListInvalidations))
+	mux.HandleFunc("POST /api/v1/invalidations", h.requirePermission(permissionPurge, h.requireNotInMaintenance(h.CreateInvalidation)))
+
+	mux.HandleFunc("GET /api/v1/stats", h.GetStats)
+	mux.HandleFunc("GET /api/v1/reports/sla", h.GetSLAReport)
+	mux.HandleFunc("GET /api/v1/manifest", h.GetManifest)
+	mux.HandleFunc("GET /api/v1/health", h.GetHealth)
+
+	mux.HandleFunc("GET /api/v1/orphans", h.requirePermission(permissionPurge, h.ListOrphans))
+	mux.HandleFunc("POST /api/v1/orphans/{key}/quarantine", h.requirePermission(permissionPurge, h.requireNotInMaintenance(h.QuarantineOrphan)))
+
+	mux.HandleFunc("GET /api/v1/releases", h.ListReleases)
+	mux.HandleFunc("POST /api/v1/releases", h.requireNotInMaintenance(h.CreateRelease))
+	mux.HandleFunc("GET /api/v1/releases/{id}", h.GetRelease)
+	mux.HandleFunc("POST /api/v1/releases/{id}/publish", h.requireNotInMaintenance(h.PublishRelease))
+
+	mux.HandleFunc("POST /api/v1/scheduled-publishes/run", h.requirePermission(permissionPurge, h.requireNotInMaintenance(h.PublishScheduled)))
+	mux.HandleFunc("POST /api/v1/digest/run", h.requirePermission(permissionDigest, h.RunDigest))
+
+	mux.HandleFunc("GET /api/v1/admin/temp-storage", h.ListTempStorage)
+	mux.HandleFunc("DELETE /api/v1/admin/temp-storage/{key}", h.DeleteTempStorageObject)
+
+	mux.HandleFunc("GET /api/v1/admin/maintenance", h.requirePermission(permissionMaintenance, h.GetMaintenanceMode))
+	mux.HandleFunc("PUT /api/v1/admin/maintenance", h.requirePermission(permissionMaintenance, h.SetMaintenanceMode))
+
+	mux.HandleFunc("GET /api/v1/admin/feature-flags", h.requirePermission(permissionFeatureFlags, h.ListFeatureFlags))
+	mux.HandleFunc("PUT /api/v1/admin/feature-flags/{name}", h.requirePermission(permissionFeatureFlags, h.SetFeatureFlag))
+
+	mux.HandleFunc("GET /api/v1/api-keys", h.requirePermission(permissionAPIKeys, h.ListAPIKeys))
+	mux.HandleFunc("POST /api/v1/api-keys", h.requirePermission(permissionAPIKeys, h.CreateAPIKey))
+	mux.HandleFunc("POST /api/v1/api-keys/{id}/rotate", h.requirePermission(permissionAPIKeys, h.RotateAPIKey))
+	mux.HandleFunc("POST /api/v1/api-keys/{id}/revoke", h.requirePermission(permissionAPIKeys, h.RevokeAPIKey))
+
+	mux.HandleFunc("GET /api/v1/admin/audit-log", h.requirePermission(permissionAuditLog, h.ListAuditLogEntries))
+
+	mux.HandleFunc("GET /api/v1/admin/rejection-reasons", h.requirePermission(permissionRejectionReasons, h.ListRejectionReasons))
+	mux.HandleFunc("PUT /api/v1/admin/rejection-reasons/{code}", h.requirePermission(permissionRejectionReasons, h.SetRejectionReason))
+	mux.HandleFunc("DELETE /api/v1/admin/rejection-reasons/{code}", h.requirePermission(permissionRejectionReasons, h.DeleteRejectionReason))
+
+	mux.HandleFunc("GET /api/v1/admin/dataset-profiles", h.requirePermission(permissionDatasetProfiles, h.ListDatasetProfiles))
+	mux.HandleFunc("PUT /api/v1/admin/dataset-profiles/{dataset}", h.requirePermission(permissionDatasetProfiles, h.SetDatasetProfile))
+	mux.HandleFunc("DELETE /api/v1/admin/dataset-profiles/{dataset}", h.requirePermission(permissionDatasetProfiles, h.DeleteDatasetProfile))
+
+	mux.HandleFunc("GET /api/v1/admin/scheduler/jobs", h.requirePermission(permissionScheduler, h.GetSchedulerJobs))
+	mux.HandleFunc("GET /api/v1/admin/preflight", h.requirePermission(permissionPreflight, h.GetPreflight))
+	mux.HandleFunc("GET /api/v1/admin/cdn/status", h.requirePermission(permissionCDNStatus, h.GetCDNStatus))
+	mux.HandleFunc("GET /api/v1/admin/s3/diagnostics", h.requirePermission(permissionS3Diagnostics, h.GetS3Diagnostics))
+	mux.HandleFunc("GET /api/v1/admin/purge-retries", h.requirePermission(permissionPurge, h.ListPendingPurgeRetries))
+
+	mux.HandleFunc("DELETE /api/v1/admin/greylist/{identity}", h.requirePermission(permissionGreylist, h.ClearGreylist))
+
+	mux.HandleFunc("POST /api/v1/slack/interactions", h.HandleSlackInteraction)
+	mux.HandleFunc("POST /api/v1/slack/commands", h.HandleSlackCommand)
+}
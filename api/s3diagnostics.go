@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// permissionS3Diagnostics gates the S3 diagnostics endpoint, an
+// operational action distinct from the review permissions implied by
+// approving or rejecting an upload.
+const permissionS3Diagnostics = "imf:s3-diagnostics"
+
+// GetS3Diagnostics handles GET /api/v1/admin/s3/diagnostics, gated on
+// permissionS3Diagnostics, self-checking the configured S3 backend's
+// effective permissions, encryption settings and backup lifecycle rules,
+// so an operator can catch a misconfigured bucket before it causes a
+// publish or backup failure. It queries live rather than serving a
+// cached result, consistent with GetPreflight.
+func (h *Handler) GetS3Diagnostics(w http.ResponseWriter, r *http.Request) {
+	diagnoser, ok := h.S3.(S3Diagnoser)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "configured S3 backend does not support diagnostics")
+		return
+	}
+
+	summary, err := diagnoser.DiagnoseSelf(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "failed to run S3 diagnostics")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetS3Diagnostics(t *testing.T) {
+	t.Run("reports the self-check summary", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		s3 := newFakeS3()
+		s3.DiagnoseSelfFunc = func(context.Context) (api.S3DiagnosticsSummary, error) {
+			return api.S3DiagnosticsSummary{
+				Permissions:          api.S3PermissionsSummary{Put: true, Get: true, Copy: true, Delete: false},
+				Encryption:           "aws:kms",
+				BackupLifecycleRules: []string{"expire-backups-after-90-days"},
+			}, nil
+		}
+		h := api.NewHandler(store, api.WithS3(s3))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/s3/diagnostics", nil)
+		rec := httptest.NewRecorder()
+		h.GetS3Diagnostics(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var summary api.S3DiagnosticsSummary
+		if err := json.NewDecoder(rec.Body).Decode(&summary); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !summary.Permissions.Put || !summary.Permissions.Get || !summary.Permissions.Copy || summary.Permissions.Delete {
+			t.Fatalf("unexpected permissions summary: %+v", summary.Permissions)
+		}
+		if summary.Encryption != "aws:kms" || len(summary.BackupLifecycleRules) != 1 {
+			t.Fatalf("unexpected diagnostics summary: %+v", summary)
+		}
+	})
+
+	t.Run("returns 501 when the configured S3 backend does not support diagnostics", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/s3/diagnostics", nil)
+		rec := httptest.NewRecorder()
+		h.GetS3Diagnostics(rec, req)
+
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("expected status 501, got %d", rec.Code)
+		}
+	})
+
+	t.Run("returns 502 when the self-check fails", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		s3 := newFakeS3()
+		s3.DiagnoseSelfFunc = func(context.Context) (api.S3DiagnosticsSummary, error) {
+			return api.S3DiagnosticsSummary{}, errors.New("access denied")
+		}
+		h := api.NewHandler(store, api.WithS3(s3))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/s3/diagnostics", nil)
+		rec := httptest.NewRecorder()
+		h.GetS3Diagnostics(rec, req)
+
+		if rec.Code != http.StatusBadGateway {
+			t.Fatalf("expected status 502, got %d", rec.Code)
+		}
+	})
+}
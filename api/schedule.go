@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// PublishWindow defines a daily window, in a fixed location, during which
+// Approve is allowed to publish immediately. Start and End are "HH:MM" in
+// 24-hour time; a window where End is earlier than Start wraps past
+// midnight, e.g. Start "07:00", End "09:30" for a morning-only window, or
+// Start "22:00", End "06:00" for an overnight one.
+type PublishWindow struct {
+	Start    string
+	End      string
+	Location *time.Location
+}
+
+// Open reports whether t falls within the publish window.
+func (p PublishWindow) Open(t time.Time) bool {
+	start, err := parseClock(p.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(p.End)
+	if err != nil {
+		return false
+	}
+	if start == end {
+		return false
+	}
+
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end // window wraps midnight
+}
+
+// parseClock parses "HH:MM" into minutes since midnight. Duplicated from
+// slack.parseClock, which is unexported, to avoid coupling the two
+// packages' otherwise-unrelated config shapes together.
+func parseClock(hhmm string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(hhmm, "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid clock value %q: %w", hhmm, err)
+	}
+	return h*60 + m, nil
+}
+
+// publishWindowOpen reports whether now falls within h.PublishWindow. A nil
+// PublishWindow means no window is configured, so publishing is always
+// allowed.
+func (h *Handler) publishWindowOpen(now time.Time) bool {
+	if h.PublishWindow == nil {
+		return true
+	}
+	return h.PublishWindow.Open(now)
+}
+
+// hasPublishOverride reports whether r's caller holds
+// permissionPublishOverride, letting them publish even when the configured
+// publish window is closed. A nil h.Permissions grants the override,
+// consistent with requirePermission treating an unconfigured checker as
+// unrestricted.
+func (h *Handler) hasPublishOverride(r *http.Request) bool {
+	if h.Permissions == nil {
+		return true
+	}
+
+	granted, err := h.Permissions.HasPermission(r.Context(), r, permissionPublishOverride)
+	return err == nil && granted
+}
+
+// PublishScheduled handles POST /api/v1/scheduled-publishes/run, publishing
+// every upload queued as models.StatusScheduled while the publish window
+// was closed. It is intended to be triggered periodically once the window
+// opens, e.g. by a scheduled job, since this repo has no long-running
+// process wiring of its own yet.
+func (h *Handler) PublishScheduled(w http.ResponseWriter, r *http.Request) {
+	if !h.publishWindowOpen(time.Now()) {
+		writeError(w, http.StatusServiceUnavailable, "publish window is not currently open")
+		return
+	}
+
+	uploads, err := h.Store.ListUploads(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	var published, failed []string
+	for _, upload := range uploads {
+		if upload.Status != models.StatusScheduled {
+			continue
+		}
+
+		if err := h.publishUpload(r.Context(), upload, upload.Acknowledgements, upload.ReviewedBy); err != nil {
+			failed = append(failed, upload.ID)
+			continue
+		}
+		published = append(published, upload.ID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Published []string `json:"published"`
+		Failed    []string `json:"failed"`
+	}{Published: published, Failed: failed})
+}
@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestPublishScheduled(t *testing.T) {
+	t.Run("publishes every scheduled upload once the window is open", func(t *testing.T) {
+		scheduled := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusScheduled}
+		notScheduled := &models.Upload{ID: "2", TargetKey: "cpi/other.csv", Status: models.StatusApproved}
+		var updated *models.Upload
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{scheduled, notScheduled}, nil
+			},
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { updated = u; return nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduled-publishes/run", nil)
+		rec := httptest.NewRecorder()
+
+		h.PublishScheduled(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if updated == nil || updated.ID != "1" || updated.Status != models.StatusApproved {
+			t.Fatalf("expected only the scheduled upload to be published, got %+v", updated)
+		}
+
+		var resp struct {
+			Published []string `json:"published"`
+			Failed    []string `json:"failed"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Published) != 1 || resp.Published[0] != "1" {
+			t.Fatalf("expected upload 1 to be reported published, got %v", resp.Published)
+		}
+	})
+
+	t.Run("closed window returns 503 without publishing", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithPublishWindow(&api.PublishWindow{
+			Start: closedWindowStart, End: closedWindowEnd, Location: time.UTC,
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduled-publishes/run", nil)
+		rec := httptest.NewRecorder()
+
+		h.PublishScheduled(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d", rec.Code)
+		}
+	})
+}
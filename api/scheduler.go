@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/scheduler"
+)
+
+// permissionScheduler gates reading scheduled job run history, since it can
+// reveal operational details (job names, error messages) about the
+// service's background jobs.
+const permissionScheduler = "imf:scheduler"
+
+// SchedulerJobsResponse reports every recorded job run on h.Scheduler and,
+// when leader election is configured, which replica currently holds
+// leadership.
+type SchedulerJobsResponse struct {
+	Jobs []scheduler.Run `json:"jobs"`
+	// Leader is nil when no Scheduler is configured.
+	Leader *scheduler.LeadershipStatus `json:"leader,omitempty"`
+}
+
+// GetSchedulerJobs handles GET /api/v1/admin/scheduler/jobs, gated on
+// permissionScheduler. An unconfigured h.Scheduler reports an empty
+// response rather than an error, since a service with no scheduled jobs is
+// a valid configuration.
+func (h *Handler) GetSchedulerJobs(w http.ResponseWriter, r *http.Request) {
+	resp := SchedulerJobsResponse{Jobs: []scheduler.Run{}}
+	if h.Scheduler != nil {
+		resp.Jobs = h.Scheduler.History()
+		leadership := h.Scheduler.Leadership()
+		resp.Leader = &leadership
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
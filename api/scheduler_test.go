@@ -0,0 +1,63 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/scheduler"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetSchedulerJobs(t *testing.T) {
+	t.Run("reports recorded runs and leadership from the configured Scheduler", func(t *testing.T) {
+		sched := scheduler.NewScheduler([]scheduler.Job{{Name: "digest", Cron: "0 8 * * *", Run: func(context.Context) error { return nil }}}, nil)
+
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithScheduler(sched))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/scheduler/jobs", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetSchedulerJobs(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var resp api.SchedulerJobsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Jobs) != 0 {
+			t.Fatalf("expected no runs before the job has fired, got %v", resp.Jobs)
+		}
+		if resp.Leader == nil || !resp.Leader.IsLeader {
+			t.Fatalf("expected a leader status reporting leadership with no Elector configured, got %+v", resp.Leader)
+		}
+	})
+
+	t.Run("returns an empty response when no Scheduler is configured", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/scheduler/jobs", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetSchedulerJobs(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var resp api.SchedulerJobsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Jobs) != 0 {
+			t.Fatalf("expected no runs, got %v", resp.Jobs)
+		}
+		if resp.Leader != nil {
+			t.Fatalf("expected no leader status without a Scheduler, got %+v", resp.Leader)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("sets standard headers and passes through an allowed request", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithSecurityHeaders(
+			[]string{"GET", "POST"}, 1024, time.Hour,
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if !called {
+			t.Fatalf("expected the wrapped handler to run")
+		}
+		if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+			t.Fatalf("expected X-Content-Type-Options: nosniff, got %q", got)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+			t.Fatalf("expected Cache-Control: no-store, got %q", got)
+		}
+		if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+			t.Fatalf("expected no HSTS header over plain HTTP, got %q", got)
+		}
+	})
+
+	t.Run("sets HSTS only over TLS", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithSecurityHeaders(
+			[]string{"GET"}, 1024, time.Hour,
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		req.TLS = &tls.ConnectionState{}
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Strict-Transport-Security"); got != "max-age=3600" {
+			t.Fatalf("expected max-age=3600, got %q", got)
+		}
+	})
+
+	t.Run("rejects a disallowed method", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithSecurityHeaders(
+			[]string{"GET"}, 0, 0,
+		))
+
+		req := httptest.NewRequest(http.MethodTrace, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if called {
+			t.Fatalf("expected the wrapped handler not to run")
+		}
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405, got %d", rec.Code)
+		}
+	})
+
+	t.Run("sets X-Environment when configured", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithEnvironment("staging"))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("X-Environment"); got != "staging" {
+			t.Fatalf("expected X-Environment: staging, got %q", got)
+		}
+	})
+
+	t.Run("omits X-Environment when unconfigured", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("X-Environment"); got != "" {
+			t.Fatalf("expected no X-Environment header, got %q", got)
+		}
+	})
+
+	t.Run("rejects overlong headers", func(t *testing.T) {
+		called = false
+		h := api.NewHandler(&storemock.StoreMock{}, api.WithSecurityHeaders(
+			nil, 16, 0,
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		req.Header.Set("X-Custom", strings.Repeat("a", 64))
+		rec := httptest.NewRecorder()
+
+		h.SecurityHeaders(next).ServeHTTP(rec, req)
+
+		if called {
+			t.Fatalf("expected the wrapped handler not to run")
+		}
+		if rec.Code != http.StatusRequestHeaderFieldsTooLarge {
+			t.Fatalf("expected status 431, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// serviceAuthTokenHeader carries the shared secret an automated pipeline
+// presents to authenticate as h.ServiceAuthPrincipal instead of a human JWT.
+const serviceAuthTokenHeader = "Service-Auth-Token"
+
+// isServiceRequest reports whether r carries the configured service auth
+// token. A configured h.ServiceAuthToken is required; an empty
+// serviceAuthTokenHeader never matches even if h.ServiceAuthToken is also
+// empty, so service auth stays off until explicitly configured.
+func (h *Handler) isServiceRequest(r *http.Request) bool {
+	if h.ServiceAuthToken == "" {
+		return false
+	}
+	token := r.Header.Get(serviceAuthTokenHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.ServiceAuthToken)) == 1
+}
+
+// requireHumanReviewer wraps next so it rejects requests authenticated as
+// the service principal, since approve/reject decisions must be made by a
+// person, not an automated pipeline that only has upload access.
+func (h *Handler) requireHumanReviewer(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.isServiceRequest(r) {
+			writeError(w, http.StatusForbidden, "service principals cannot approve or reject uploads")
+			return
+		}
+		next(w, r)
+	}
+}
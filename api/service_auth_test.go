@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestCreateUpload_ServiceAuth(t *testing.T) {
+	t.Run("attributes the upload to the configured service principal", func(t *testing.T) {
+		var created models.Upload
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+				created = *upload
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithServiceAuth("s3cret", "pipeline:weo-loader"))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+		req.Header.Set("Service-Auth-Token", "s3cret")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if created.UploadedBy != "pipeline:weo-loader" {
+			t.Fatalf("expected upload to be attributed to the service principal, got %q", created.UploadedBy)
+		}
+	})
+}
+
+func TestRequireHumanReviewer(t *testing.T) {
+	t.Run("blocks a service-authenticated approval", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) {
+				t.Fatalf("expected the request to be rejected before loading the upload")
+				return nil, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithServiceAuth("s3cret", "pipeline:weo-loader"))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		req.Header.Set("Service-Auth-Token", "s3cret")
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("allows a human-authenticated approval", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc:    func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error { return nil },
+			ListUploadsFunc:  func(ctx context.Context, dataset string) ([]*models.Upload, error) { return nil, nil },
+			GetMaintenanceModeFunc: func(ctx context.Context) (*models.MaintenanceMode, error) {
+				return &models.MaintenanceMode{}, nil
+			},
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV123", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithServiceAuth("s3cret", "pipeline:weo-loader"))
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/1/approve", nil)
+		rec := httptest.NewRecorder()
+
+		mux := http.NewServeMux()
+		h.Routes(mux)
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+}
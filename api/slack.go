@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	slackpkg "github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+// HandleSlackInteraction handles POST /api/v1/slack/interactions, Slack's
+// callback for a Block Kit button click. It validates the request's Slack
+// signature, maps the clicking Slack user to a service user, and drives the
+// same Approve/Reject workflow as the HTTP API.
+func (h *Handler) HandleSlackInteraction(w http.ResponseWriter, r *http.Request) {
+	values, ok := h.readVerifiedSlackForm(w, r)
+	if !ok {
+		return
+	}
+
+	payload, err := slackpkg.ParseInteractionPayload(values.Get("payload"))
+	if err != nil || len(payload.Actions) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid slack interaction payload")
+		return
+	}
+
+	action := payload.Actions[0]
+
+	var value slackpkg.ActionValue
+	if err := json.Unmarshal([]byte(action.Value), &value); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid slack action value")
+		return
+	}
+
+	reviewer, ok := h.resolveSlackUser(w, r, payload.User.ID)
+	if !ok {
+		return
+	}
+
+	switch action.ActionID {
+	case "imf_approve":
+		h.approveUpload(w, r, value.UploadID, value.Acknowledged, reviewer, "")
+	case "imf_reject":
+		h.rejectUpload(w, r, value.UploadID, "", "", reviewer)
+	default:
+		writeError(w, http.StatusBadRequest, "unknown slack action")
+	}
+}
+
+// HandleSlackCommand handles POST /api/v1/slack/commands, the callback for
+// the /imf slash command. It currently supports "/imf list [dataset]" to
+// list uploads pending review.
+func (h *Handler) HandleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	values, ok := h.readVerifiedSlackForm(w, r)
+	if !ok {
+		return
+	}
+
+	fields := strings.Fields(values.Get("text"))
+	if len(fields) == 0 || fields[0] != "list" {
+		respondSlackText(w, "usage: /imf list [dataset]")
+		return
+	}
+
+	var dataset string
+	if len(fields) > 1 {
+		dataset = fields[1]
+	}
+
+	uploads, err := h.Store.ListUploads(r.Context(), dataset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	respondSlackText(w, formatPendingUploads(uploads))
+}
+
+// readVerifiedSlackForm reads and signature-verifies the raw request body,
+// returning it parsed as form values. It writes an error response and
+// returns ok=false if the request is unconfigured, malformed or unsigned.
+func (h *Handler) readVerifiedSlackForm(w http.ResponseWriter, r *http.Request) (url.Values, bool) {
+	if h.SlackSigningSecret == "" {
+		writeError(w, http.StatusServiceUnavailable, "slack integration is not configured")
+		return nil, false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body")
+		return nil, false
+	}
+
+	timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+	signature := r.Header.Get("X-Slack-Signature")
+	if err := slackpkg.VerifySignature(h.SlackSigningSecret, timestamp, string(body), signature); err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid slack signature")
+		return nil, false
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid slack payload")
+		return nil, false
+	}
+
+	return values, true
+}
+
+// resolveSlackUser maps slackUserID to a service username via h.UserMapper,
+// falling back to the raw Slack user ID when no mapper is configured.
+func (h *Handler) resolveSlackUser(w http.ResponseWriter, r *http.Request, slackUserID string) (string, bool) {
+	if h.UserMapper == nil {
+		return slackUserID, true
+	}
+
+	user, err := h.UserMapper.MapUser(r.Context(), slackUserID)
+	if err != nil {
+		writeError(w, http.StatusForbidden, "unrecognised slack user")
+		return "", false
+	}
+
+	return user, true
+}
+
+func respondSlackText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		ResponseType string `json:"response_type"`
+		Text         string `json:"text"`
+	}{ResponseType: "ephemeral", Text: text})
+}
+
+func formatPendingUploads(uploads []*models.Upload) string {
+	var pending []string
+	for _, u := range uploads {
+		if u.Status == models.StatusPending {
+			pending = append(pending, fmt.Sprintf("%s: %s", u.ID, u.Filename))
+		}
+	}
+	if len(pending) == 0 {
+		return "no uploads pending review"
+	}
+	return strings.Join(pending, "\n")
+}
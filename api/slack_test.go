@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	apimock "github.com/ONSdigital/dis-imf-uploader/api/mock"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+const slackSigningSecret = "test-secret"
+
+func signSlackRequest(t *testing.T, body string) (string, string) {
+	t.Helper()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(slackSigningSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	return timestamp, "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSlackRequest(t *testing.T, path, body string) *http.Request {
+	t.Helper()
+	timestamp, signature := signSlackRequest(t, body)
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", signature)
+	return req
+}
+
+func TestHandleSlackInteraction(t *testing.T) {
+	t.Run("approve button publishes and records the slack user as reviewer", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPending}
+		var reviewedBy string
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+			UpdateUploadFunc: func(ctx context.Context, u *models.Upload) error {
+				reviewedBy = u.ReviewedBy
+				return nil
+			},
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) { return nil, nil },
+		}
+		s3 := &apimock.S3ClientMock{
+			BackupFileFunc: func(ctx context.Context, key string) error { return nil },
+			UploadFileFunc: func(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error { return nil },
+		}
+		cf := &apimock.CloudFrontClientMock{
+			InvalidatePathsFunc: func(ctx context.Context, paths []string) (string, error) { return "INV1", nil },
+		}
+		h := api.NewHandler(store, api.WithS3(s3), api.WithCloudFront(cf), api.WithSlackSigningSecret(slackSigningSecret))
+
+		payload := `{"type":"block_actions","user":{"id":"U123"},"actions":[{"action_id":"imf_approve","value":"{\"upload_id\":\"1\"}"}]}`
+		body := url.Values{"payload": {payload}}.Encode()
+
+		req := newSlackRequest(t, "/api/v1/slack/interactions", body)
+		rec := httptest.NewRecorder()
+
+		h.HandleSlackInteraction(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if reviewedBy != "U123" {
+			t.Fatalf("expected reviewer U123, got %q", reviewedBy)
+		}
+	})
+
+	t.Run("invalid signature is rejected", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		h := api.NewHandler(store, api.WithSlackSigningSecret(slackSigningSecret))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/slack/interactions", strings.NewReader("payload=bad"))
+		req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+		req.Header.Set("X-Slack-Signature", "v0=deadbeef")
+		rec := httptest.NewRecorder()
+
+		h.HandleSlackInteraction(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unmapped slack user is rejected", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv"}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		mapper := &apimock.UserMapperMock{
+			MapUserFunc: func(ctx context.Context, externalID string) (string, error) {
+				return "", errors.New("no mapping for slack user")
+			},
+		}
+		h := api.NewHandler(store, api.WithUserMapper(mapper), api.WithSlackSigningSecret(slackSigningSecret))
+
+		payload := `{"type":"block_actions","user":{"id":"U999"},"actions":[{"action_id":"imf_approve","value":"{\"upload_id\":\"1\"}"}]}`
+		body := url.Values{"payload": {payload}}.Encode()
+
+		req := newSlackRequest(t, "/api/v1/slack/interactions", body)
+		rec := httptest.NewRecorder()
+
+		h.HandleSlackInteraction(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandleSlackCommand(t *testing.T) {
+	t.Run("list returns pending uploads", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{
+					{ID: "1", Filename: "data.csv", Status: models.StatusPending},
+					{ID: "2", Filename: "old.csv", Status: models.StatusApproved},
+				}, nil
+			},
+		}
+		h := api.NewHandler(store, api.WithSlackSigningSecret(slackSigningSecret))
+
+		body := url.Values{"text": {"list"}}.Encode()
+		req := newSlackRequest(t, "/api/v1/slack/commands", body)
+		rec := httptest.NewRecorder()
+
+		h.HandleSlackCommand(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "data.csv") || strings.Contains(rec.Body.String(), "old.csv") {
+			t.Fatalf("expected response to list only the pending upload, got %s", rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/reconcile"
+)
+
+// StatsResponse summarises the upload backlog and, when a Reconciler is
+// configured, the outcome of the most recent background drift check.
+type StatsResponse struct {
+	TotalUploads int `json:"total_uploads"`
+	Pending      int `json:"pending"`
+	Approved     int `json:"approved"`
+	Rejected     int `json:"rejected"`
+	Published    int `json:"published"`
+	Missing      int `json:"missing"`
+	Mismatched   int `json:"mismatched"`
+	CheckFailed  int `json:"check_failed"`
+	// RejectionsByReason counts rejected uploads by RejectionReasonCode, so
+	// rejection reporting doesn't depend on parsing free-text notes. An
+	// upload rejected without a code (e.g. via the Slack callback) is
+	// counted under the empty string key.
+	RejectionsByReason map[string]int `json:"rejections_by_reason,omitempty"`
+	// Reconciliation is nil when no Reconciler is configured, rather than
+	// reporting a stale or misleadingly zeroed snapshot.
+	Reconciliation *reconcile.Stats `json:"reconciliation,omitempty"`
+	// Quota reports the calling identity's upload quota usage for the
+	// current UTC day. Nil when the caller identity is unknown (no
+	// callerIdentityHeader), rather than reporting a meaningless snapshot
+	// for "no one".
+	Quota *QuotaStatus `json:"quota,omitempty"`
+}
+
+// GetStats handles GET /api/v1/stats, summarising the upload backlog and any
+// drift the background Reconciler has found.
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	uploads, err := h.Store.ListUploads(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	stats := StatsResponse{TotalUploads: len(uploads)}
+	for _, upload := range uploads {
+		switch upload.Status {
+		case models.StatusPending:
+			stats.Pending++
+		case models.StatusApproved:
+			stats.Approved++
+		case models.StatusRejected:
+			stats.Rejected++
+			if stats.RejectionsByReason == nil {
+				stats.RejectionsByReason = map[string]int{}
+			}
+			stats.RejectionsByReason[upload.RejectionReasonCode]++
+		case models.StatusPublished:
+			stats.Published++
+		}
+
+		switch upload.Drift {
+		case models.DriftMissing:
+			stats.Missing++
+		case models.DriftChecksumMismatch:
+			stats.Mismatched++
+		case models.DriftCheckFailed:
+			stats.CheckFailed++
+		}
+	}
+
+	if h.Reconciler != nil {
+		snapshot := h.Reconciler.Stats()
+		stats.Reconciliation = &snapshot
+	}
+
+	if identity := callerIdentity(r); identity != "" {
+		if _, usage, err := h.quotaExceeded(r.Context(), identity); err == nil {
+			stats.Quota = &usage
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
@@ -0,0 +1,50 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+)
+
+func TestGetStats(t *testing.T) {
+	t.Run("summarises upload backlog and drift", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				return []*models.Upload{
+					{ID: "1", Status: models.StatusPending},
+					{ID: "2", Status: models.StatusApproved},
+					{ID: "3", Status: models.StatusPublished, Drift: models.DriftMissing},
+					{ID: "4", Status: models.StatusPublished, Drift: models.DriftChecksumMismatch},
+					{ID: "5", Status: models.StatusRejected},
+				}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetStats(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var resp api.StatsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.TotalUploads != 5 || resp.Pending != 1 || resp.Approved != 1 || resp.Published != 2 || resp.Rejected != 1 {
+			t.Fatalf("unexpected status breakdown: %+v", resp)
+		}
+		if resp.Missing != 1 || resp.Mismatched != 1 {
+			t.Fatalf("unexpected drift breakdown: %+v", resp)
+		}
+	})
+}
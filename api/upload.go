@@ -0,0 +1,477 @@
+package api
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// contentSHA256Header lets a caller of CreateUpload assert the sha256
+// (hex-encoded) checksum of the file it's sending, computed client-side
+// before the upload started. When present, CreateUpload verifies it against
+// what was actually staged and rejects the upload on a mismatch, catching
+// truncation or corruption in transit that a bare multipart POST wouldn't
+// otherwise surface until a reviewer opened the file.
+const contentSHA256Header = "X-Content-SHA256"
+
+// contentMD5Header is the standard RFC 1864 Content-MD5 header
+// (base64-encoded, not hex), offered as an alternative to contentSHA256Header
+// for callers whose HTTP client already computes it for them. Verified the
+// same way: a mismatch rejects the upload.
+const contentMD5Header = "Content-MD5"
+
+// maxWait caps the ?wait= long-poll duration GetUpload will honor, so a
+// misconfigured or malicious caller can't hold a handler goroutine open
+// indefinitely.
+const maxWait = 60 * time.Second
+
+// GetUpload handles GET /uploads/{id}. A ?wait=<duration> query parameter
+// (e.g. "?wait=30s") makes it long-poll: instead of returning immediately,
+// it holds the request open until h.Events publishes a change for id or
+// wait elapses, whichever comes first, then returns the upload's current
+// state. This lets a CI pipeline block for the next status change instead
+// of hammering the endpoint in a tight polling loop.
+func (h *Handler) GetUpload(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	if wait, ok := parseWait(r); ok {
+		upload = h.waitForStatusChange(r.Context(), id, upload, wait)
+	}
+
+	if err := writeJSONWithETag(w, r, h.decorateTempAvailability(r.Context(), upload)); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+	}
+}
+
+// parseWait parses the wait query parameter off r as a Go duration string,
+// capped at maxWait. ok is false when wait wasn't given or didn't parse to
+// a positive duration, in which case GetUpload should skip long-polling.
+func parseWait(r *http.Request) (wait time.Duration, ok bool) {
+	raw := r.URL.Query().Get("wait")
+	if raw == "" {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	if d > maxWait {
+		d = maxWait
+	}
+	return d, true
+}
+
+// waitForStatusChange blocks until h.Events publishes a change for id,
+// wait elapses, or ctx is done, then re-fetches and returns the upload's
+// current state. It always returns a non-nil upload: if the re-fetch after
+// waking fails, current is returned rather than losing the response.
+func (h *Handler) waitForStatusChange(ctx context.Context, id string, current *models.Upload, wait time.Duration) *models.Upload {
+	changed, cancel := h.Events.Subscribe(id)
+	defer cancel()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-changed:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	if updated, err := h.Store.GetUpload(ctx, id); err == nil {
+		return updated
+	}
+	return current
+}
+
+// UploadStatus decorates an upload with fields derived from temp storage at
+// response time, rather than persisted on the record itself, so a reviewer
+// can tell whether a pending upload's bytes are still there without a
+// separate call to /api/v1/admin/temp-storage.
+type UploadStatus struct {
+	*models.Upload
+	// TempAvailable is true when TempKey still exists in temp storage.
+	// Always false once no Temp backend is configured or TempKey is empty.
+	TempAvailable bool `json:"temp_available"`
+	// TempExpiresInSeconds counts down to when the staged file expires, so
+	// soon-to-expire pending uploads can be prioritized. Omitted when
+	// TempAvailable is false or the configured Temp backend/TTL can't
+	// support the estimate.
+	TempExpiresInSeconds *int64 `json:"temp_expires_in_seconds,omitempty"`
+}
+
+// decorateTempAvailability wraps upload with its current temp-storage
+// availability, checked via temp.Stater so the object's content doesn't
+// have to be fetched just to answer "does it still exist".
+func (h *Handler) decorateTempAvailability(ctx context.Context, upload *models.Upload) UploadStatus {
+	status := UploadStatus{Upload: upload}
+
+	if h.Temp == nil || upload.TempKey == "" {
+		return status
+	}
+
+	stater, ok := h.Temp.(temp.Stater)
+	if !ok {
+		return status
+	}
+
+	info, err := stater.Stat(ctx, upload.TempKey)
+	if err != nil {
+		return status
+	}
+
+	status.TempAvailable = true
+	// ExpiresAt reflects the backend's actual TTL, which ExtendTTL may have
+	// reset independently of h.TempTTL, so it takes precedence when the
+	// backend reports it. Falling back to h.TempTTL - info.Age assumes the
+	// object still carries the TTL it was originally stored with.
+	switch {
+	case !info.ExpiresAt.IsZero():
+		remaining := time.Until(info.ExpiresAt)
+		if remaining < 0 {
+			remaining = 0
+		}
+		seconds := int64(remaining.Seconds())
+		status.TempExpiresInSeconds = &seconds
+	case h.TempTTL > 0:
+		remaining := h.TempTTL - info.Age
+		if remaining < 0 {
+			remaining = 0
+		}
+		seconds := int64(remaining.Seconds())
+		status.TempExpiresInSeconds = &seconds
+	}
+	return status
+}
+
+// decorateTempAvailabilityAll runs decorateTempAvailability over uploads, so
+// ListUploads can surface temp availability across a whole page of results
+// in one server-side pass rather than a client having to check each upload
+// individually.
+func (h *Handler) decorateTempAvailabilityAll(ctx context.Context, uploads []*models.Upload) []UploadStatus {
+	statuses := make([]UploadStatus, len(uploads))
+	for i, upload := range uploads {
+		statuses[i] = h.decorateTempAvailability(ctx, upload)
+	}
+	return statuses
+}
+
+// allowedUploadSortFields lists the sortBy values ListUploads accepts,
+// matching the compound indexes ensureUploadIndexes creates so every
+// accepted sort stays index-backed as the collection grows.
+var allowedUploadSortFields = []string{"created_at", "status", "uploaded_by"}
+
+func isAllowedUploadSortField(field string) bool {
+	for _, f := range allowedUploadSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ListUploads handles GET /uploads. The status query parameter accepts a
+// comma-separated list (e.g. "pending,rejected") and prefix filters by
+// TargetKey prefix, so a reviewer dashboard can build a "needs attention"
+// view spanning several statuses in a single call. sortBy orders the
+// results and must be one of allowedUploadSortFields (advertised on every
+// response via the Upload-Sortable-Fields header); sortOrder is "asc"
+// (default) or "desc".
+func (h *Handler) ListUploads(w http.ResponseWriter, r *http.Request) {
+	dataset := r.URL.Query().Get("dataset")
+	statusParam := r.URL.Query().Get("status")
+	prefix := r.URL.Query().Get("prefix")
+	sortBy := r.URL.Query().Get("sortBy")
+	tagsParam := r.URL.Query().Get("tags")
+
+	w.Header().Set("Upload-Sortable-Fields", strings.Join(allowedUploadSortFields, ", "))
+
+	if sortBy != "" && !isAllowedUploadSortField(sortBy) {
+		writeValidationErrors(w, models.ValidationError{
+			Rule:    "invalid_sort_field",
+			Message: fmt.Sprintf("sortBy must be one of: %s", strings.Join(allowedUploadSortFields, ", ")),
+			Value:   sortBy,
+		})
+		return
+	}
+
+	if statusParam == "" && prefix == "" && sortBy == "" && tagsParam == "" {
+		uploads, err := h.Store.ListUploads(r.Context(), dataset)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to list uploads")
+			return
+		}
+		if err := writeJSONWithETag(w, r, h.decorateTempAvailabilityAll(r.Context(), uploads)); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to encode response")
+		}
+		return
+	}
+
+	filter := models.UploadFilter{
+		Dataset:         dataset,
+		TargetKeyPrefix: prefix,
+		SortBy:          sortBy,
+		SortDescending:  strings.EqualFold(r.URL.Query().Get("sortOrder"), "desc"),
+	}
+	for _, s := range strings.Split(statusParam, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			filter.Statuses = append(filter.Statuses, models.Status(s))
+		}
+	}
+	filter.Tags = parseTags(tagsParam)
+
+	uploads, err := h.Store.ListUploadsFiltered(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list uploads")
+		return
+	}
+
+	if err := writeJSONWithETag(w, r, h.decorateTempAvailabilityAll(r.Context(), uploads)); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+	}
+}
+
+// GetUploadCounts handles GET /api/v1/uploads/counts, returning the number
+// of uploads in each status via a single aggregation so dashboard nav
+// badges don't need one paginated ListUploads call per status.
+func (h *Handler) GetUploadCounts(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.Store.CountUploadsByStatus(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to count uploads")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(counts)
+}
+
+// CreateUpload handles POST /uploads. When a Temp storage backend is
+// configured and the request carries a multipart "file" part, the file is
+// written to temp storage with the handler's configured TTL; otherwise the
+// request body is treated as the upload metadata only (used by tests and
+// callers that stage the file separately).
+//
+// Before accepting the file, CreateUpload checks the caller's upload quota
+// (h.MaxUploadsPerDay/h.MaxBytesPerDay) and rejects with 429 if it's already
+// exceeded, unless the caller holds permissionQuotaOverride, so a runaway
+// pipeline can't flood the review queue. It also rejects with 429 if the
+// caller has been greylisted after h.GreylistThreshold validation failures
+// within h.GreylistWindow (see recordUploadFailure), until an admin clears
+// them via ClearGreylist.
+func (h *Handler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	identity := callerIdentity(r)
+	if h.isServiceRequest(r) {
+		identity = h.ServiceAuthPrincipal
+	}
+
+	exceeded, _, err := h.quotaExceeded(r.Context(), identity)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to check upload quota")
+		return
+	}
+	if exceeded && !h.hasQuotaOverride(r) {
+		writeError(w, http.StatusTooManyRequests, "daily upload quota exceeded")
+		return
+	}
+
+	if h.GreylistThreshold > 0 && identity != "" && h.greylist.blocked(identity, time.Now(), h.GreylistWindow, h.GreylistThreshold) {
+		writeError(w, http.StatusTooManyRequests, "uploads temporarily blocked after repeated validation failures")
+		return
+	}
+
+	var upload models.Upload
+
+	if h.Temp != nil && isMultipart(r) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "missing file part")
+			return
+		}
+		defer file.Close()
+
+		if strings.EqualFold(filepath.Ext(header.Filename), ".zip") && r.FormValue("explode") == "true" {
+			h.explodeZipUpload(w, r, file, header)
+			return
+		}
+
+		upload.Dataset = r.FormValue("dataset")
+		upload.Filename = header.Filename
+		upload.Tags = parseTags(r.FormValue("tags"))
+
+		if upload.Dataset == "" {
+			if dataset, ok := validate.DetectDataset(upload.Filename, h.FilenamePolicy); ok {
+				upload.Dataset = dataset
+			}
+		} else if result := validate.ValidateFilename(upload.Dataset, upload.Filename, h.FilenamePolicy); !result.Valid {
+			errs := make([]models.ValidationError, len(result.Findings))
+			for i, finding := range result.Findings {
+				errs[i] = models.ValidationError{Rule: finding.Code, Message: finding.Message, Value: upload.Filename}
+			}
+			h.recordUploadFailure(r.Context(), identity)
+			writeValidationErrors(w, errs...)
+			return
+		}
+
+		upload.TempKey = fmt.Sprintf("pending/%s/%s", upload.Dataset, header.Filename)
+
+		var body io.Reader = file
+		size := header.Size
+
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".pdf":
+			var ok bool
+			body, size, ok = h.screenPDFUpload(w, file)
+			if !ok {
+				h.recordUploadFailure(r.Context(), identity)
+				return
+			}
+		case ".xlsx", ".xlsm":
+			var ok bool
+			body, size, ok = h.screenXLSXUpload(w, file)
+			if !ok {
+				h.recordUploadFailure(r.Context(), identity)
+				return
+			}
+		}
+
+		token := r.FormValue("upload_token")
+		if token != "" {
+			_, tracked := h.Progress.Start(token, size, body)
+			body = tracked
+			defer h.Progress.Finish(token)
+		}
+
+		expectedSHA256 := r.Header.Get(contentSHA256Header)
+		expectedMD5 := r.Header.Get(contentMD5Header)
+		sha256Hasher := sha256.New()
+		md5Hasher := md5.New()
+		body = io.TeeReader(body, io.MultiWriter(sha256Hasher, md5Hasher))
+
+		if err := h.Temp.Store(r.Context(), upload.TempKey, body, size, h.TempTTL); err != nil {
+			if errors.Is(err, temp.ErrCapacityExceeded) {
+				writeError(w, http.StatusInsufficientStorage, err.Error())
+				return
+			}
+			writeError(w, http.StatusInternalServerError, "failed to stage file")
+			return
+		}
+
+		checksum := hex.EncodeToString(sha256Hasher.Sum(nil))
+		if expectedSHA256 != "" && !strings.EqualFold(expectedSHA256, checksum) {
+			_ = h.Temp.Delete(r.Context(), upload.TempKey)
+			h.recordUploadFailure(r.Context(), identity)
+			writeValidationErrors(w, models.ValidationError{
+				Rule:    "checksum_mismatch",
+				Message: fmt.Sprintf("uploaded content sha256 %s did not match %s %s", checksum, contentSHA256Header, expectedSHA256),
+				Value:   checksum,
+			})
+			return
+		}
+		if expectedMD5 != "" {
+			if md5Sum := base64.StdEncoding.EncodeToString(md5Hasher.Sum(nil)); expectedMD5 != md5Sum {
+				_ = h.Temp.Delete(r.Context(), upload.TempKey)
+				h.recordUploadFailure(r.Context(), identity)
+				writeValidationErrors(w, models.ValidationError{
+					Rule:    "checksum_mismatch",
+					Message: fmt.Sprintf("uploaded content md5 %s did not match %s %s", md5Sum, contentMD5Header, expectedMD5),
+					Value:   md5Sum,
+				})
+				return
+			}
+		}
+		upload.Checksum = checksum
+	} else if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if h.isServiceRequest(r) {
+		upload.UploadedBy = h.ServiceAuthPrincipal
+	} else if upload.UploadedBy == "" {
+		upload.UploadedBy = identity
+	}
+
+	upload.Status = models.StatusPending
+
+	if err := h.Store.CreateUpload(r.Context(), &upload); err != nil {
+		h.alertStoreFailure(r.Context(), "failed to create upload "+upload.Filename+": "+err.Error())
+		writeError(w, http.StatusInternalServerError, "failed to create upload")
+		return
+	}
+
+	h.notifyApprovalRequest(r.Context(), &upload)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(upload)
+}
+
+func isMultipart(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	return len(ct) >= 19 && ct[:19] == "multipart/form-data"
+}
+
+// parseTags splits a comma-separated tags form value into a trimmed,
+// non-empty tag list. An empty input yields a nil slice.
+func parseTags(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	var tags []string
+	for _, tag := range strings.Split(raw, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// UpdateUploadTags handles PATCH /uploads/{id}/tags, letting a reviewer
+// replace an upload's tags with a new set.
+func (h *Handler) UpdateUploadTags(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	upload, err := h.Store.GetUpload(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	upload.Tags = body.Tags
+	if err := h.Store.UpdateUpload(r.Context(), upload); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update upload")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(upload)
+}
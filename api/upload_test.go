@@ -0,0 +1,568 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func weoFilenamePolicy() validate.FilenamePolicy {
+	return validate.FilenamePolicy{
+		Patterns: map[string]*regexp.Regexp{
+			"weo": regexp.MustCompile(`^imf_weo_\d{6}\.xlsx$`),
+		},
+	}
+}
+
+func newCreateUploadRequest(t *testing.T, dataset, filename string) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if dataset != "" {
+		if err := w.WriteField("dataset", dataset); err != nil {
+			t.Fatalf("failed to write dataset field: %v", err)
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write([]byte("data")); err != nil {
+		t.Fatalf("failed to write form file content: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestCreateUpload_FilenamePolicy(t *testing.T) {
+	t.Run("rejects a filename not matching its dataset's pattern", func(t *testing.T) {
+		store := &storemock.StoreMock{}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithFilenamePolicy(weoFilenamePolicy()),
+		)
+
+		req := newCreateUploadRequest(t, "weo", "weo-january.xlsx")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("detects the dataset from the filename when none is supplied", func(t *testing.T) {
+		var created models.Upload
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(ctx context.Context, upload *models.Upload) error {
+				created = *upload
+				return nil
+			},
+		}
+		h := api.NewHandler(store,
+			api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour),
+			api.WithFilenamePolicy(weoFilenamePolicy()),
+		)
+
+		req := newCreateUploadRequest(t, "", "imf_weo_202401.xlsx")
+		rec := httptest.NewRecorder()
+
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if created.Dataset != "weo" {
+			t.Fatalf("expected dataset to be detected as %q, got %q", "weo", created.Dataset)
+		}
+
+		var body models.Upload
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.Dataset != "weo" {
+			t.Fatalf("expected response dataset %q, got %q", "weo", body.Dataset)
+		}
+	})
+}
+
+func TestGetUploadCounts(t *testing.T) {
+	t.Run("returns the per-status counts from the store", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CountUploadsByStatusFunc: func(ctx context.Context) (map[models.Status]int, error) {
+				return map[models.Status]int{models.StatusPending: 2, models.StatusApproved: 1}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/uploads/counts", nil)
+		rec := httptest.NewRecorder()
+
+		h.GetUploadCounts(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var counts map[models.Status]int
+		if err := json.NewDecoder(rec.Body).Decode(&counts); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if counts[models.StatusPending] != 2 || counts[models.StatusApproved] != 1 {
+			t.Fatalf("unexpected counts: %+v", counts)
+		}
+	})
+}
+
+func TestListUploads(t *testing.T) {
+	t.Run("filters by comma-separated status and prefix", func(t *testing.T) {
+		var gotFilter models.UploadFilter
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				gotFilter = filter
+				return []*models.Upload{{ID: "1"}}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads?dataset=weo&status=pending,rejected&prefix=imf/weo/", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListUploads(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if gotFilter.Dataset != "weo" || gotFilter.TargetKeyPrefix != "imf/weo/" {
+			t.Fatalf("unexpected filter: %+v", gotFilter)
+		}
+		if len(gotFilter.Statuses) != 2 || gotFilter.Statuses[0] != models.StatusPending || gotFilter.Statuses[1] != models.StatusRejected {
+			t.Fatalf("unexpected statuses: %+v", gotFilter.Statuses)
+		}
+	})
+
+	t.Run("filters by comma-separated tags", func(t *testing.T) {
+		var gotFilter models.UploadFilter
+		store := &storemock.StoreMock{
+			ListUploadsFilteredFunc: func(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+				gotFilter = filter
+				return []*models.Upload{{ID: "1"}}, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads?tags=Q3-revision, embargoed", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListUploads(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(gotFilter.Tags) != 2 || gotFilter.Tags[0] != "Q3-revision" || gotFilter.Tags[1] != "embargoed" {
+			t.Fatalf("unexpected tags: %+v", gotFilter.Tags)
+		}
+	})
+
+	t.Run("rejects an unrecognised sortBy value", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads?sortBy=filename", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListUploads(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("advertises the sortable fields on every response", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) { return nil, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListUploads(rec, req)
+
+		if got := rec.Header().Get("Upload-Sortable-Fields"); got == "" {
+			t.Fatalf("expected Upload-Sortable-Fields to be set")
+		}
+	})
+
+	t.Run("falls back to the simple dataset listing when no status or prefix is given", func(t *testing.T) {
+		called := false
+		store := &storemock.StoreMock{
+			ListUploadsFunc: func(ctx context.Context, dataset string) ([]*models.Upload, error) {
+				called = true
+				return nil, nil
+			},
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads?dataset=weo", nil)
+		rec := httptest.NewRecorder()
+
+		h.ListUploads(rec, req)
+
+		if !called {
+			t.Fatalf("expected the simple ListUploads path to be used")
+		}
+	})
+}
+
+func TestGetUpload_TempAvailability(t *testing.T) {
+	t.Run("reports temp_available and a countdown when the staged file exists", func(t *testing.T) {
+		tempStorage := temp.NewInMemoryStorage(1<<20, 0)
+		if err := tempStorage.Store(context.Background(), "pending/weo/file.xlsx", bytes.NewReader([]byte("data")), 4, time.Hour); err != nil {
+			t.Fatalf("failed to seed temp storage: %v", err)
+		}
+
+		upload := &models.Upload{ID: "1", TempKey: "pending/weo/file.xlsx", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(tempStorage, time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		var body struct {
+			TempAvailable        bool  `json:"temp_available"`
+			TempExpiresInSeconds int64 `json:"temp_expires_in_seconds"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !body.TempAvailable {
+			t.Fatalf("expected temp_available to be true")
+		}
+		if body.TempExpiresInSeconds <= 0 || body.TempExpiresInSeconds > 3600 {
+			t.Fatalf("expected a countdown within the configured TTL, got %d", body.TempExpiresInSeconds)
+		}
+	})
+
+	t.Run("reports temp_available false once the staged file is gone", func(t *testing.T) {
+		tempStorage := temp.NewInMemoryStorage(1<<20, 0)
+
+		upload := &models.Upload{ID: "1", TempKey: "pending/weo/file.xlsx", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(ctx context.Context, id string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store, api.WithTemp(tempStorage, time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		var body struct {
+			TempAvailable bool `json:"temp_available"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.TempAvailable {
+			t.Fatalf("expected temp_available to be false")
+		}
+	})
+}
+
+func TestGetUpload_ETag(t *testing.T) {
+	upload := &models.Upload{ID: "1", Status: models.StatusPending}
+	store := &storemock.StoreMock{
+		GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+	h.GetUpload(rec, req)
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag header")
+	}
+
+	t.Run("returns 304 when If-None-Match matches", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+		req.SetPathValue("id", "1")
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Fatalf("expected status 304, got %d", rec.Code)
+		}
+		if rec.Body.Len() != 0 {
+			t.Fatalf("expected an empty body, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("returns the full body once the upload changes", func(t *testing.T) {
+		upload.Status = models.StatusApproved
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1", nil)
+		req.SetPathValue("id", "1")
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if rec.Header().Get("ETag") == etag {
+			t.Fatal("expected the ETag to change alongside the upload")
+		}
+	})
+}
+
+func TestGetUpload_Wait(t *testing.T) {
+	t.Run("returns as soon as the upload changes, without waiting out the full timeout", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			upload.Status = models.StatusApproved
+			h.Events.Publish(upload.ID)
+		}()
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1?wait=5s", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		start := time.Now()
+		h.GetUpload(rec, req)
+		if elapsed := time.Since(start); elapsed >= 5*time.Second {
+			t.Fatalf("expected the publish to wake the request well before the 5s wait, took %s", elapsed)
+		}
+
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.Status != string(models.StatusApproved) {
+			t.Fatalf("expected status %q, got %q", models.StatusApproved, body.Status)
+		}
+	})
+
+	t.Run("returns the unchanged upload once wait elapses", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Status: models.StatusPending}
+		store := &storemock.StoreMock{
+			GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		}
+		h := api.NewHandler(store)
+
+		req := httptest.NewRequest(http.MethodGet, "/uploads/1?wait=20ms", nil)
+		req.SetPathValue("id", "1")
+		rec := httptest.NewRecorder()
+
+		h.GetUpload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.Status != string(models.StatusPending) {
+			t.Fatalf("expected status %q, got %q", models.StatusPending, body.Status)
+		}
+	})
+}
+
+func TestCreateUpload_Tags(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("dataset", "weo"); err != nil {
+		t.Fatalf("failed to write dataset field: %v", err)
+	}
+	if err := w.WriteField("tags", "Q3-revision, embargoed"); err != nil {
+		t.Fatalf("failed to write tags field: %v", err)
+	}
+	part, err := w.CreateFormFile("file", "imf_weo_202501.csv")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write([]byte("data")); err != nil {
+		t.Fatalf("failed to write form file content: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	var created *models.Upload
+	store := &storemock.StoreMock{
+		CreateUploadFunc: func(_ context.Context, upload *models.Upload) error {
+			created = upload
+			return nil
+		},
+	}
+	h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	h.CreateUpload(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
+	if created == nil || len(created.Tags) != 2 || created.Tags[0] != "Q3-revision" || created.Tags[1] != "embargoed" {
+		t.Fatalf("unexpected tags recorded: %+v", created)
+	}
+}
+
+func TestCreateUpload_ContentChecksum(t *testing.T) {
+	buildRequest := func(t *testing.T, checksum string) *http.Request {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		if err := w.WriteField("dataset", "weo"); err != nil {
+			t.Fatalf("failed to write dataset field: %v", err)
+		}
+		part, err := w.CreateFormFile("file", "imf_weo_202501.csv")
+		if err != nil {
+			t.Fatalf("failed to create form file: %v", err)
+		}
+		if _, err := part.Write([]byte("data")); err != nil {
+			t.Fatalf("failed to write form file content: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed to close multipart writer: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", &buf)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		if checksum != "" {
+			req.Header.Set("X-Content-SHA256", checksum)
+		}
+		return req
+	}
+
+	// sha256("data")
+	const checksum = "3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7"
+
+	t.Run("records a matching checksum", func(t *testing.T) {
+		var created *models.Upload
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(_ context.Context, upload *models.Upload) error {
+				created = upload
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, buildRequest(t, checksum))
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if created == nil || created.Checksum != checksum {
+			t.Fatalf("unexpected checksum recorded: %+v", created)
+		}
+	})
+
+	t.Run("rejects a mismatched checksum", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(context.Context, *models.Upload) error {
+				t.Fatal("expected upload not to be created on checksum mismatch")
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, buildRequest(t, strings.Repeat("0", 64)))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("rejects a mismatched Content-MD5", func(t *testing.T) {
+		store := &storemock.StoreMock{
+			CreateUploadFunc: func(context.Context, *models.Upload) error {
+				t.Fatal("expected upload not to be created on checksum mismatch")
+				return nil
+			},
+		}
+		h := api.NewHandler(store, api.WithTemp(temp.NewInMemoryStorage(1<<20, 0), time.Hour))
+
+		req := buildRequest(t, "")
+		req.Header.Set("Content-MD5", "not-the-right-digest")
+		rec := httptest.NewRecorder()
+		h.CreateUpload(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestUpdateUploadTags(t *testing.T) {
+	upload := &models.Upload{ID: "1", Tags: []string{"old-tag"}}
+	var updated *models.Upload
+	store := &storemock.StoreMock{
+		GetUploadFunc: func(context.Context, string) (*models.Upload, error) { return upload, nil },
+		UpdateUploadFunc: func(_ context.Context, u *models.Upload) error {
+			updated = u
+			return nil
+		},
+	}
+	h := api.NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodPatch, "/uploads/1/tags", strings.NewReader(`{"tags":["Q3-revision"]}`))
+	req.SetPathValue("id", "1")
+	rec := httptest.NewRecorder()
+
+	h.UpdateUploadTags(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if updated == nil || len(updated.Tags) != 1 || updated.Tags[0] != "Q3-revision" {
+		t.Fatalf("unexpected tags recorded: %+v", updated)
+	}
+}
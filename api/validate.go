@@ -0,0 +1,72 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// ValidateUpload handles POST /api/v1/uploads/validate, running the same
+// content validation CreateUpload applies to a "file" part (archive policy,
+// active-content scanning, macro detection) without staging the file or
+// creating an upload record, so a dashboard can check a file before
+// committing it to the review queue.
+func (h *Handler) ValidateUpload(w http.ResponseWriter, r *http.Request) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing file part")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read uploaded file")
+		return
+	}
+
+	result := h.validateByExtension(header.Filename, data)
+
+	if dataset := r.FormValue("dataset"); dataset != "" {
+		if filenameResult := validate.ValidateFilename(dataset, header.Filename, h.FilenamePolicy); !filenameResult.Valid {
+			result.Valid = false
+			result.Findings = append(result.Findings, filenameResult.Findings...)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// validateByExtension runs the content validator matching filename's
+// extension, returning a passing empty Result for extensions with no
+// dedicated validator (e.g. csv).
+func (h *Handler) validateByExtension(filename string, data []byte) *validate.Result {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdf":
+		return validate.ValidatePDF(data, h.PDFPolicy)
+	case ".xlsx", ".xlsm":
+		return validate.ValidateXLSX(data, h.XLSXPolicy)
+	case ".zip":
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return &validate.Result{
+				Valid: false,
+				Findings: []validate.Finding{{
+					Code:     "invalid_zip",
+					Message:  "not a valid zip archive",
+					Severity: validate.SeverityReject,
+				}},
+			}
+		}
+		return validate.ValidateZip(reader, h.ZipPolicy)
+	default:
+		return &validate.Result{Valid: true}
+	}
+}
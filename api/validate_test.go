@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func newValidateUploadRequest(t *testing.T, filename string, content []byte) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("failed to write form file content: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/validate", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestValidateUpload(t *testing.T) {
+	t.Run("does not create an upload record", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := newValidateUploadRequest(t, "notes.csv", []byte("a,b,c"))
+		rec := httptest.NewRecorder()
+
+		h.ValidateUpload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var result validate.Result
+		if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !result.Valid {
+			t.Fatalf("expected an extension with no dedicated validator to pass, got %+v", result)
+		}
+	})
+
+	t.Run("rejects a PDF containing embedded JavaScript", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := newValidateUploadRequest(t, "report.pdf", []byte("1 0 obj << /JavaScript >> endobj"))
+		rec := httptest.NewRecorder()
+
+		h.ValidateUpload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var result validate.Result
+		if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result.Valid || len(result.Findings) == 0 {
+			t.Fatalf("expected embedded JavaScript to be flagged, got %+v", result)
+		}
+	})
+
+	t.Run("missing file part returns 400", func(t *testing.T) {
+		h := api.NewHandler(&storemock.StoreMock{})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/validate", nil)
+		rec := httptest.NewRecorder()
+
+		h.ValidateUpload(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// screenXLSXUpload validates file against h.XLSXPolicy. If the workbook is
+// rejected it writes the validation result as the response and returns
+// ok=false; callers must stop processing the request in that case.
+func (h *Handler) screenXLSXUpload(w http.ResponseWriter, file multipart.File) (body io.Reader, size int64, ok bool) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read uploaded file")
+		return nil, 0, false
+	}
+
+	result := validate.ValidateXLSX(data, h.XLSXPolicy)
+	if !result.Valid {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(result)
+		return nil, 0, false
+	}
+
+	return bytes.NewReader(data), int64(len(data)), true
+}
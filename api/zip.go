@@ -0,0 +1,101 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// explodeZipUpload validates a zip bundle against h.ZipPolicy and, if valid,
+// stages each contained entry as its own pending upload linked back to a
+// parent "bundle" upload via ParentUploadID.
+func (h *Handler) explodeZipUpload(w http.ResponseWriter, r *http.Request, file multipart.File, header *multipart.FileHeader) {
+	reader, err := zip.NewReader(file, header.Size)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "not a valid zip archive")
+		return
+	}
+
+	result := validate.ValidateZip(reader, h.ZipPolicy)
+	if !result.Valid {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	entries, err := validate.ExtractZip(reader)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to extract zip entries")
+		return
+	}
+
+	dataset := r.FormValue("dataset")
+
+	bundle := &models.Upload{
+		ID:        newUploadID(),
+		Dataset:   dataset,
+		Filename:  header.Filename,
+		Status:    models.StatusPending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := h.Store.CreateUpload(r.Context(), bundle); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create bundle upload")
+		return
+	}
+
+	children := make([]*models.Upload, 0, len(entries))
+	for _, entry := range entries {
+		child := &models.Upload{
+			ID:             newUploadID(),
+			Dataset:        dataset,
+			Filename:       entry.Name,
+			TempKey:        fmt.Sprintf("pending/%s/%s", dataset, entry.Name),
+			Status:         models.StatusPending,
+			ParentUploadID: bundle.ID,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+
+		if h.Temp != nil {
+			if err := h.Temp.Store(r.Context(), child.TempKey, bytes.NewReader(entry.Data), int64(len(entry.Data)), h.TempTTL); err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to stage exploded entry")
+				return
+			}
+		}
+
+		if err := h.Store.CreateUpload(r.Context(), child); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to create upload for exploded entry")
+			return
+		}
+
+		children = append(children, child)
+	}
+
+	if h.Notifier != nil {
+		_ = h.Notifier.Notify(r.Context(), fmt.Sprintf("zip bundle %s exploded into %d uploads pending review", bundle.ID, len(children)))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(struct {
+		Bundle   *models.Upload   `json:"bundle"`
+		Children []*models.Upload `json:"children"`
+	}{Bundle: bundle, Children: children})
+}
+
+func newUploadID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,168 @@
+// Package archive periodically moves upload records that finished review
+// more than a configured retention period ago out of the hot collection, so
+// list queries over live uploads keep a bounded working set as the
+// collection grows over years of operation.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Store is the subset of store.Store the archiver depends on. Duplicated
+// from store.Store to avoid an import cycle.
+type Store interface {
+	ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error)
+	DeleteUpload(ctx context.Context, id string) error
+}
+
+// Uploader puts a file's contents into permanent storage. It is declared
+// here rather than reusing api.Uploader, to avoid an import cycle, and its
+// UploadFile takes no content-type, content-disposition or cache-control
+// arguments.
+type Uploader interface {
+	UploadFile(ctx context.Context, key string, body io.Reader) error
+}
+
+// Stats summarises the outcome of the most recently completed archival
+// pass.
+type Stats struct {
+	LastRunAt time.Time `json:"last_run_at"`
+	Archived  int       `json:"archived"`
+	// Failed counts uploads whose archive object could not be written or
+	// whose hot-collection record could not be trimmed, so a run degraded
+	// by infrastructure failures is visible rather than silently reported
+	// as "all clear".
+	Failed int `json:"failed"`
+}
+
+// Archiver periodically finds approved or rejected uploads whose Status
+// hasn't changed for longer than Retention, writes each one as an NDJSON
+// object under ArchivePrefix in S3, and deletes it from the hot collection.
+// Uploads still pending, or otherwise younger than Retention, are left
+// untouched.
+type Archiver struct {
+	Store         Store
+	S3            Uploader
+	Retention     time.Duration
+	Interval      time.Duration
+	ArchivePrefix string
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewArchiver constructs an Archiver backed by store and s3. A zero
+// interval disables Run; ArchiveOnce can still be called directly, e.g.
+// from an ops command.
+func NewArchiver(store Store, s3 Uploader, retention, interval time.Duration, archivePrefix string) *Archiver {
+	return &Archiver{Store: store, S3: s3, Retention: retention, Interval: interval, ArchivePrefix: archivePrefix}
+}
+
+// Run executes ArchiveOnce every Interval until ctx is cancelled. It is a
+// no-op if Interval is zero.
+func (a *Archiver) Run(ctx context.Context) {
+	if a.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = a.ArchiveOnce(ctx)
+		}
+	}
+}
+
+// ArchiveOnce finds every archivable upload older than Retention, writes it
+// to S3 as a single NDJSON object under ArchivePrefix, and removes it from
+// the hot collection. Records are written to S3 before being deleted, so a
+// failed write leaves the record in place to be retried on the next pass
+// rather than losing it.
+func (a *Archiver) ArchiveOnce(ctx context.Context) error {
+	uploads, err := a.Store.ListUploads(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list uploads for archival: %w", err)
+	}
+
+	stats := Stats{LastRunAt: time.Now()}
+	var errs []error
+
+	for _, upload := range uploads {
+		if !archivable(upload, stats.LastRunAt, a.Retention) {
+			continue
+		}
+
+		if err := a.archiveUpload(ctx, upload); err != nil {
+			stats.Failed++
+			errs = append(errs, fmt.Errorf("failed to archive upload %s: %w", upload.ID, err))
+			continue
+		}
+
+		if err := a.Store.DeleteUpload(ctx, upload.ID); err != nil {
+			stats.Failed++
+			errs = append(errs, fmt.Errorf("failed to trim archived upload %s from the hot collection: %w", upload.ID, err))
+			continue
+		}
+
+		stats.Archived++
+	}
+
+	a.mu.Lock()
+	a.stats = stats
+	a.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the most recently completed archival pass.
+// The zero value means no pass has completed yet.
+func (a *Archiver) Stats() Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+// archiveUpload writes upload as a single NDJSON line to its own object
+// under ArchivePrefix, keyed by ID so re-running a pass after a partial
+// failure overwrites rather than duplicates it.
+func (a *Archiver) archiveUpload(ctx context.Context, upload *models.Upload) error {
+	line, err := json.Marshal(upload)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload: %w", err)
+	}
+	line = append(line, '\n')
+
+	key := fmt.Sprintf("%s/%s.ndjson", strings.TrimSuffix(a.ArchivePrefix, "/"), upload.ID)
+	if err := a.S3.UploadFile(ctx, key, bytes.NewReader(line)); err != nil {
+		return fmt.Errorf("failed to write archive object: %w", err)
+	}
+	return nil
+}
+
+// archivable reports whether upload finished review (approved or rejected)
+// more than retention ago, measured from UpdatedAt, so an old but still
+// pending upload is never swept up.
+func archivable(upload *models.Upload, now time.Time, retention time.Duration) bool {
+	if upload.Status != models.StatusApproved && upload.Status != models.StatusRejected {
+		return false
+	}
+	if upload.UpdatedAt.IsZero() {
+		return false
+	}
+	return now.Sub(upload.UpdatedAt) >= retention
+}
@@ -0,0 +1,128 @@
+package archive_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/archive"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+type fakeStore struct {
+	uploads   []*models.Upload
+	deleted   []string
+	deleteErr error
+}
+
+func (s *fakeStore) ListUploads(_ context.Context, _ string) ([]*models.Upload, error) {
+	return s.uploads, nil
+}
+
+func (s *fakeStore) DeleteUpload(_ context.Context, id string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+type fakeUploader struct {
+	objects   map[string][]byte
+	uploadErr error
+}
+
+func (u *fakeUploader) UploadFile(_ context.Context, key string, body io.Reader) error {
+	if u.uploadErr != nil {
+		return u.uploadErr
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if u.objects == nil {
+		u.objects = map[string][]byte{}
+	}
+	u.objects[key] = content
+	return nil
+}
+
+func TestArchiver_ArchiveOnce(t *testing.T) {
+	now := time.Now()
+
+	t.Run("archives an approved upload older than retention and trims it from the hot collection", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Dataset: "cpi", Status: models.StatusApproved, UpdatedAt: now.Add(-100 * 24 * time.Hour)}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeUploader{}
+		a := archive.NewArchiver(store, s3, 90*24*time.Hour, 0, "archive/uploads")
+
+		if err := a.ArchiveOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(store.deleted) != 1 || store.deleted[0] != "1" {
+			t.Fatalf("expected upload 1 to be trimmed, got %+v", store.deleted)
+		}
+		content, ok := s3.objects["archive/uploads/1.ndjson"]
+		if !ok {
+			t.Fatalf("expected an archive object to be written")
+		}
+		var archived models.Upload
+		if err := json.Unmarshal(content, &archived); err != nil {
+			t.Fatalf("failed to decode archived upload: %v", err)
+		}
+		if archived.ID != "1" {
+			t.Fatalf("unexpected archived upload: %+v", archived)
+		}
+		if stats := a.Stats(); stats.Archived != 1 {
+			t.Fatalf("expected stats to record one archived upload, got %+v", stats)
+		}
+	})
+
+	t.Run("leaves uploads younger than retention alone", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Status: models.StatusApproved, UpdatedAt: now.Add(-time.Hour)}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		a := archive.NewArchiver(store, &fakeUploader{}, 90*24*time.Hour, 0, "archive/uploads")
+
+		if err := a.ArchiveOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(store.deleted) != 0 {
+			t.Fatalf("expected no uploads to be archived, got %+v", store.deleted)
+		}
+	})
+
+	t.Run("skips pending uploads regardless of age", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Status: models.StatusPending, UpdatedAt: now.Add(-365 * 24 * time.Hour)}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		a := archive.NewArchiver(store, &fakeUploader{}, 90*24*time.Hour, 0, "archive/uploads")
+
+		if err := a.ArchiveOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(store.deleted) != 0 {
+			t.Fatalf("expected pending uploads to be skipped, got %+v", store.deleted)
+		}
+	})
+
+	t.Run("a failed archive write leaves the record in place to retry next pass", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", Status: models.StatusRejected, UpdatedAt: now.Add(-100 * 24 * time.Hour)}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeUploader{uploadErr: errors.New("s3 unavailable")}
+		a := archive.NewArchiver(store, s3, 90*24*time.Hour, 0, "archive/uploads")
+
+		err := a.ArchiveOnce(context.Background())
+		if err == nil {
+			t.Fatalf("expected the write failure to be reported")
+		}
+		if len(store.deleted) != 0 {
+			t.Fatalf("expected the record not to be trimmed after a failed write, got %+v", store.deleted)
+		}
+		if stats := a.Stats(); stats.Failed != 1 {
+			t.Fatalf("expected stats to record one failure, got %+v", stats)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package auditretention periodically exports audit log entries older than
+// a configured retention period to S3 as a single NDJSON batch, then deletes
+// them from the hot collection, keeping the audit log queryable while still
+// preserving entries for compliance.
+package auditretention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Store is the subset of store.Store the retention job depends on.
+// Duplicated from store.Store to avoid an import cycle.
+type Store interface {
+	ListAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) ([]*models.AuditLogEntry, error)
+	DeleteAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) error
+}
+
+// Uploader puts a file's contents into permanent storage. It is declared
+// here rather than reusing api.Uploader, to avoid an import cycle, and its
+// UploadFile takes no content-type, content-disposition or cache-control
+// arguments.
+type Uploader interface {
+	UploadFile(ctx context.Context, key string, body io.Reader) error
+}
+
+// Stats summarises the outcome of the most recently completed export pass.
+type Stats struct {
+	LastRunAt time.Time `json:"last_run_at"`
+	Exported  int       `json:"exported"`
+	// Failed is 1 if the pass's export batch could not be written or the
+	// expired entries could not be trimmed, and 0 otherwise: a batch export
+	// either succeeds as a whole or is retried whole on the next pass.
+	Failed int `json:"failed"`
+}
+
+// Retainer periodically finds audit log entries recorded more than
+// Retention ago, writes them as a single NDJSON object under ExportPrefix in
+// S3, and deletes them from the hot collection. Entries younger than
+// Retention are left untouched.
+type Retainer struct {
+	Store        Store
+	S3           Uploader
+	Retention    time.Duration
+	Interval     time.Duration
+	ExportPrefix string
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewRetainer constructs a Retainer backed by store and s3. A zero interval
+// disables Run; ExportOnce can still be called directly, e.g. from an ops
+// command.
+func NewRetainer(store Store, s3 Uploader, retention, interval time.Duration, exportPrefix string) *Retainer {
+	return &Retainer{Store: store, S3: s3, Retention: retention, Interval: interval, ExportPrefix: exportPrefix}
+}
+
+// Run executes ExportOnce every Interval until ctx is cancelled. It is a
+// no-op if Interval is zero.
+func (r *Retainer) Run(ctx context.Context) {
+	if r.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.ExportOnce(ctx)
+		}
+	}
+}
+
+// ExportOnce finds every audit log entry recorded more than Retention ago,
+// writes them all to S3 as a single NDJSON object under ExportPrefix, and
+// removes them from the hot collection. Entries are written to S3 before
+// being deleted, so a failed write leaves them in place to be retried on the
+// next pass rather than losing them.
+func (r *Retainer) ExportOnce(ctx context.Context) error {
+	if r.Retention <= 0 {
+		return nil
+	}
+
+	stats := Stats{LastRunAt: time.Now()}
+	cutoff := stats.LastRunAt.Add(-r.Retention)
+
+	entries, err := r.Store.ListAuditLogEntriesOlderThan(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to list expired audit log entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		r.mu.Lock()
+		r.stats = stats
+		r.mu.Unlock()
+		return nil
+	}
+
+	if err := r.exportBatch(ctx, cutoff, entries); err != nil {
+		stats.Failed = 1
+		r.mu.Lock()
+		r.stats = stats
+		r.mu.Unlock()
+		return fmt.Errorf("failed to export expired audit log entries: %w", err)
+	}
+
+	if err := r.Store.DeleteAuditLogEntriesOlderThan(ctx, cutoff); err != nil {
+		stats.Failed = 1
+		r.mu.Lock()
+		r.stats = stats
+		r.mu.Unlock()
+		return fmt.Errorf("failed to trim exported audit log entries from the hot collection: %w", err)
+	}
+
+	stats.Exported = len(entries)
+	r.mu.Lock()
+	r.stats = stats
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Stats returns a snapshot of the most recently completed export pass. The
+// zero value means no pass has completed yet.
+func (r *Retainer) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// exportBatch writes every entry as one NDJSON line each to a single object
+// under ExportPrefix, keyed by the run's cutoff so re-running a pass after a
+// partial failure overwrites rather than duplicates it.
+func (r *Retainer) exportBatch(ctx context.Context, cutoff time.Time, entries []*models.AuditLogEntry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit log entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	key := fmt.Sprintf("%s/%s.ndjson", strings.TrimSuffix(r.ExportPrefix, "/"), cutoff.UTC().Format("20060102T150405Z"))
+	if err := r.S3.UploadFile(ctx, key, &buf); err != nil {
+		return fmt.Errorf("failed to write export object: %w", err)
+	}
+	return nil
+}
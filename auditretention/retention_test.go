@@ -0,0 +1,141 @@
+package auditretention_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/auditretention"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+type fakeStore struct {
+	entries   []*models.AuditLogEntry
+	deleted   bool
+	deleteErr error
+}
+
+func (s *fakeStore) ListAuditLogEntriesOlderThan(_ context.Context, cutoff time.Time) ([]*models.AuditLogEntry, error) {
+	var expired []*models.AuditLogEntry
+	for _, entry := range s.entries {
+		if entry.RecordedAt.Before(cutoff) {
+			expired = append(expired, entry)
+		}
+	}
+	return expired, nil
+}
+
+func (s *fakeStore) DeleteAuditLogEntriesOlderThan(_ context.Context, _ time.Time) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = true
+	return nil
+}
+
+type fakeUploader struct {
+	objects   map[string][]byte
+	uploadErr error
+}
+
+func (u *fakeUploader) UploadFile(_ context.Context, key string, body io.Reader) error {
+	if u.uploadErr != nil {
+		return u.uploadErr
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if u.objects == nil {
+		u.objects = map[string][]byte{}
+	}
+	u.objects[key] = content
+	return nil
+}
+
+func TestRetainer_ExportOnce(t *testing.T) {
+	now := time.Now()
+
+	t.Run("exports entries older than retention as one batch and trims them from the hot collection", func(t *testing.T) {
+		entry := &models.AuditLogEntry{ID: "1", Method: "POST", Path: "/uploads", RecordedAt: now.Add(-800 * 24 * time.Hour)}
+		store := &fakeStore{entries: []*models.AuditLogEntry{entry}}
+		s3 := &fakeUploader{}
+		r := auditretention.NewRetainer(store, s3, 730*24*time.Hour, 0, "archive/audit-log")
+
+		if err := r.ExportOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !store.deleted {
+			t.Fatalf("expected expired entries to be trimmed")
+		}
+		var found bool
+		for key, content := range s3.objects {
+			if !strings.HasPrefix(key, "archive/audit-log/") {
+				continue
+			}
+			found = true
+			var exported models.AuditLogEntry
+			if err := json.Unmarshal(content, &exported); err != nil {
+				t.Fatalf("failed to decode exported entry: %v", err)
+			}
+			if exported.ID != "1" {
+				t.Fatalf("unexpected exported entry: %+v", exported)
+			}
+		}
+		if !found {
+			t.Fatalf("expected an export object to be written")
+		}
+		if stats := r.Stats(); stats.Exported != 1 {
+			t.Fatalf("expected stats to record one exported entry, got %+v", stats)
+		}
+	})
+
+	t.Run("leaves entries younger than retention alone", func(t *testing.T) {
+		entry := &models.AuditLogEntry{ID: "1", RecordedAt: now.Add(-time.Hour)}
+		store := &fakeStore{entries: []*models.AuditLogEntry{entry}}
+		r := auditretention.NewRetainer(store, &fakeUploader{}, 730*24*time.Hour, 0, "archive/audit-log")
+
+		if err := r.ExportOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if store.deleted {
+			t.Fatalf("expected no entries to be trimmed")
+		}
+	})
+
+	t.Run("a failed export write leaves entries in place to retry next pass", func(t *testing.T) {
+		entry := &models.AuditLogEntry{ID: "1", RecordedAt: now.Add(-800 * 24 * time.Hour)}
+		store := &fakeStore{entries: []*models.AuditLogEntry{entry}}
+		s3 := &fakeUploader{uploadErr: errors.New("s3 unavailable")}
+		r := auditretention.NewRetainer(store, s3, 730*24*time.Hour, 0, "archive/audit-log")
+
+		err := r.ExportOnce(context.Background())
+		if err == nil {
+			t.Fatalf("expected the write failure to be reported")
+		}
+		if store.deleted {
+			t.Fatalf("expected entries not to be trimmed after a failed write")
+		}
+		if stats := r.Stats(); stats.Failed != 1 {
+			t.Fatalf("expected stats to record a failure, got %+v", stats)
+		}
+	})
+
+	t.Run("a zero retention disables the job", func(t *testing.T) {
+		entry := &models.AuditLogEntry{ID: "1", RecordedAt: now.Add(-800 * 24 * time.Hour)}
+		store := &fakeStore{entries: []*models.AuditLogEntry{entry}}
+		r := auditretention.NewRetainer(store, &fakeUploader{}, 0, 0, "archive/audit-log")
+
+		if err := r.ExportOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if store.deleted {
+			t.Fatalf("expected no entries to be trimmed when retention is disabled")
+		}
+	})
+}
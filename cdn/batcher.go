@@ -0,0 +1,104 @@
+// Package cdn coalesces CloudFront invalidation requests raised by
+// multiple upload approvals completed close together into a single
+// CreateInvalidation call, since each invalidation has a per-path cost.
+package cdn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Invalidator triggers cache invalidation for the given paths and returns
+// an invalidation ID that can be used to track completion. Duplicated from
+// api.Invalidator so this package does not depend on api.
+type Invalidator interface {
+	InvalidatePaths(ctx context.Context, paths []string) (string, error)
+}
+
+// Batcher coalesces InvalidatePaths calls raised within Window into a
+// single call to the wrapped Invalidator, so N approvals completed close
+// together share one invalidation ID instead of paying for N. It
+// implements the same Invalidator interface it wraps, so it can be used
+// anywhere a CloudFrontClient is expected.
+type Batcher struct {
+	Invalidator Invalidator
+	// Window is how long a batch stays open collecting paths before it is
+	// flushed. Window <= 0 disables batching: InvalidatePaths calls
+	// straight through.
+	Window time.Duration
+
+	mu      sync.Mutex
+	paths   []string
+	waiters []chan batchResult
+	timer   *time.Timer
+}
+
+type batchResult struct {
+	id  string
+	err error
+}
+
+// NewBatcher constructs a Batcher coalescing calls to invalidator within
+// window.
+func NewBatcher(invalidator Invalidator, window time.Duration) *Batcher {
+	return &Batcher{Invalidator: invalidator, Window: window}
+}
+
+// InvalidatePaths adds paths to the pending batch and blocks until that
+// batch is flushed, returning the invalidation ID shared by every caller
+// in the same batch.
+func (b *Batcher) InvalidatePaths(ctx context.Context, paths []string) (string, error) {
+	if b.Window <= 0 {
+		return b.Invalidator.InvalidatePaths(ctx, paths)
+	}
+
+	wait := make(chan batchResult, 1)
+
+	b.mu.Lock()
+	b.paths = append(b.paths, paths...)
+	b.waiters = append(b.waiters, wait)
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.Window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case res := <-wait:
+		return res.id, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// flush sends the accumulated batch of paths as a single InvalidatePaths
+// call and delivers the shared result to every waiting caller.
+func (b *Batcher) flush() {
+	b.mu.Lock()
+	paths := dedupe(b.paths)
+	waiters := b.waiters
+	b.paths = nil
+	b.waiters = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	id, err := b.Invalidator.InvalidatePaths(context.Background(), paths)
+	for _, waiter := range waiters {
+		waiter <- batchResult{id: id, err: err}
+	}
+}
+
+// dedupe returns paths with duplicates removed, preserving first
+// occurrence order.
+func dedupe(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package cdn_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/cdn"
+)
+
+type recordingInvalidator struct {
+	mu    sync.Mutex
+	calls [][]string
+}
+
+func (r *recordingInvalidator) InvalidatePaths(_ context.Context, paths []string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, paths)
+	return "INV1", nil
+}
+
+func (r *recordingInvalidator) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.calls)
+}
+
+func TestBatcher_InvalidatePaths(t *testing.T) {
+	t.Run("coalesces paths raised within the window into one call", func(t *testing.T) {
+		invalidator := &recordingInvalidator{}
+		batcher := cdn.NewBatcher(invalidator, 20*time.Millisecond)
+
+		var wg sync.WaitGroup
+		ids := make([]string, 2)
+		for i, p := range []string{"/a", "/b"} {
+			wg.Add(1)
+			go func(i int, path string) {
+				defer wg.Done()
+				id, err := batcher.InvalidatePaths(context.Background(), []string{path})
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				ids[i] = id
+			}(i, p)
+		}
+		wg.Wait()
+
+		if invalidator.callCount() != 1 {
+			t.Fatalf("expected one coalesced call, got %d", invalidator.callCount())
+		}
+		if ids[0] != ids[1] {
+			t.Fatalf("expected callers in the same batch to share an invalidation ID, got %q and %q", ids[0], ids[1])
+		}
+	})
+
+	t.Run("disabled when window is zero", func(t *testing.T) {
+		invalidator := &recordingInvalidator{}
+		batcher := cdn.NewBatcher(invalidator, 0)
+
+		if _, err := batcher.InvalidatePaths(context.Background(), []string{"/a"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := batcher.InvalidatePaths(context.Background(), []string{"/b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if invalidator.callCount() != 2 {
+			t.Fatalf("expected two separate calls, got %d", invalidator.callCount())
+		}
+	})
+
+	t.Run("deduplicates repeated paths in a batch", func(t *testing.T) {
+		invalidator := &recordingInvalidator{}
+		batcher := cdn.NewBatcher(invalidator, 20*time.Millisecond)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = batcher.InvalidatePaths(context.Background(), []string{"/a"})
+			}()
+		}
+		wg.Wait()
+
+		if invalidator.callCount() != 1 {
+			t.Fatalf("expected one coalesced call, got %d", invalidator.callCount())
+		}
+		if got := invalidator.calls[0]; len(got) != 1 {
+			t.Fatalf("expected duplicate paths to be deduplicated, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,264 @@
+// Package client is a minimal Go SDK for the dis-imf-uploader HTTP API, for
+// other ONS services that want typed access without hand-rolling requests.
+//
+// Coverage is intentionally partial: it only wraps endpoints the API
+// actually exposes today (listing uploads, stats, an upload's diff summary,
+// and CSV/NDJSON export). Users listing, comments, rollback, cancel,
+// resubmit and batch operations have no corresponding endpoint in this
+// service yet, so there is nothing here to wrap for them; add a method
+// alongside its handler once one lands.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// API is the surface Client implements, so consumers can depend on an
+// interface and substitute mock.ClientMock or fake.Fake in their own tests
+// instead of a real HTTP round trip.
+type API interface {
+	ListUploads(ctx context.Context, opts ListUploadsOptions) ([]models.Upload, error)
+	GetStats(ctx context.Context) (*api.StatsResponse, error)
+	GetUploadTimeline(ctx context.Context, id string) (*validate.DiffSummary, error)
+	ExportUploads(ctx context.Context, format string) ([]byte, error)
+	UploadFileFromPath(ctx context.Context, path string, opts UploadOptions) (*models.Upload, error)
+}
+
+// ErrChecksumMismatch is returned by UploadFileFromPath when the server's
+// response doesn't confirm the checksum the client computed and sent, i.e.
+// what was staged doesn't match what was read from disk.
+var ErrChecksumMismatch = fmt.Errorf("dis-imf-uploader client: uploaded content checksum did not match the server's")
+
+// Client calls a dis-imf-uploader instance over HTTP.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Ensure Client implements API.
+var _ API = (*Client)(nil)
+
+// Option configures a Client constructed by New.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for requests. The default
+// is http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// New builds a Client that talks to the instance at baseURL (e.g.
+// "https://imf-uploader.example.com").
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimRight(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ListUploadsOptions narrows a ListUploads call. Zero values are omitted
+// from the request, matching the API's own "unset means unfiltered"
+// behaviour.
+type ListUploadsOptions struct {
+	Dataset string
+	Status  string
+	Prefix  string
+	SortBy  string
+	Tags    []string
+}
+
+// ListUploads calls GET /uploads.
+func (c *Client) ListUploads(ctx context.Context, opts ListUploadsOptions) ([]models.Upload, error) {
+	query := url.Values{}
+	if opts.Dataset != "" {
+		query.Set("dataset", opts.Dataset)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if opts.Prefix != "" {
+		query.Set("prefix", opts.Prefix)
+	}
+	if opts.SortBy != "" {
+		query.Set("sortBy", opts.SortBy)
+	}
+	if len(opts.Tags) > 0 {
+		query.Set("tags", strings.Join(opts.Tags, ","))
+	}
+
+	var uploads []models.Upload
+	if err := c.get(ctx, "/uploads?"+query.Encode(), &uploads); err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+// GetStats calls GET /api/v1/stats.
+func (c *Client) GetStats(ctx context.Context) (*api.StatsResponse, error) {
+	var stats api.StatsResponse
+	if err := c.get(ctx, "/api/v1/stats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetUploadTimeline calls GET /api/v1/uploads/{id}/diff, which reports how
+// an approved upload's published content differs from what was staged.
+func (c *Client) GetUploadTimeline(ctx context.Context, id string) (*validate.DiffSummary, error) {
+	var summary validate.DiffSummary
+	if err := c.get(ctx, "/api/v1/uploads/"+url.PathEscape(id)+"/diff", &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ExportUploads calls GET /api/v1/uploads/export and returns the raw
+// response body in the requested format ("csv" or "ndjson").
+func (c *Client) ExportUploads(ctx context.Context, format string) ([]byte, error) {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/uploads/export?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("building export request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling export endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading export response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("export endpoint returned %d: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// UploadOptions narrows an UploadFileFromPath call.
+type UploadOptions struct {
+	// Dataset is required unless the server can detect it from the
+	// filename, matching CreateUpload's own fallback via FilenamePolicy.
+	Dataset string
+	Tags    []string
+}
+
+// UploadFileFromPath streams the file at path to POST /uploads as a
+// multipart upload. It detects the file's content type from its first 512
+// bytes (see http.DetectContentType) and sends it as the part's
+// Content-Type, and it computes the sha256 checksum of the whole file
+// before sending, passing it as the X-Content-SHA256 header so the server
+// can verify what it staged matches what was read from disk. If the
+// server's response reports a different checksum, it returns
+// ErrChecksumMismatch alongside the created upload.
+func (c *Client) UploadFileFromPath(ctx context.Context, path string, opts UploadOptions) (*models.Upload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	checksum := sha256.Sum256(data)
+	checksumHex := hex.EncodeToString(checksum[:])
+	contentType := http.DetectContentType(data)
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if opts.Dataset != "" {
+		if err := w.WriteField("dataset", opts.Dataset); err != nil {
+			return nil, fmt.Errorf("writing dataset field: %w", err)
+		}
+	}
+	if len(opts.Tags) > 0 {
+		if err := w.WriteField("tags", strings.Join(opts.Tags, ",")); err != nil {
+			return nil, fmt.Errorf("writing tags field: %w", err)
+		}
+	}
+	part, err := w.CreatePart(map[string][]string{
+		"Content-Disposition": {fmt.Sprintf(`form-data; name="file"; filename=%q`, filepath.Base(path))},
+		"Content-Type":        {contentType},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating file part: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("writing file content: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("closing multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/uploads", &body)
+	if err != nil {
+		return nil, fmt.Errorf("building upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("X-Content-SHA256", checksumHex)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling /uploads: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading upload response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("/uploads returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var upload models.Upload
+	if err := json.Unmarshal(respBody, &upload); err != nil {
+		return nil, fmt.Errorf("decoding upload response: %w", err)
+	}
+	if upload.Checksum != "" && !strings.EqualFold(upload.Checksum, checksumHex) {
+		return &upload, ErrChecksumMismatch
+	}
+	return &upload, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("building request for %s: %w", path, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s returned %d: %s", path, resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response from %s: %w", path, err)
+	}
+	return nil
+}
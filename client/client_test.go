@@ -0,0 +1,143 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/client"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+func TestListUploads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/uploads" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("dataset"); got != "weo" {
+			t.Fatalf("expected dataset=weo, got %q", got)
+		}
+		json.NewEncoder(w).Encode([]models.Upload{{ID: "1", Dataset: "weo"}})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	uploads, err := c.ListUploads(context.Background(), client.ListUploadsOptions{Dataset: "weo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(uploads) != 1 || uploads[0].ID != "1" {
+		t.Fatalf("unexpected uploads: %+v", uploads)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(api.StatsResponse{TotalUploads: 3, Pending: 2})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	stats, err := c.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalUploads != 3 || stats.Pending != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestExportUploads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("format"); got != "ndjson" {
+			t.Fatalf("expected format=ndjson, got %q", got)
+		}
+		w.Write([]byte(`{"id":"1"}` + "\n"))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	body, err := c.ExportUploads(context.Background(), "ndjson")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"id":"1"}`+"\n" {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+func TestUploadFileFromPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "imf_weo_202501.csv")
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	t.Run("sends the checksum header and returns the created upload", func(t *testing.T) {
+		var gotChecksum string
+		var gotContentType string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotChecksum = r.Header.Get("X-Content-SHA256")
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("failed to parse multipart form: %v", err)
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("failed to read file part: %v", err)
+			}
+			defer file.Close()
+			gotContentType = header.Header.Get("Content-Type")
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.Upload{ID: "1", Checksum: gotChecksum})
+		}))
+		defer server.Close()
+
+		c := client.New(server.URL)
+		upload, err := c.UploadFileFromPath(context.Background(), path, client.UploadOptions{Dataset: "weo"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if upload.ID != "1" {
+			t.Fatalf("unexpected upload: %+v", upload)
+		}
+		if gotChecksum == "" {
+			t.Fatal("expected a checksum header to be sent")
+		}
+		if gotContentType == "" {
+			t.Fatal("expected a detected content type on the file part")
+		}
+	})
+
+	t.Run("reports a checksum mismatch from the server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.Upload{ID: "1", Checksum: "does-not-match"})
+		}))
+		defer server.Close()
+
+		c := client.New(server.URL)
+		_, err := c.UploadFileFromPath(context.Background(), path, client.UploadOptions{Dataset: "weo"})
+		if !errors.Is(err, client.ErrChecksumMismatch) {
+			t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+		}
+	})
+}
+
+func TestGetStats_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL)
+	if _, err := c.GetStats(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,194 @@
+// Package fake provides an in-memory client.API for downstream consumers'
+// tests, as an alternative to mock.ClientMock when a test wants realistic
+// stateful behaviour (seed some uploads, transition their status, then
+// assert on what ListUploads returns) rather than per-call stubs.
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/client"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// Fake is an in-memory client.API backed by a map keyed by upload ID.
+type Fake struct {
+	mu       sync.Mutex
+	uploads  map[string]*models.Upload
+	diffs    map[string]*validate.DiffSummary
+	exportFn func(format string) ([]byte, error)
+}
+
+// New returns an empty Fake, ready to be seeded with SetUploads.
+func New() *Fake {
+	return &Fake{
+		uploads: map[string]*models.Upload{},
+		diffs:   map[string]*validate.DiffSummary{},
+	}
+}
+
+// Ensure Fake implements client.API.
+var _ client.API = &Fake{}
+
+// SetUploads seeds (or replaces) the fake's upload set.
+func (f *Fake) SetUploads(uploads ...models.Upload) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range uploads {
+		u := uploads[i]
+		f.uploads[u.ID] = &u
+	}
+}
+
+// SetDiff seeds the diff summary GetUploadTimeline returns for id.
+func (f *Fake) SetDiff(id string, summary validate.DiffSummary) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.diffs[id] = &summary
+}
+
+// SetExportFunc overrides what ExportUploads returns. The default returns
+// an error, since there's no single obvious in-memory rendering of the
+// seeded uploads to CSV/NDJSON.
+func (f *Fake) SetExportFunc(fn func(format string) ([]byte, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.exportFn = fn
+}
+
+// Transition moves the upload identified by id to status, honoring the same
+// state machine the real service enforces (see models.CanTransition). It
+// returns an error if id is unknown or the transition isn't allowed, so a
+// consumer's test can exercise the same illegal-transition failures the
+// real API would return.
+func (f *Fake) Transition(id string, status models.Status) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	upload, ok := f.uploads[id]
+	if !ok {
+		return fmt.Errorf("upload %q not found", id)
+	}
+	if !models.CanTransition(upload.Status, status) {
+		return fmt.Errorf("cannot transition upload %q from %q to %q", id, upload.Status, status)
+	}
+	upload.Status = status
+	return nil
+}
+
+func (f *Fake) ListUploads(_ context.Context, opts client.ListUploadsOptions) ([]models.Upload, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matched []models.Upload
+	for _, u := range f.uploads {
+		if opts.Dataset != "" && u.Dataset != opts.Dataset {
+			continue
+		}
+		if opts.Status != "" && string(u.Status) != opts.Status {
+			continue
+		}
+		if opts.Prefix != "" && !strings.HasPrefix(u.TargetKey, opts.Prefix) {
+			continue
+		}
+		if len(opts.Tags) > 0 && !hasAnyTag(u.Tags, opts.Tags) {
+			continue
+		}
+		matched = append(matched, *u)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return matched, nil
+}
+
+func (f *Fake) GetStats(_ context.Context) (*api.StatsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := api.StatsResponse{TotalUploads: len(f.uploads)}
+	for _, u := range f.uploads {
+		switch u.Status {
+		case models.StatusPending:
+			stats.Pending++
+		case models.StatusApproved:
+			stats.Approved++
+		case models.StatusRejected:
+			stats.Rejected++
+		case models.StatusPublished:
+			stats.Published++
+		}
+	}
+	return &stats, nil
+}
+
+func (f *Fake) GetUploadTimeline(_ context.Context, id string) (*validate.DiffSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	summary, ok := f.diffs[id]
+	if !ok {
+		return nil, fmt.Errorf("no diff summary seeded for upload %q", id)
+	}
+	return summary, nil
+}
+
+func (f *Fake) ExportUploads(_ context.Context, format string) ([]byte, error) {
+	f.mu.Lock()
+	fn := f.exportFn
+	f.mu.Unlock()
+
+	if fn == nil {
+		return nil, fmt.Errorf("fake.Fake: no ExportFunc set, call SetExportFunc")
+	}
+	return fn(format)
+}
+
+// UploadFileFromPath reads path, records a new pending upload with an
+// auto-assigned ID and the file's sha256 checksum, and returns it — mirroring
+// the server's own CreateUpload well enough for a consumer's test to assert
+// on the resulting upload without a real HTTP round trip.
+func (f *Fake) UploadFileFromPath(_ context.Context, path string, opts client.UploadOptions) (*models.Upload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	sum := sha256.Sum256(data)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strconv.Itoa(len(f.uploads) + 1)
+	upload := &models.Upload{
+		ID:       id,
+		Dataset:  opts.Dataset,
+		Filename: filepath.Base(path),
+		Tags:     opts.Tags,
+		Status:   models.StatusPending,
+		Checksum: hex.EncodeToString(sum[:]),
+		Size:     int64(len(data)),
+	}
+	f.uploads[id] = upload
+	return upload, nil
+}
+
+func hasAnyTag(got, wanted []string) bool {
+	for _, w := range wanted {
+		for _, g := range got {
+			if g == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/client"
+	"github.com/ONSdigital/dis-imf-uploader/client/fake"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+func TestFake_ListUploadsFiltersByDatasetAndStatus(t *testing.T) {
+	f := fake.New()
+	f.SetUploads(
+		models.Upload{ID: "1", Dataset: "weo", Status: models.StatusPending},
+		models.Upload{ID: "2", Dataset: "weo", Status: models.StatusApproved},
+		models.Upload{ID: "3", Dataset: "gfsr", Status: models.StatusPending},
+	)
+
+	uploads, err := f.ListUploads(context.Background(), client.ListUploadsOptions{Dataset: "weo", Status: "pending"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(uploads) != 1 || uploads[0].ID != "1" {
+		t.Fatalf("unexpected uploads: %+v", uploads)
+	}
+}
+
+func TestFake_TransitionHonorsStatusMachine(t *testing.T) {
+	f := fake.New()
+	f.SetUploads(models.Upload{ID: "1", Status: models.StatusPending})
+
+	if err := f.Transition("1", models.StatusApproved); err != nil {
+		t.Fatalf("expected pending -> approved to be allowed: %v", err)
+	}
+	if err := f.Transition("1", models.StatusPending); err == nil {
+		t.Fatal("expected approved -> pending to be rejected")
+	}
+}
+
+func TestFake_GetStats(t *testing.T) {
+	f := fake.New()
+	f.SetUploads(
+		models.Upload{ID: "1", Status: models.StatusPending},
+		models.Upload{ID: "2", Status: models.StatusApproved},
+	)
+
+	stats, err := f.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.TotalUploads != 2 || stats.Pending != 1 || stats.Approved != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,554 @@
+// Package component boots the API against in-memory fakes so that
+// features/*.feature scenarios can exercise the full upload/review/publish
+// flow without external dependencies such as MongoDB, S3 or Slack.
+package component
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Component wraps a running instance of the API backed by fakes, plus
+// captured side effects that step definitions can assert against.
+type Component struct {
+	Server           *httptest.Server
+	Store            *fakeStore
+	PublishedKeys    []string
+	InvalidatedPaths []string
+	Notifications    []string
+}
+
+// NewComponent starts an httptest server serving the API with fresh fakes.
+func NewComponent() *Component {
+	c := &Component{Store: newFakeStore()}
+
+	handler := api.NewHandler(c.Store,
+		api.WithS3(&fakeS3{c}),
+		api.WithCloudFront(&fakeCloudFront{c}),
+		api.WithCloudflare(&fakeCloudflare{c}),
+		api.WithNotifier(fakeNotifierFunc(func(_ context.Context, msg string) error {
+			c.Notifications = append(c.Notifications, msg)
+			return nil
+		})),
+	)
+
+	mux := http.NewServeMux()
+	handler.Routes(mux)
+	c.Server = httptest.NewServer(mux)
+
+	return c
+}
+
+// Close shuts down the underlying httptest server.
+func (c *Component) Close() {
+	c.Server.Close()
+}
+
+type fakeNotifierFunc func(ctx context.Context, message string) error
+
+func (f fakeNotifierFunc) Notify(ctx context.Context, message string) error { return f(ctx, message) }
+
+type fakeS3 struct{ c *Component }
+
+func (f *fakeS3) UploadFile(_ context.Context, key string, _ io.Reader, _, _, _ string) error {
+	f.c.PublishedKeys = append(f.c.PublishedKeys, key)
+	return nil
+}
+
+func (f *fakeS3) BackupFile(_ context.Context, _ string) error { return nil }
+
+func (f *fakeS3) DownloadFile(_ context.Context, _ string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(nil)), nil
+}
+
+type fakeCloudFront struct{ c *Component }
+
+func (f *fakeCloudFront) InvalidatePaths(_ context.Context, paths []string) (string, error) {
+	f.c.InvalidatedPaths = append(f.c.InvalidatedPaths, paths...)
+	return "INV-COMPONENT", nil
+}
+
+type fakeCloudflare struct{ c *Component }
+
+func (f *fakeCloudflare) PurgeCache(_ context.Context, _ []string) error { return nil }
+
+type fakeStore struct {
+	mu               sync.Mutex
+	uploads          map[string]*models.Upload
+	notifications    map[string]*models.Notification
+	invalidations    map[string]*models.Invalidation
+	releases         map[string]*models.Release
+	maintenance      *models.MaintenanceMode
+	featureFlags     map[string]*models.FeatureFlag
+	apiKeys          map[string]*models.APIKey
+	auditLog         map[string]*models.AuditLogEntry
+	rejectionReasons map[string]*models.RejectionReason
+	datasetProfiles  map[string]*models.DatasetProfile
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		uploads:       map[string]*models.Upload{},
+		notifications: map[string]*models.Notification{},
+		invalidations: map[string]*models.Invalidation{},
+		releases:      map[string]*models.Release{},
+		maintenance:   &models.MaintenanceMode{ID: models.MaintenanceModeID},
+		featureFlags:  map[string]*models.FeatureFlag{},
+		apiKeys:       map[string]*models.APIKey{},
+		auditLog:      map[string]*models.AuditLogEntry{},
+		rejectionReasons: map[string]*models.RejectionReason{
+			"data-quality": {Code: "data-quality", Label: "Data quality issue", Active: true},
+		},
+		datasetProfiles: map[string]*models.DatasetProfile{},
+	}
+}
+
+func (s *fakeStore) CreateUpload(_ context.Context, upload *models.Upload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if upload.ID == "" {
+		upload.ID = fmt.Sprintf("upload-%d", len(s.uploads)+1)
+	}
+	s.uploads[upload.ID] = upload
+	return nil
+}
+
+func (s *fakeStore) GetUpload(_ context.Context, id string) (*models.Upload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, ok := s.uploads[id]
+	if !ok {
+		return nil, fmt.Errorf("upload %q not found", id)
+	}
+	return upload, nil
+}
+
+func (s *fakeStore) ListUploads(_ context.Context, dataset string) ([]*models.Upload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var uploads []*models.Upload
+	for _, u := range s.uploads {
+		if dataset == "" || u.Dataset == dataset {
+			uploads = append(uploads, u)
+		}
+	}
+	return uploads, nil
+}
+
+func (s *fakeStore) ListUploadsFiltered(_ context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var uploads []*models.Upload
+	for _, u := range s.uploads {
+		if filter.Dataset != "" && u.Dataset != filter.Dataset {
+			continue
+		}
+		if filter.UploadedBy != "" && u.UploadedBy != filter.UploadedBy {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, u.Status) {
+			continue
+		}
+		if filter.TargetKeyPrefix != "" && !strings.HasPrefix(u.TargetKey, filter.TargetKeyPrefix) {
+			continue
+		}
+		if !filter.CreatedAfter.IsZero() && u.CreatedAt.Before(filter.CreatedAfter) {
+			continue
+		}
+		if !filter.CreatedBefore.IsZero() && !u.CreatedAt.Before(filter.CreatedBefore) {
+			continue
+		}
+		if len(filter.Tags) > 0 && !anyTagMatches(filter.Tags, u.Tags) {
+			continue
+		}
+		uploads = append(uploads, u)
+	}
+	sortUploads(uploads, filter.SortBy, filter.SortDescending)
+	return uploads, nil
+}
+
+// sortUploads orders uploads by sortBy ("created_at", "status" or
+// "uploaded_by"), matching the fields ListUploadsFiltered can sort by in
+// Mongo. Any other value, including empty, leaves the order untouched.
+func sortUploads(uploads []*models.Upload, sortBy string, descending bool) {
+	var less func(a, b *models.Upload) bool
+	switch sortBy {
+	case "created_at":
+		less = func(a, b *models.Upload) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "status":
+		less = func(a, b *models.Upload) bool { return a.Status < b.Status }
+	case "uploaded_by":
+		less = func(a, b *models.Upload) bool { return a.UploadedBy < b.UploadedBy }
+	default:
+		return
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if descending {
+			return less(uploads[j], uploads[i])
+		}
+		return less(uploads[i], uploads[j])
+	})
+}
+
+func containsStatus(statuses []models.Status, status models.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// anyTagMatches reports whether wanted and got share at least one tag.
+func anyTagMatches(wanted, got []string) bool {
+	for _, w := range wanted {
+		for _, g := range got {
+			if w == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *fakeStore) CountUploadsByStatus(_ context.Context) (map[models.Status]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := map[models.Status]int{}
+	for _, u := range s.uploads {
+		counts[u.Status]++
+	}
+	return counts, nil
+}
+
+func (s *fakeStore) UpdateUpload(_ context.Context, upload *models.Upload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.uploads[upload.ID]
+	if !ok {
+		return fmt.Errorf("upload %q not found", upload.ID)
+	}
+	if !models.CanTransition(current.Status, upload.Status) {
+		return fmt.Errorf("invalid upload status transition: %s -> %s", current.Status, upload.Status)
+	}
+	s.uploads[upload.ID] = upload
+	return nil
+}
+
+func (s *fakeStore) DeleteUpload(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploads, id)
+	return nil
+}
+
+func (s *fakeStore) CreateNotification(_ context.Context, notification *models.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if notification.ID == "" {
+		notification.ID = fmt.Sprintf("notification-%d", len(s.notifications)+1)
+	}
+	s.notifications[notification.ID] = notification
+	return nil
+}
+
+func (s *fakeStore) GetNotification(_ context.Context, id string) (*models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	notification, ok := s.notifications[id]
+	if !ok {
+		return nil, fmt.Errorf("notification %q not found", id)
+	}
+	return notification, nil
+}
+
+func (s *fakeStore) ListNotifications(_ context.Context, uploadID string) ([]*models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var notifications []*models.Notification
+	for _, n := range s.notifications {
+		if n.UploadID == uploadID {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications, nil
+}
+
+func (s *fakeStore) UpdateNotification(_ context.Context, notification *models.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.notifications[notification.ID]; !ok {
+		return fmt.Errorf("notification %q not found", notification.ID)
+	}
+	s.notifications[notification.ID] = notification
+	return nil
+}
+
+func (s *fakeStore) CreateInvalidation(_ context.Context, invalidation *models.Invalidation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if invalidation.ID == "" {
+		invalidation.ID = fmt.Sprintf("invalidation-%d", len(s.invalidations)+1)
+	}
+	s.invalidations[invalidation.ID] = invalidation
+	return nil
+}
+
+func (s *fakeStore) ListInvalidations(_ context.Context, dataset string) ([]*models.Invalidation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var invalidations []*models.Invalidation
+	for _, inv := range s.invalidations {
+		if dataset == "" || inv.Dataset == dataset {
+			invalidations = append(invalidations, inv)
+		}
+	}
+	return invalidations, nil
+}
+
+func (s *fakeStore) CreateRelease(_ context.Context, release *models.Release) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if release.ID == "" {
+		release.ID = fmt.Sprintf("release-%d", len(s.releases)+1)
+	}
+	s.releases[release.ID] = release
+	return nil
+}
+
+func (s *fakeStore) GetRelease(_ context.Context, id string) (*models.Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	release, ok := s.releases[id]
+	if !ok {
+		return nil, fmt.Errorf("release %q not found", id)
+	}
+	return release, nil
+}
+
+func (s *fakeStore) ListReleases(_ context.Context, dataset string) ([]*models.Release, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var releases []*models.Release
+	for _, rel := range s.releases {
+		if dataset == "" || rel.Dataset == dataset {
+			releases = append(releases, rel)
+		}
+	}
+	return releases, nil
+}
+
+func (s *fakeStore) UpdateRelease(_ context.Context, release *models.Release) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.releases[release.ID]; !ok {
+		return fmt.Errorf("release %q not found", release.ID)
+	}
+	s.releases[release.ID] = release
+	return nil
+}
+
+func (s *fakeStore) GetMaintenanceMode(_ context.Context) (*models.MaintenanceMode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mode := *s.maintenance
+	return &mode, nil
+}
+
+func (s *fakeStore) SetMaintenanceMode(_ context.Context, mode *models.MaintenanceMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mode.ID = models.MaintenanceModeID
+	s.maintenance = mode
+	return nil
+}
+
+func (s *fakeStore) GetFeatureFlag(_ context.Context, name string) (*models.FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if flag, ok := s.featureFlags[name]; ok {
+		return flag, nil
+	}
+	return &models.FeatureFlag{Name: name}, nil
+}
+
+func (s *fakeStore) ListFeatureFlags(_ context.Context) ([]*models.FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var flags []*models.FeatureFlag
+	for _, flag := range s.featureFlags {
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+func (s *fakeStore) SetFeatureFlag(_ context.Context, flag *models.FeatureFlag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.featureFlags[flag.Name] = flag
+	return nil
+}
+
+func (s *fakeStore) GetRejectionReason(_ context.Context, code string) (*models.RejectionReason, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reason, ok := s.rejectionReasons[code]
+	if !ok {
+		return nil, fmt.Errorf("rejection reason %q not found", code)
+	}
+	return reason, nil
+}
+
+func (s *fakeStore) ListRejectionReasons(_ context.Context) ([]*models.RejectionReason, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var reasons []*models.RejectionReason
+	for _, reason := range s.rejectionReasons {
+		reasons = append(reasons, reason)
+	}
+	return reasons, nil
+}
+
+func (s *fakeStore) SetRejectionReason(_ context.Context, reason *models.RejectionReason) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejectionReasons[reason.Code] = reason
+	return nil
+}
+
+func (s *fakeStore) DeleteRejectionReason(_ context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rejectionReasons, code)
+	return nil
+}
+
+func (s *fakeStore) GetDatasetProfile(_ context.Context, dataset string) (*models.DatasetProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profile, ok := s.datasetProfiles[dataset]
+	if !ok {
+		return nil, fmt.Errorf("dataset profile %q not found", dataset)
+	}
+	return profile, nil
+}
+
+func (s *fakeStore) ListDatasetProfiles(_ context.Context) ([]*models.DatasetProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var profiles []*models.DatasetProfile
+	for _, profile := range s.datasetProfiles {
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (s *fakeStore) SetDatasetProfile(_ context.Context, profile *models.DatasetProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.datasetProfiles[profile.Dataset] = profile
+	return nil
+}
+
+func (s *fakeStore) DeleteDatasetProfile(_ context.Context, dataset string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.datasetProfiles, dataset)
+	return nil
+}
+
+func (s *fakeStore) CreateAPIKey(_ context.Context, key *models.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key.ID == "" {
+		key.ID = fmt.Sprintf("api-key-%d", len(s.apiKeys)+1)
+	}
+	s.apiKeys[key.ID] = key
+	return nil
+}
+
+func (s *fakeStore) GetAPIKey(_ context.Context, id string) (*models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.apiKeys[id]
+	if !ok {
+		return nil, fmt.Errorf("api key %q not found", id)
+	}
+	return key, nil
+}
+
+func (s *fakeStore) ListAPIKeys(_ context.Context) ([]*models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []*models.APIKey
+	for _, key := range s.apiKeys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *fakeStore) UpdateAPIKey(_ context.Context, key *models.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.apiKeys[key.ID]; !ok {
+		return fmt.Errorf("api key %q not found", key.ID)
+	}
+	s.apiKeys[key.ID] = key
+	return nil
+}
+
+func (s *fakeStore) CreateAuditLogEntry(_ context.Context, entry *models.AuditLogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry.ID == "" {
+		entry.ID = fmt.Sprintf("audit-log-%d", len(s.auditLog)+1)
+	}
+	s.auditLog[entry.ID] = entry
+	return nil
+}
+
+func (s *fakeStore) ListAuditLogEntries(_ context.Context) ([]*models.AuditLogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []*models.AuditLogEntry
+	for _, entry := range s.auditLog {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *fakeStore) ListAuditLogEntriesOlderThan(_ context.Context, cutoff time.Time) ([]*models.AuditLogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []*models.AuditLogEntry
+	for _, entry := range s.auditLog {
+		if entry.RecordedAt.Before(cutoff) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (s *fakeStore) DeleteAuditLogEntriesOlderThan(_ context.Context, cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, entry := range s.auditLog {
+		if entry.RecordedAt.Before(cutoff) {
+			delete(s.auditLog, id)
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) Checker(_ context.Context) error { return nil }
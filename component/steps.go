@@ -0,0 +1,100 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cucumber/godog"
+)
+
+// FeatureContext registers the step definitions used by features/*.feature
+// against a fresh Component per scenario.
+func FeatureContext(sctx *godog.ScenarioContext) {
+	var c *Component
+	var lastStatus int
+	var lastBody []byte
+	var uploadID string
+
+	sctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
+		c = NewComponent()
+		return ctx, nil
+	})
+
+	sctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+		c.Close()
+		return ctx, err
+	})
+
+	sctx.Step(`^I upload a file "([^"]*)" for dataset "([^"]*)"$`, func(filename, dataset string) error {
+		body, _ := json.Marshal(map[string]string{"filename": filename, "dataset": dataset})
+		resp, err := http.Post(c.Server.URL+"/uploads", "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		lastStatus = resp.StatusCode
+		lastBody, _ = io.ReadAll(resp.Body)
+
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(lastBody, &created); err == nil {
+			uploadID = created.ID
+		}
+		return nil
+	})
+
+	sctx.Step(`^a reviewer approves the upload$`, func() error {
+		resp, err := http.Post(fmt.Sprintf("%s/uploads/%s/approve", c.Server.URL, uploadID), "application/json", nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		lastStatus = resp.StatusCode
+		return nil
+	})
+
+	sctx.Step(`^a reviewer rejects the upload$`, func() error {
+		resp, err := http.Post(fmt.Sprintf("%s/uploads/%s/reject", c.Server.URL, uploadID), "application/json", bytes.NewReader([]byte(`{"reason_code":"data-quality"}`)))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		lastStatus = resp.StatusCode
+		return nil
+	})
+
+	sctx.Step(`^the response status should be (\d+)$`, func(status int) error {
+		if lastStatus != status {
+			return fmt.Errorf("expected status %d, got %d: %s", status, lastStatus, lastBody)
+		}
+		return nil
+	})
+
+	sctx.Step(`^the file should be published to the target location$`, func() error {
+		if len(c.PublishedKeys) == 0 {
+			return fmt.Errorf("expected a file to have been published, none were")
+		}
+		return nil
+	})
+
+	sctx.Step(`^the cache should be invalidated$`, func() error {
+		if len(c.InvalidatedPaths) == 0 {
+			return fmt.Errorf("expected an invalidation to have been triggered, none were")
+		}
+		return nil
+	})
+
+	sctx.Step(`^a rejection notification should be sent$`, func() error {
+		for _, n := range c.Notifications {
+			if n != "" {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected a notification to have been sent")
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/ONSdigital/dis-imf-uploader/component"
+)
+
+// TestFeatures runs the godog component test suite against features/*.feature.
+func TestFeatures(t *testing.T) {
+	suite := godog.TestSuite{
+		Name:                "component",
+		ScenarioInitializer: component.FeatureContext,
+		Options: &godog.Options{
+			Format: "pretty",
+			Paths:  []string{"features"},
+		},
+	}
+
+	if suite.Run() != 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,190 @@
+// Package concurrency bounds how many S3 upload, CloudFront invalidation or
+// Cloudflare purge calls run at once, queueing callers past the limit
+// instead of firing every call from a batch approval at the downstream
+// simultaneously, and records how long callers spent waiting for a slot.
+package concurrency
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats summarises a Limiter's activity so operators can tell a downstream
+// that's merely busy apart from one that's queueing every call.
+type Stats struct {
+	// Limit is the number of concurrent calls the Limiter allows through.
+	Limit int `json:"limit"`
+	// InFlight is the number of calls currently holding a slot.
+	InFlight int `json:"in_flight"`
+	// Waiting is the number of calls currently queued for a slot.
+	Waiting int `json:"waiting"`
+	// TotalWait is the cumulative time every call that acquired a slot
+	// spent queued for one, including calls that acquired immediately.
+	TotalWait time.Duration `json:"total_wait"`
+}
+
+// Limiter is a semaphore bounding the number of concurrent operations,
+// tracking how many callers are queued and how long acquiring a slot took.
+// The zero value is not usable; construct with NewLimiter.
+type Limiter struct {
+	limit int
+	sem   chan struct{}
+
+	mu        sync.Mutex
+	waiting   int
+	totalWait time.Duration
+
+	inFlight int64
+}
+
+// NewLimiter constructs a Limiter allowing up to limit concurrent
+// operations. limit <= 0 means unlimited: Acquire always succeeds
+// immediately and Release is a no-op.
+func NewLimiter(limit int) *Limiter {
+	l := &Limiter{limit: limit}
+	if limit > 0 {
+		l.sem = make(chan struct{}, limit)
+	}
+	return l
+}
+
+// Acquire blocks until a slot is free or ctx is cancelled, whichever comes
+// first, and returns a func that releases the slot. Callers must call the
+// returned func exactly once (typically via defer) when Acquire succeeds.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	l.mu.Lock()
+	l.waiting++
+	l.mu.Unlock()
+
+	select {
+	case l.sem <- struct{}{}:
+		l.mu.Lock()
+		l.waiting--
+		l.totalWait += time.Since(start)
+		l.mu.Unlock()
+		atomic.AddInt64(&l.inFlight, 1)
+		return func() {
+			atomic.AddInt64(&l.inFlight, -1)
+			<-l.sem
+		}, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.waiting--
+		l.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the Limiter's activity so far.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return Stats{
+		Limit:     l.limit,
+		InFlight:  int(atomic.LoadInt64(&l.inFlight)),
+		Waiting:   l.waiting,
+		TotalWait: l.totalWait,
+	}
+}
+
+// Uploader puts a file's contents into permanent storage. Duplicated from
+// api.Uploader to avoid an import cycle.
+type Uploader interface {
+	UploadFile(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error
+}
+
+// LimitedUploader wraps an Uploader so no more than Limiter's configured
+// limit of uploads run at once. It implements Uploader itself, so it can be
+// used anywhere an S3Client is expected.
+type LimitedUploader struct {
+	Uploader Uploader
+	Limiter  *Limiter
+}
+
+// NewLimitedUploader wraps uploader with a Limiter allowing up to limit
+// concurrent uploads.
+func NewLimitedUploader(uploader Uploader, limit int) *LimitedUploader {
+	return &LimitedUploader{Uploader: uploader, Limiter: NewLimiter(limit)}
+}
+
+// UploadFile acquires a slot from Limiter, blocking if the limit is
+// already reached, then delegates to Uploader.
+func (u *LimitedUploader) UploadFile(ctx context.Context, key string, body io.Reader, contentType, contentDisposition, cacheControl string) error {
+	release, err := u.Limiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return u.Uploader.UploadFile(ctx, key, body, contentType, contentDisposition, cacheControl)
+}
+
+// Invalidator triggers cache invalidation for the given paths and returns
+// an invalidation ID. Duplicated from api.Invalidator to avoid an import
+// cycle.
+type Invalidator interface {
+	InvalidatePaths(ctx context.Context, paths []string) (string, error)
+}
+
+// LimitedInvalidator wraps an Invalidator so no more than Limiter's
+// configured limit of invalidations run at once. It implements Invalidator
+// itself, so it can be used anywhere a CloudFrontClient is expected.
+type LimitedInvalidator struct {
+	Invalidator Invalidator
+	Limiter     *Limiter
+}
+
+// NewLimitedInvalidator wraps invalidator with a Limiter allowing up to
+// limit concurrent invalidations.
+func NewLimitedInvalidator(invalidator Invalidator, limit int) *LimitedInvalidator {
+	return &LimitedInvalidator{Invalidator: invalidator, Limiter: NewLimiter(limit)}
+}
+
+// InvalidatePaths acquires a slot from Limiter, blocking if the limit is
+// already reached, then delegates to Invalidator.
+func (i *LimitedInvalidator) InvalidatePaths(ctx context.Context, paths []string) (string, error) {
+	release, err := i.Limiter.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return i.Invalidator.InvalidatePaths(ctx, paths)
+}
+
+// Purger triggers a cache purge for the given URLs. Duplicated from
+// api.Purger to avoid an import cycle.
+type Purger interface {
+	PurgeCache(ctx context.Context, urls []string) error
+}
+
+// LimitedPurger wraps a Purger so no more than Limiter's configured limit
+// of purges run at once. It implements Purger itself, so it can be used
+// anywhere a CloudflareClient is expected.
+type LimitedPurger struct {
+	Purger  Purger
+	Limiter *Limiter
+}
+
+// NewLimitedPurger wraps purger with a Limiter allowing up to limit
+// concurrent purges.
+func NewLimitedPurger(purger Purger, limit int) *LimitedPurger {
+	return &LimitedPurger{Purger: purger, Limiter: NewLimiter(limit)}
+}
+
+// PurgeCache acquires a slot from Limiter, blocking if the limit is already
+// reached, then delegates to Purger.
+func (p *LimitedPurger) PurgeCache(ctx context.Context, urls []string) error {
+	release, err := p.Limiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.Purger.PurgeCache(ctx, urls)
+}
@@ -0,0 +1,121 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/concurrency"
+)
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for condition")
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	t.Run("blocks a third acquirer until one of two in-flight releases", func(t *testing.T) {
+		l := concurrency.NewLimiter(2)
+
+		release1, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		release2, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		acquired := make(chan struct{})
+		go func() {
+			release3, err := l.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			release3()
+			close(acquired)
+		}()
+
+		waitFor(t, func() bool { return l.Stats().Waiting == 1 })
+		release1()
+
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("expected the third acquirer to proceed once a slot freed up")
+		}
+		release2()
+	})
+
+	t.Run("returns the caller's context error if cancelled while queued", func(t *testing.T) {
+		l := concurrency.NewLimiter(1)
+		release, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := l.Acquire(ctx); err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("a limit of zero disables queueing entirely", func(t *testing.T) {
+		l := concurrency.NewLimiter(0)
+		for i := 0; i < 5; i++ {
+			release, err := l.Acquire(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			release()
+		}
+		if stats := l.Stats(); stats.Limit != 0 {
+			t.Fatalf("expected an unlimited limiter to report limit 0, got %+v", stats)
+		}
+	})
+}
+
+type fakeUploader struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (u *fakeUploader) UploadFile(_ context.Context, _ string, body io.Reader, _, _, _ string) error {
+	u.mu.Lock()
+	u.calls++
+	u.mu.Unlock()
+	_, err := io.ReadAll(body)
+	return err
+}
+
+func TestLimitedUploader_UploadFile(t *testing.T) {
+	uploader := &fakeUploader{}
+	limited := concurrency.NewLimitedUploader(uploader, 1)
+
+	if err := limited.UploadFile(context.Background(), "key", errReader{}, "text/csv", "", ""); err == nil {
+		t.Fatalf("expected the failing reader's error to propagate")
+	}
+	uploader.mu.Lock()
+	calls := uploader.calls
+	uploader.mu.Unlock()
+	if calls != 1 {
+		t.Fatalf("expected the wrapped uploader to be called once, got %d", calls)
+	}
+}
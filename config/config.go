@@ -0,0 +1,590 @@
+package config
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config represents the app configuration options for the uploader service.
+type Config struct {
+	BindAddr                   string        `envconfig:"BIND_ADDR"`
+	GracefulShutdownTimeout    time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckInterval        time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
+	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	// DashboardBaseURL is the base URL of the review dashboard. It is passed
+	// to every notifier so links back to a given upload can be included in
+	// notification messages.
+	DashboardBaseURL string `envconfig:"DASHBOARD_BASE_URL"`
+	// Environment names the deployment this instance is running in, e.g.
+	// "staging" or "production", so a Slack message or API response can be
+	// told apart from the same alert firing in another environment.
+	Environment string `envconfig:"ENVIRONMENT"`
+	MongoConfig
+	TempStorageConfig
+	ZipBundleConfig
+	PDFConfig
+	XLSXConfig
+	ValidationConfig
+	ApprovalConfig
+	ServiceAuthConfig
+	CORSConfig
+	SecurityConfig
+	AuditConfig
+	TLSConfig
+	SlackConfig
+	AlertConfig
+	NotifierConfig
+	CDNConfig
+	ReconcileConfig
+	OrphanScanConfig
+	PublishWindowConfig
+	ArchiveConfig
+	AuditLogConfig
+	ConcurrencyConfig
+	ScanCacheConfig
+	SLAConfig
+	QuotaConfig
+	GreylistConfig
+	PublishConfig
+	DigestConfig
+	SchedulerConfig
+}
+
+// TempStorageConfig configures the Redis-backed temp storage of files
+// pending review.
+type TempStorageConfig struct {
+	// Backend selects the temp storage implementation: "redis", "s3",
+	// "disk" or "memory".
+	Backend               string        `envconfig:"TEMP_STORAGE_BACKEND"`
+	RedisAddr             string        `envconfig:"REDIS_ADDR"`
+	RedisPassword         string        `envconfig:"REDIS_PASSWORD"                    json:"-"`
+	TempStorageTTL        time.Duration `envconfig:"TEMP_STORAGE_TTL"`
+	TempStorageMaxTotal   int64         `envconfig:"TEMP_STORAGE_MAX_TOTAL_BYTES"`
+	TempStorageMaxPerFile int64         `envconfig:"TEMP_STORAGE_MAX_FILE_BYTES"`
+	TempStorageS3Bucket   string        `envconfig:"TEMP_STORAGE_S3_BUCKET"`
+	TempStorageS3Prefix   string        `envconfig:"TEMP_STORAGE_S3_PREFIX"`
+	TempStorageDiskDir    string        `envconfig:"TEMP_STORAGE_DISK_DIR"`
+	EncryptionEnabled     bool          `envconfig:"TEMP_STORAGE_ENCRYPTION_ENABLED"`
+	// EncryptionCurrentKeyID selects which key in EncryptionKeys new objects
+	// are encrypted with; older objects remain decryptable via their
+	// recorded key ID as long as that key stays present in EncryptionKeys.
+	EncryptionCurrentKeyID string            `envconfig:"TEMP_STORAGE_ENCRYPTION_CURRENT_KEY_ID"`
+	EncryptionKeys         map[string]string `envconfig:"TEMP_STORAGE_ENCRYPTION_KEYS"    json:"-"` // keyID -> hex-encoded 32-byte key
+	MemoryJanitorInterval  time.Duration     `envconfig:"TEMP_STORAGE_MEMORY_JANITOR_INTERVAL"`
+}
+
+// ZipBundleConfig configures the rules enforced on zip archives uploaded
+// with explode=true.
+type ZipBundleConfig struct {
+	ZipAllowedExtensions []string `envconfig:"ZIP_BUNDLE_ALLOWED_EXTENSIONS"`
+	ZipMaxEntrySizeBytes int64    `envconfig:"ZIP_BUNDLE_MAX_ENTRY_SIZE_BYTES"`
+	ZipMaxEntries        int      `envconfig:"ZIP_BUNDLE_MAX_ENTRIES"`
+}
+
+// PDFConfig configures how PDF uploads are screened for active content.
+type PDFConfig struct {
+	// PDFActiveContentAction is "reject" or "sanitize".
+	PDFActiveContentAction string `envconfig:"PDF_ACTIVE_CONTENT_ACTION"`
+}
+
+// XLSXConfig configures how XLSX/XLSM uploads are screened for macros,
+// external links and DDE formulas.
+type XLSXConfig struct {
+	// XLSXActiveContentAction is "reject" or "flag".
+	XLSXActiveContentAction string `envconfig:"XLSX_ACTIVE_CONTENT_ACTION"`
+}
+
+// ValidationConfig configures the naming convention an upload's filename
+// must follow, per dataset, e.g. "weo" -> `^imf_weo_\d{6}\.xlsx$` for
+// imf_weo_YYYYMM.xlsx. A dataset with no configured pattern is not
+// restricted, and a filename matching exactly one configured pattern has
+// its dataset recorded automatically when the caller didn't supply one.
+type ValidationConfig struct {
+	FilenamePatterns map[string]string `envconfig:"VALIDATION_FILENAME_PATTERNS" json:"-"` // dataset -> regex
+}
+
+// ApprovalConfig configures the checklist a reviewer must acknowledge
+// before an upload can be approved.
+type ApprovalConfig struct {
+	ApprovalChecklist []string `envconfig:"APPROVAL_CHECKLIST"`
+	// ApprovalPreventSelfApproval blocks Approve when the reviewer email
+	// matches the uploader email. Disable for small teams where the same
+	// person often uploads and reviews.
+	ApprovalPreventSelfApproval bool `envconfig:"APPROVAL_PREVENT_SELF_APPROVAL"`
+}
+
+// CORSConfig configures the CORS headers served on API responses so a
+// dashboard running on a different origin can call the API from the
+// browser. Empty AllowedOrigins disables CORS entirely.
+type CORSConfig struct {
+	CORSAllowedOrigins []string      `envconfig:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods []string      `envconfig:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders []string      `envconfig:"CORS_ALLOWED_HEADERS"`
+	CORSMaxAge         time.Duration `envconfig:"CORS_MAX_AGE"`
+}
+
+// SecurityConfig configures the headers and request checks applied by
+// api.Handler.SecurityHeaders before any request reaches a handler.
+type SecurityConfig struct {
+	SecurityAllowedMethods []string      `envconfig:"SECURITY_ALLOWED_METHODS"`
+	SecurityMaxHeaderBytes int           `envconfig:"SECURITY_MAX_HEADER_BYTES"`
+	SecurityHSTSMaxAge     time.Duration `envconfig:"SECURITY_HSTS_MAX_AGE"`
+}
+
+// AuditConfig configures which requests api.Handler.AuditLog records into
+// the audit log.
+type AuditConfig struct {
+	// AuditPathPrefixes lists the request path prefixes AuditLog records
+	// mutating calls under, e.g. "/api/v1/admin". Empty disables auditing
+	// entirely.
+	AuditPathPrefixes []string `envconfig:"AUDIT_PATH_PREFIXES"`
+}
+
+// TLSConfig configures TLS termination for the HTTP server, for deployments
+// without a fronting proxy. Empty TLSCertFile/TLSKeyFile disable TLS
+// entirely.
+type TLSConfig struct {
+	TLSCertFile string `envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile  string `envconfig:"TLS_KEY_FILE"`
+	// TLSMinVersion is the minimum TLS version to accept, e.g. "1.2" or
+	// "1.3".
+	TLSMinVersion string `envconfig:"TLS_MIN_VERSION"`
+	// TLSClientCAFile, if set, is a PEM bundle of CAs trusted to sign
+	// client certificates. TLSRequireClientCert additionally rejects
+	// connections that don't present one.
+	TLSClientCAFile      string `envconfig:"TLS_CLIENT_CA_FILE"`
+	TLSRequireClientCert bool   `envconfig:"TLS_REQUIRE_CLIENT_CERT"`
+	// TLSReloadInterval, if non-zero, re-reads the certificate and key
+	// from disk at that interval so a renewed certificate is picked up
+	// without restarting the process.
+	TLSReloadInterval time.Duration `envconfig:"TLS_RELOAD_INTERVAL"`
+}
+
+// ArchiveConfig configures the background job that moves approved and
+// rejected uploads older than ArchiveRetention out of the hot collection
+// into S3, keeping list queries fast as the collection grows over time.
+type ArchiveConfig struct {
+	// ArchiveRetention is how long an approved or rejected upload is kept
+	// in the hot collection after its last status change, measured from
+	// UpdatedAt. Zero disables the background job entirely.
+	ArchiveRetention time.Duration `envconfig:"ARCHIVE_RETENTION"`
+	// ArchiveInterval is how often the archival job sweeps for uploads past
+	// ArchiveRetention.
+	ArchiveInterval time.Duration `envconfig:"ARCHIVE_INTERVAL"`
+	// ArchivePrefix is the S3 key prefix each archived upload is written
+	// under, one NDJSON object per upload, keyed by upload ID.
+	ArchivePrefix string `envconfig:"ARCHIVE_PREFIX"`
+}
+
+// AuditLogConfig configures the background job that exports audit log
+// entries older than AuditLogRetention to S3 before deleting them, keeping
+// the hot collection small while preserving them for compliance.
+type AuditLogConfig struct {
+	// AuditLogRetention is how long an audit log entry is kept in the hot
+	// collection after it was recorded. Zero disables the background job
+	// entirely.
+	AuditLogRetention time.Duration `envconfig:"AUDIT_LOG_RETENTION"`
+	// AuditLogExportInterval is how often the export job sweeps for entries
+	// past AuditLogRetention.
+	AuditLogExportInterval time.Duration `envconfig:"AUDIT_LOG_EXPORT_INTERVAL"`
+	// AuditLogExportPrefix is the S3 key prefix each export batch is written
+	// under, one NDJSON object per run.
+	AuditLogExportPrefix string `envconfig:"AUDIT_LOG_EXPORT_PREFIX"`
+}
+
+// ServiceAuthConfig configures upload access for automated pipelines that
+// authenticate with a shared service token instead of a human JWT.
+type ServiceAuthConfig struct {
+	// ServiceAuthToken is the shared secret an automated pipeline presents
+	// in the Service-Auth-Token header to upload without a human JWT.
+	// Empty disables service-principal auth entirely.
+	ServiceAuthToken string `envconfig:"SERVICE_AUTH_TOKEN" json:"-"`
+	// ServiceAuthPrincipal is the name recorded as UploadedBy on uploads
+	// authenticated via ServiceAuthToken, e.g. "pipeline:weo-loader".
+	ServiceAuthPrincipal string `envconfig:"SERVICE_AUTH_PRINCIPAL"`
+}
+
+// SlackConfig configures Block Kit approval notifications and the
+// interactivity/slash command callbacks Slack sends back.
+type SlackConfig struct {
+	SlackWebhookURL    string            `envconfig:"SLACK_WEBHOOK_URL"`
+	SlackSigningSecret string            `envconfig:"SLACK_SIGNING_SECRET" json:"-"`
+	SlackUserMap       map[string]string `envconfig:"SLACK_USER_MAP"       json:"-"` // Slack user ID -> service username
+	// SlackApprovalTemplate and SlackNotifyTemplate override the built-in Go
+	// templates used to render Slack message text. Empty keeps the default.
+	SlackApprovalTemplate string `envconfig:"SLACK_APPROVAL_TEMPLATE"`
+	SlackNotifyTemplate   string `envconfig:"SLACK_NOTIFY_TEMPLATE"`
+	// SlackDigestInterval batches lifecycle notifications into a single
+	// summary message posted at this interval instead of one per event.
+	// Zero disables batching.
+	SlackDigestInterval time.Duration `envconfig:"SLACK_DIGEST_INTERVAL"`
+	// SlackQuietHoursStart/End are "HH:MM" (24-hour, SlackQuietHoursTimezone)
+	// bounds of a daily window during which only error notifications are
+	// sent immediately; everything else is held for the next digest flush.
+	// Leaving either empty disables quiet hours.
+	SlackQuietHoursStart    string `envconfig:"SLACK_QUIET_HOURS_START"`
+	SlackQuietHoursEnd      string `envconfig:"SLACK_QUIET_HOURS_END"`
+	SlackQuietHoursTimezone string `envconfig:"SLACK_QUIET_HOURS_TIMEZONE"`
+	// SlackChannelRoutes and SlackMentionGroups route notifications by
+	// dataset prefix to the owning team's channel: SlackChannelRoutes maps
+	// a prefix to the webhook URL for that team's channel, and
+	// SlackMentionGroups optionally maps the same prefix to a Slack
+	// mention (e.g. a user group) prepended to routed messages. A dataset
+	// matching no prefix falls back to SlackWebhookURL.
+	SlackChannelRoutes map[string]string `envconfig:"SLACK_CHANNEL_ROUTES" json:"-"`
+	SlackMentionGroups map[string]string `envconfig:"SLACK_MENTION_GROUPS"`
+}
+
+// NotifierConfig configures the shared HTTP client and async delivery
+// queue reused by every outbound notifier (Slack, PagerDuty, Opsgenie, and
+// any future webhook/Teams integration).
+type NotifierConfig struct {
+	// NotifierHTTPTimeout bounds a single notifier HTTP request so a hung
+	// webhook can't stall the handler that triggered it.
+	NotifierHTTPTimeout time.Duration `envconfig:"NOTIFIER_HTTP_TIMEOUT"`
+	// NotifierProxyURL routes notifier requests through an HTTP(S) proxy.
+	// Empty falls back to the environment proxy settings.
+	NotifierProxyURL string `envconfig:"NOTIFIER_PROXY_URL"`
+	// NotifierQueueSize bounds the number of pending sends buffered by
+	// notify.Async before further sends are dropped.
+	NotifierQueueSize int `envconfig:"NOTIFIER_QUEUE_SIZE"`
+	// NotifierWorkers is the number of background workers notify.Async
+	// runs to deliver queued sends concurrently.
+	NotifierWorkers int `envconfig:"NOTIFIER_WORKERS"`
+}
+
+// ConcurrencyConfig bounds how many S3 upload, CloudFront invalidation and
+// Cloudflare purge calls run at once, so a batch approval queues excess
+// calls behind concurrency.Limiter instead of firing all of them at a
+// downstream simultaneously. Each limit is independent: a value of 0
+// disables queueing for that operation.
+type ConcurrencyConfig struct {
+	MaxConcurrentS3Uploads        int `envconfig:"MAX_CONCURRENT_S3_UPLOADS"`
+	MaxConcurrentInvalidations    int `envconfig:"MAX_CONCURRENT_INVALIDATIONS"`
+	MaxConcurrentCloudflarePurges int `envconfig:"MAX_CONCURRENT_CLOUDFLARE_PURGES"`
+}
+
+// ScanCacheConfig configures scan.CachingScanner, the checksum-keyed cache
+// of antivirus scan verdicts sitting in front of whichever Scanner is
+// eventually wired in, so identical file content isn't rescanned within
+// ScanCacheTTL.
+type ScanCacheConfig struct {
+	ScanCacheTTL time.Duration `envconfig:"SCAN_CACHE_TTL"`
+}
+
+// SLAConfig sets the review and publish turnaround targets GetSLAReport
+// measures uploads against when computing the percentage handled within
+// target.
+type SLAConfig struct {
+	SLAReviewTarget  time.Duration `envconfig:"SLA_REVIEW_TARGET"`
+	SLAPublishTarget time.Duration `envconfig:"SLA_PUBLISH_TARGET"`
+}
+
+// QuotaConfig bounds how many uploads and bytes a single UploadedBy identity
+// may submit via CreateUpload within a rolling UTC day, protecting the
+// review queue from runaway automation. Zero disables the corresponding
+// limit.
+type QuotaConfig struct {
+	MaxUploadsPerDay int   `envconfig:"MAX_UPLOADS_PER_DAY"`
+	MaxBytesPerDay   int64 `envconfig:"MAX_BYTES_PER_DAY"`
+}
+
+// GreylistConfig bounds how many upload validation failures a single
+// UploadedBy identity may have within GreylistWindow before further
+// uploads from them are blocked until an admin clears them. Zero
+// GreylistThreshold disables greylisting entirely.
+type GreylistConfig struct {
+	GreylistThreshold int           `envconfig:"GREYLIST_THRESHOLD"`
+	GreylistWindow    time.Duration `envconfig:"GREYLIST_WINDOW"`
+}
+
+// CDNConfig configures batching of CloudFront invalidation requests.
+type CDNConfig struct {
+	// CDNInvalidationBatchWindow coalesces invalidations raised by
+	// approvals completed within this window into a single
+	// CreateInvalidation call. Zero disables batching: every approval
+	// invalidates immediately on its own.
+	CDNInvalidationBatchWindow time.Duration `envconfig:"CDN_INVALIDATION_BATCH_WINDOW"`
+	// CDNDatasetPrefixPattern is a fmt.Sprintf pattern, keyed by dataset,
+	// used to build the wildcard path invalidated when a whole dataset is
+	// invalidated at once (e.g. "/imf/%s/*" produces "/imf/weo/*" for the
+	// "weo" dataset) instead of one invalidation per published file.
+	CDNDatasetPrefixPattern string `envconfig:"CDN_DATASET_PREFIX_PATTERN"`
+	// PublicURLTemplates are fmt.Sprintf patterns, each keyed by the
+	// published TargetKey, used to build the public URL(s) returned in the
+	// Approve response, e.g. "https://cdn.example.com/%s" produces
+	// "https://cdn.example.com/imf/weo/2024.csv". Multiple templates support
+	// a file being reachable through more than one host, e.g. a CDN and a
+	// direct S3 website endpoint.
+	PublicURLTemplates []string `envconfig:"PUBLIC_URL_TEMPLATES"`
+}
+
+// PublishConfig configures how published S3 objects are served.
+type PublishConfig struct {
+	// ContentDispositionTemplate is an fmt.Sprintf pattern keyed by the
+	// published file's base name, used to set the Content-Disposition
+	// header on published objects, e.g. "attachment; filename=%q" prompts
+	// a browser to download the file rather than render it inline. Empty
+	// leaves Content-Disposition unset.
+	ContentDispositionTemplate string `envconfig:"CONTENT_DISPOSITION_TEMPLATE"`
+
+	// CacheControlByExtension maps a lowercased file extension (including
+	// the leading dot) to the Cache-Control header published objects with
+	// that extension are served with, e.g. ".pdf": "public, max-age=604800"
+	// for a file that rarely changes vs ".csv": "no-cache" for one that's
+	// revised often. An extension with no entry is published with no
+	// Cache-Control header, reducing reliance on invalidations for the
+	// files that need it least. DatasetProfile.CacheControl overrides this
+	// per dataset.
+	CacheControlByExtension map[string]string `envconfig:"CACHE_CONTROL_BY_EXTENSION" json:"-"`
+}
+
+// DigestConfig configures the daily backlog summary posted to Slack/webhook
+// via RunDigest. RunDigest has no scheduling of its own; DigestCron and
+// DigestTimezone describe the schedule an external trigger (e.g. a
+// Kubernetes CronJob) should be configured with, following the same
+// externally-triggered pattern as PublishWindowConfig's scheduled-publish
+// endpoint.
+type DigestConfig struct {
+	// DigestCron is a standard 5-field cron expression, e.g. "0 8 * * *"
+	// for every morning at 08:00, evaluated in DigestTimezone by whatever
+	// external scheduler triggers RunDigest.
+	DigestCron string `envconfig:"DIGEST_CRON"`
+	// DigestTimezone is an IANA timezone name (e.g. "Europe/London") both
+	// DigestCron and RunDigest's "yesterday" window are evaluated in.
+	// Empty means UTC.
+	DigestTimezone string `envconfig:"DIGEST_TIMEZONE"`
+}
+
+// SchedulerConfig configures scheduler.Scheduler, the shared cron trigger
+// background jobs (digest, reconciliation, cleanup, reminders) run under
+// instead of each rolling its own fixed-interval ticker.
+type SchedulerConfig struct {
+	// JobCron maps a job name (e.g. "digest", "reconcile") to the 5-field
+	// cron expression it runs on. A job with no entry, or an empty
+	// expression, is disabled.
+	JobCron map[string]string `envconfig:"SCHEDULER_JOB_CRON" json:"-"`
+	// JobJitterSeconds bounds the random per-run delay scheduler.Job.Jitter
+	// applies, so replicas sharing a schedule don't all call downstream
+	// dependencies in the same instant. Zero disables jitter.
+	JobJitterSeconds int `envconfig:"SCHEDULER_JOB_JITTER_SECONDS"`
+	// LeaderElectionBackend selects which of scheduler.RedisElector ("redis")
+	// or scheduler.MongoElector ("mongo") backs leader election. Empty
+	// disables leader election, so every replica treats itself as the
+	// leader — the correct setting for a single-replica deployment.
+	LeaderElectionBackend string `envconfig:"SCHEDULER_LEADER_ELECTION_BACKEND"`
+	// LeaderElectionKey is the Redis key or MongoDB lease document ID the
+	// configured Elector contends for.
+	LeaderElectionKey string `envconfig:"SCHEDULER_LEADER_ELECTION_KEY"`
+	// LeaderLeaseSeconds is how long a won leader-election lease is held
+	// before it must be renewed.
+	LeaderLeaseSeconds int `envconfig:"SCHEDULER_LEADER_LEASE_SECONDS"`
+}
+
+// ReconcileConfig configures the background job that verifies approved
+// uploads still exist in S3 with matching checksums.
+type ReconcileConfig struct {
+	// ReconcileInterval is how often the reconciler sweeps approved and
+	// published uploads. Zero disables the background job entirely.
+	ReconcileInterval time.Duration `envconfig:"RECONCILE_INTERVAL"`
+}
+
+// OrphanScanConfig configures the orphaned-object report that finds S3
+// objects with no corresponding approved or published upload.
+type OrphanScanConfig struct {
+	// OrphanScanPrefix is the S3 prefix scanned for orphans. Empty disables
+	// the endpoint, since scanning the whole bucket is rarely intended.
+	OrphanScanPrefix string `envconfig:"ORPHAN_SCAN_PREFIX"`
+}
+
+// PublishWindowConfig configures the daily window during which an approval
+// is published immediately rather than queued, e.g. so an IMF dataset only
+// goes live during the hours ONS release calendar rules permit. Leaving
+// either bound empty disables the restriction.
+type PublishWindowConfig struct {
+	// PublishWindowStart and PublishWindowEnd are "HH:MM" (24-hour,
+	// PublishWindowTimezone) bounds of the daily publish window. A window
+	// where End is earlier than Start wraps past midnight.
+	PublishWindowStart    string `envconfig:"PUBLISH_WINDOW_START"`
+	PublishWindowEnd      string `envconfig:"PUBLISH_WINDOW_END"`
+	PublishWindowTimezone string `envconfig:"PUBLISH_WINDOW_TIMEZONE"`
+}
+
+// AlertConfig configures paging on-call about critical failures via
+// PagerDuty or Opsgenie. Leaving both routing keys empty disables paging;
+// Slack notifications are unaffected.
+type AlertConfig struct {
+	PagerDutyRoutingKey string `envconfig:"PAGERDUTY_ROUTING_KEY" json:"-"`
+	OpsgenieAPIKey      string `envconfig:"OPSGENIE_API_KEY"      json:"-"`
+}
+
+// MongoConfig contains the config required to connect to MongoDB.
+type MongoConfig struct {
+	ClusterEndpoint            string `envconfig:"MONGODB_CLUSTER_ENDPOINT"`
+	Username                   string `envconfig:"MONGODB_USERNAME"`
+	Password                   string `envconfig:"MONGODB_PASSWORD"                    json:"-"`
+	Database                   string `envconfig:"MONGODB_DATABASE"`
+	UploadsCollection          string `envconfig:"MONGODB_UPLOADS_COLLECTION"`
+	NotificationsCollection    string `envconfig:"MONGODB_NOTIFICATIONS_COLLECTION"`
+	InvalidationsCollection    string `envconfig:"MONGODB_INVALIDATIONS_COLLECTION"`
+	ReleasesCollection         string `envconfig:"MONGODB_RELEASES_COLLECTION"`
+	MaintenanceCollection      string `envconfig:"MONGODB_MAINTENANCE_COLLECTION"`
+	FeatureFlagsCollection     string `envconfig:"MONGODB_FEATURE_FLAGS_COLLECTION"`
+	APIKeysCollection          string `envconfig:"MONGODB_API_KEYS_COLLECTION"`
+	AuditLogCollection         string `envconfig:"MONGODB_AUDIT_LOG_COLLECTION"`
+	RejectionReasonsCollection string `envconfig:"MONGODB_REJECTION_REASONS_COLLECTION"`
+	DatasetProfilesCollection  string `envconfig:"MONGODB_DATASET_PROFILES_COLLECTION"`
+	SchedulerLeaseCollection   string `envconfig:"MONGODB_SCHEDULER_LEASE_COLLECTION"`
+	ReplicaSet                 string `envconfig:"MONGODB_REPLICA_SET"`
+	IsSSL                      bool   `envconfig:"MONGODB_IS_SSL"`
+	VerifyCert                 bool   `envconfig:"MONGODB_VERIFY_CERT"`
+	CACertPath                 string `envconfig:"MONGODB_CA_CERT_PATH"`
+	IsDocumentDB               bool   `envconfig:"MONGODB_IS_DOCUMENT_DB"`
+	QueryTimeoutSecs           int    `envconfig:"MONGODB_QUERY_TIMEOUT_SECONDS"`
+	ConnectTimeoutSecs         int    `envconfig:"MONGODB_CONNECT_TIMEOUT_SECONDS"`
+	// PreferSecondaryForReads routes list/stats/export queries to a
+	// secondary-preferred read preference, so a reporting burst can't
+	// compete with writes and status checks (which always read the
+	// primary) for the same replica.
+	PreferSecondaryForReads bool `envconfig:"MONGODB_PREFER_SECONDARY_FOR_READS"`
+	// MaxPoolSize and MinPoolSize bound the client's connection pool.
+	// Zero leaves the driver's own default in place.
+	MaxPoolSize uint64 `envconfig:"MONGODB_MAX_POOL_SIZE"`
+	MinPoolSize uint64 `envconfig:"MONGODB_MIN_POOL_SIZE"`
+	// MaxConnIdleTime closes pooled connections that have sat idle longer
+	// than this. Zero leaves the driver's own default in place.
+	MaxConnIdleTime time.Duration `envconfig:"MONGODB_MAX_CONN_IDLE_TIME"`
+	// ServerSelectionTimeout bounds how long an operation waits for a
+	// suitable server before failing. Zero leaves the driver's own default
+	// in place.
+	ServerSelectionTimeout time.Duration `envconfig:"MONGODB_SERVER_SELECTION_TIMEOUT"`
+	// SlowQueryThreshold, if non-zero, logs any command that takes at
+	// least this long, so a reporting burst that starts hammering the DB
+	// shows up by endpoint rather than only as an aggregate latency spike.
+	SlowQueryThreshold time.Duration `envconfig:"MONGODB_SLOW_QUERY_THRESHOLD"`
+}
+
+var cfg *Config
+
+// Get returns the default config with any modifications through environment
+// variables applied, initialising it on first use.
+func Get() (*Config, error) {
+	if cfg != nil {
+		return cfg, nil
+	}
+
+	cfg = &Config{
+		BindAddr:                   "localhost:29500",
+		GracefulShutdownTimeout:    5 * time.Second,
+		HealthCheckInterval:        30 * time.Second,
+		HealthCheckCriticalTimeout: 90 * time.Second,
+		MongoConfig: MongoConfig{
+			ClusterEndpoint:            "localhost:27017",
+			Database:                   "imf_uploader",
+			UploadsCollection:          "uploads",
+			NotificationsCollection:    "notifications",
+			InvalidationsCollection:    "invalidations",
+			ReleasesCollection:         "releases",
+			MaintenanceCollection:      "maintenance",
+			FeatureFlagsCollection:     "featureFlags",
+			APIKeysCollection:          "apiKeys",
+			AuditLogCollection:         "auditLog",
+			RejectionReasonsCollection: "rejectionReasons",
+			DatasetProfilesCollection:  "datasetProfiles",
+			IsSSL:                      false,
+			VerifyCert:                 true,
+			QueryTimeoutSecs:           15,
+			ConnectTimeoutSecs:         5,
+			MaxPoolSize:                100,
+			MinPoolSize:                0,
+			MaxConnIdleTime:            10 * time.Minute,
+			ServerSelectionTimeout:     30 * time.Second,
+			SlowQueryThreshold:         500 * time.Millisecond,
+		},
+		TempStorageConfig: TempStorageConfig{
+			Backend:               "redis",
+			RedisAddr:             "localhost:6379",
+			TempStorageTTL:        24 * time.Hour,
+			TempStorageMaxTotal:   5 * 1024 * 1024 * 1024, // 5GB
+			TempStorageMaxPerFile: 500 * 1024 * 1024,      // 500MB
+			TempStorageS3Bucket:   "dis-imf-uploader-staging",
+			TempStorageS3Prefix:   "pending",
+			TempStorageDiskDir:    "/tmp/dis-imf-uploader/pending",
+			MemoryJanitorInterval: time.Minute,
+		},
+		ZipBundleConfig: ZipBundleConfig{
+			ZipAllowedExtensions: []string{".csv", ".xlsx", ".xls"},
+			ZipMaxEntrySizeBytes: 500 * 1024 * 1024, // 500MB
+			ZipMaxEntries:        200,
+		},
+		PDFConfig: PDFConfig{
+			PDFActiveContentAction: "reject",
+		},
+		XLSXConfig: XLSXConfig{
+			XLSXActiveContentAction: "reject",
+		},
+		ApprovalConfig: ApprovalConfig{
+			ApprovalChecklist:           []string{"figures verified", "embargo date confirmed"},
+			ApprovalPreventSelfApproval: true,
+		},
+		CORSConfig: CORSConfig{
+			CORSAllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			CORSAllowedHeaders: []string{"Content-Type", "Authorization"},
+			CORSMaxAge:         10 * time.Minute,
+		},
+		SecurityConfig: SecurityConfig{
+			SecurityAllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			SecurityMaxHeaderBytes: 16 * 1024,
+			SecurityHSTSMaxAge:     180 * 24 * time.Hour,
+		},
+		AuditConfig: AuditConfig{
+			AuditPathPrefixes: []string{"/api/v1/admin", "/api/v1/api-keys"},
+		},
+		TLSConfig: TLSConfig{
+			TLSMinVersion: "1.2",
+		},
+		NotifierConfig: NotifierConfig{
+			NotifierHTTPTimeout: 10 * time.Second,
+			NotifierQueueSize:   100,
+			NotifierWorkers:     4,
+		},
+		CDNConfig: CDNConfig{
+			CDNDatasetPrefixPattern: "/imf/%s/*",
+		},
+		ReconcileConfig: ReconcileConfig{
+			ReconcileInterval: time.Hour,
+		},
+		OrphanScanConfig: OrphanScanConfig{
+			OrphanScanPrefix: "imf/",
+		},
+		ArchiveConfig: ArchiveConfig{
+			ArchiveRetention: 6 * 30 * 24 * time.Hour,
+			ArchiveInterval:  24 * time.Hour,
+			ArchivePrefix:    "archive/uploads",
+		},
+		AuditLogConfig: AuditLogConfig{
+			AuditLogRetention:      2 * 365 * 24 * time.Hour,
+			AuditLogExportInterval: 24 * time.Hour,
+			AuditLogExportPrefix:   "archive/audit-log",
+		},
+		ConcurrencyConfig: ConcurrencyConfig{
+			MaxConcurrentS3Uploads:        10,
+			MaxConcurrentInvalidations:    5,
+			MaxConcurrentCloudflarePurges: 5,
+		},
+		ScanCacheConfig: ScanCacheConfig{
+			ScanCacheTTL: 30 * 24 * time.Hour,
+		},
+		SLAConfig: SLAConfig{
+			SLAReviewTarget:  24 * time.Hour,
+			SLAPublishTarget: 48 * time.Hour,
+		},
+	}
+
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
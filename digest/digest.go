@@ -0,0 +1,141 @@
+// Package digest computes and posts a daily summary of the upload
+// backlog — how many uploads are pending, the oldest of them, and
+// yesterday's approvals, rejections and failures — to Slack or a webhook
+// each morning.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Store is the subset of store.Store Digest depends on. Duplicated from
+// store.Store to avoid an import cycle.
+type Store interface {
+	ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error)
+}
+
+// Notifier posts the finished digest message. Duplicated from api.Notifier
+// to avoid an import cycle.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// Summary is the daily backlog snapshot a Digest posts.
+type Summary struct {
+	PendingCount int
+	// OldestPendingID and OldestPendingAge describe the longest-waiting
+	// pending upload, or ("", 0) if PendingCount is 0.
+	OldestPendingID  string
+	OldestPendingAge time.Duration
+	// ApprovedYesterday and RejectedYesterday count uploads first reviewed
+	// (ReviewedAt) during yesterday, in Digest.Location.
+	ApprovedYesterday int
+	RejectedYesterday int
+	// FailedCount counts uploads currently stuck in models.StatusFailed,
+	// regardless of when they failed.
+	FailedCount int
+}
+
+// Message renders summary as the text posted to Slack/webhook.
+func (s Summary) Message() string {
+	oldest := "none"
+	if s.OldestPendingID != "" {
+		oldest = fmt.Sprintf("%s (waiting %s)", s.OldestPendingID, s.OldestPendingAge.Round(time.Minute))
+	}
+	return fmt.Sprintf(
+		"daily upload digest: %d pending (oldest: %s), yesterday: %d approved / %d rejected, %d currently failed",
+		s.PendingCount, oldest, s.ApprovedYesterday, s.RejectedYesterday, s.FailedCount,
+	)
+}
+
+// Digest computes and posts the daily backlog Summary. It has no scheduling
+// of its own: Run is intended to be invoked once a day by an external
+// trigger (e.g. a Kubernetes CronJob configured with the desired cron
+// expression and timezone), since this repo has no long-running process
+// wiring of its own yet — the same pattern api.PublishScheduled uses for
+// the publish window.
+type Digest struct {
+	Store    Store
+	Notifier Notifier
+	// Location is the timezone "yesterday" is evaluated in, e.g. so a
+	// digest triggered just after UTC midnight still reports the intended
+	// local day. Nil means UTC.
+	Location *time.Location
+}
+
+// NewDigest constructs a Digest posting summaries via notifier, evaluating
+// "yesterday" in location. A nil location defaults to UTC.
+func NewDigest(store Store, notifier Notifier, location *time.Location) *Digest {
+	if location == nil {
+		location = time.UTC
+	}
+	return &Digest{Store: store, Notifier: notifier, Location: location}
+}
+
+// Run builds today's Summary and posts it via Notifier.
+func (d *Digest) Run(ctx context.Context) error {
+	summary, err := d.Summarise(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build daily digest: %w", err)
+	}
+	if err := d.Notifier.Notify(ctx, summary.Message()); err != nil {
+		return fmt.Errorf("failed to post daily digest: %w", err)
+	}
+	return nil
+}
+
+// Summarise builds a Summary of the current upload backlog, without
+// posting it.
+func (d *Digest) Summarise(ctx context.Context) (Summary, error) {
+	uploads, err := d.Store.ListUploads(ctx, "")
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to list uploads: %w", err)
+	}
+
+	loc := d.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	yesterdayStart := todayStart.AddDate(0, 0, -1)
+
+	var summary Summary
+	var oldestCreatedAt time.Time
+	for _, upload := range uploads {
+		switch upload.Status {
+		case models.StatusPending:
+			summary.PendingCount++
+			if summary.OldestPendingID == "" || upload.CreatedAt.Before(oldestCreatedAt) {
+				summary.OldestPendingID = upload.ID
+				oldestCreatedAt = upload.CreatedAt
+			}
+		case models.StatusFailed:
+			summary.FailedCount++
+		}
+
+		if upload.ReviewedAt.IsZero() {
+			continue
+		}
+		reviewedAt := upload.ReviewedAt.In(loc)
+		if reviewedAt.Before(yesterdayStart) || !reviewedAt.Before(todayStart) {
+			continue
+		}
+		switch upload.Status {
+		case models.StatusApproved, models.StatusPublished:
+			summary.ApprovedYesterday++
+		case models.StatusRejected:
+			summary.RejectedYesterday++
+		}
+	}
+
+	if summary.OldestPendingID != "" {
+		summary.OldestPendingAge = now.Sub(oldestCreatedAt)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,108 @@
+package digest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/digest"
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+type fakeStore struct {
+	uploads []*models.Upload
+	listErr error
+}
+
+func (s *fakeStore) ListUploads(_ context.Context, _ string) ([]*models.Upload, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	return s.uploads, nil
+}
+
+type fakeNotifier struct {
+	messages []string
+	err      error
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, message string) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestDigest_Summarise(t *testing.T) {
+	now := time.Now().UTC()
+	yesterday := now.AddDate(0, 0, -1)
+	twoDaysAgo := now.AddDate(0, 0, -2)
+
+	store := &fakeStore{uploads: []*models.Upload{
+		{ID: "1", Status: models.StatusPending, CreatedAt: twoDaysAgo},
+		{ID: "2", Status: models.StatusPending, CreatedAt: now},
+		{ID: "3", Status: models.StatusApproved, ReviewedAt: yesterday},
+		{ID: "4", Status: models.StatusRejected, ReviewedAt: yesterday},
+		{ID: "5", Status: models.StatusApproved, ReviewedAt: twoDaysAgo}, // outside yesterday's window
+		{ID: "6", Status: models.StatusFailed},
+	}}
+	d := digest.NewDigest(store, &fakeNotifier{}, time.UTC)
+
+	summary, err := d.Summarise(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.PendingCount != 2 {
+		t.Fatalf("expected 2 pending, got %d", summary.PendingCount)
+	}
+	if summary.OldestPendingID != "1" {
+		t.Fatalf("expected the oldest pending upload to be id 1, got %q", summary.OldestPendingID)
+	}
+	if summary.ApprovedYesterday != 1 {
+		t.Fatalf("expected 1 approval yesterday, got %d", summary.ApprovedYesterday)
+	}
+	if summary.RejectedYesterday != 1 {
+		t.Fatalf("expected 1 rejection yesterday, got %d", summary.RejectedYesterday)
+	}
+	if summary.FailedCount != 1 {
+		t.Fatalf("expected 1 currently failed, got %d", summary.FailedCount)
+	}
+}
+
+func TestDigest_Run(t *testing.T) {
+	t.Run("posts the summary message via Notifier", func(t *testing.T) {
+		store := &fakeStore{uploads: []*models.Upload{
+			{ID: "1", Status: models.StatusPending, CreatedAt: time.Now()},
+		}}
+		notifier := &fakeNotifier{}
+		d := digest.NewDigest(store, notifier, nil)
+
+		if err := d.Run(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 1 {
+			t.Fatalf("expected one message to be posted, got %v", notifier.messages)
+		}
+	})
+
+	t.Run("propagates a failure to list uploads", func(t *testing.T) {
+		store := &fakeStore{listErr: errors.New("store unreachable")}
+		d := digest.NewDigest(store, &fakeNotifier{}, nil)
+
+		if err := d.Run(context.Background()); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("propagates a failure to post", func(t *testing.T) {
+		store := &fakeStore{}
+		notifier := &fakeNotifier{err: errors.New("webhook unreachable")}
+		d := digest.NewDigest(store, notifier, nil)
+
+		if err := d.Run(context.Background()); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
@@ -0,0 +1,60 @@
+// Package events is a minimal in-process pub/sub used to wake up a
+// long-polling GetUpload request as soon as an upload's status changes,
+// instead of it sleeping through its full wait window or the caller
+// resorting to a tight polling loop.
+package events
+
+import "sync"
+
+// Bus fans out upload status changes to whoever is currently waiting on a
+// given upload ID. It has no memory of past publishes: a Subscribe call
+// only sees changes that happen after it, so callers must re-check the
+// upload's current state (it may already have changed) before subscribing.
+type Bus struct {
+	mu   sync.Mutex
+	subs map[string][]chan struct{}
+}
+
+// NewBus returns an empty Bus.
+func NewBus() *Bus {
+	return &Bus{subs: map[string][]chan struct{}{}}
+}
+
+// Subscribe returns a channel that's closed the next time Publish(id) is
+// called. The returned cancel func must be called once the caller stops
+// waiting (e.g. on timeout), or the subscription leaks until the next
+// Publish(id).
+func (b *Bus) Subscribe(id string) (ch <-chan struct{}, cancel func()) {
+	c := make(chan struct{})
+
+	b.mu.Lock()
+	b.subs[id] = append(b.subs[id], c)
+	b.mu.Unlock()
+
+	return c, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[id]
+		for i, sub := range subs {
+			if sub == c {
+				b.subs[id] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		if len(b.subs[id]) == 0 {
+			delete(b.subs, id)
+		}
+	}
+}
+
+// Publish wakes every subscriber currently waiting on id.
+func (b *Bus) Publish(id string) {
+	b.mu.Lock()
+	subs := b.subs[id]
+	delete(b.subs, id)
+	b.mu.Unlock()
+
+	for _, c := range subs {
+		close(c)
+	}
+}
@@ -0,0 +1,65 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/events"
+)
+
+func TestBus_PublishWakesSubscriber(t *testing.T) {
+	b := events.NewBus()
+
+	changed, cancel := b.Subscribe("1")
+	defer cancel()
+
+	go b.Publish("1")
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Publish to close the subscription channel")
+	}
+}
+
+func TestBus_PublishIsNoOpWithoutSubscribers(t *testing.T) {
+	b := events.NewBus()
+	b.Publish("1") // must not panic or block
+}
+
+func TestBus_CancelStopsFutureWaitFromSeeingAPastPublish(t *testing.T) {
+	b := events.NewBus()
+
+	changed, cancel := b.Subscribe("1")
+	cancel()
+	b.Publish("1")
+
+	select {
+	case <-changed:
+		t.Fatal("expected a canceled subscription not to receive Publish")
+	default:
+	}
+}
+
+func TestBus_SubscribersForDifferentIDsAreIndependent(t *testing.T) {
+	b := events.NewBus()
+
+	changed1, cancel1 := b.Subscribe("1")
+	defer cancel1()
+	changed2, cancel2 := b.Subscribe("2")
+	defer cancel2()
+
+	b.Publish("1")
+
+	select {
+	case <-changed1:
+	case <-time.After(time.Second):
+		t.Fatal("expected Publish(\"1\") to wake subscriber 1")
+	}
+
+	select {
+	case <-changed2:
+		t.Fatal("expected Publish(\"1\") not to wake subscriber 2")
+	default:
+	}
+}
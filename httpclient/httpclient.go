@@ -0,0 +1,37 @@
+// Package httpclient builds *http.Client instances for outbound notifier
+// requests (Slack, PagerDuty, Opsgenie, and any future webhook/Teams
+// integration) with a bounded timeout and optional proxy, so a hung
+// downstream call cannot stall the handler that triggered it.
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Config controls the *http.Client returned by New.
+type Config struct {
+	// Timeout bounds the whole request/response cycle. Zero disables the
+	// timeout, matching http.DefaultClient.
+	Timeout time.Duration
+	// ProxyURL routes requests through an HTTP(S) proxy. Empty falls back
+	// to http.ProxyFromEnvironment.
+	ProxyURL string
+}
+
+// New builds an *http.Client from cfg.
+func New(cfg Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", cfg.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}, nil
+}
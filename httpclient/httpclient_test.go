@@ -0,0 +1,41 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/httpclient"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("applies the configured timeout", func(t *testing.T) {
+		client, err := httpclient.New(httpclient.Config{Timeout: 5 * time.Second})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.Timeout != 5*time.Second {
+			t.Fatalf("expected timeout 5s, got %v", client.Timeout)
+		}
+	})
+
+	t.Run("configures a proxy when set", func(t *testing.T) {
+		client, err := httpclient.New(httpclient.Config{ProxyURL: "http://proxy.internal:8080"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		transport, ok := client.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("expected *http.Transport, got %T", client.Transport)
+		}
+		if transport.Proxy == nil {
+			t.Fatal("expected a proxy function to be set")
+		}
+	})
+
+	t.Run("invalid proxy url is an error", func(t *testing.T) {
+		if _, err := httpclient.New(httpclient.Config{ProxyURL: "://bad"}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
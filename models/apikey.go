@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// APIKey is a credential issued to an automation client, scoped to a set of
+// permissions it can exercise, so external pipelines don't have to share
+// the single service auth token (see api.Handler.ServiceAuthToken).
+type APIKey struct {
+	ID   string `bson:"_id"  json:"id"`
+	Name string `bson:"name" json:"name"`
+	// HashedKey is the sha256 (hex-encoded) digest of the raw key. The raw
+	// key itself is never stored and is only returned once, at creation or
+	// rotation time.
+	HashedKey string `bson:"hashed_key" json:"-"`
+	// Permissions lists the permission strings this key is scoped to, e.g.
+	// "imf:purge". Empty grants none, matching the zero-value-is-safest
+	// convention h.Permissions relies on elsewhere.
+	Permissions []string `bson:"permissions,omitempty" json:"permissions,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	// RevokedAt marks when the key was revoked. Nil means it is still
+	// active.
+	RevokedAt *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
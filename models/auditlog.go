@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Actor identifies who performed an audited action. ID and Email are
+// populated from whatever the request carried (a service principal name, a
+// forwarded user email, or an explicit override); either may be empty if
+// the request didn't supply it. AuthMethod records how the actor was
+// established, so an entry with an empty ID/Email is still distinguishable
+// from one where identity simply couldn't be determined.
+type Actor struct {
+	ID          string `bson:"id,omitempty"           json:"id,omitempty"`
+	Email       string `bson:"email,omitempty"        json:"email,omitempty"`
+	DisplayName string `bson:"display_name,omitempty" json:"display_name,omitempty"`
+	// AuthMethod is "service" for a request authenticated with
+	// Service-Auth-Token, "header" for one identified via the Audit-Actor
+	// or X-User-Email headers, or "" if no identity could be determined.
+	AuthMethod string `bson:"auth_method,omitempty" json:"auth_method,omitempty"`
+}
+
+// AuditLogEntry records a single mutating admin API call, so changes to
+// maintenance mode, feature flags, API keys and similar config can be
+// traced back to who made them and what was sent.
+type AuditLogEntry struct {
+	ID     string `bson:"_id"    json:"id"`
+	Actor  Actor  `bson:"actor"  json:"actor"`
+	Method string `bson:"method" json:"method"`
+	Path   string `bson:"path"   json:"path"`
+	// Body is the raw JSON request body, captured as the "after" state of
+	// the change. There is no generic way to capture a "before" state
+	// without handler-specific knowledge, so it is omitted here.
+	Body string `bson:"body,omitempty" json:"body,omitempty"`
+	// Before is the pre-change state, populated only by handlers that know
+	// it (e.g. PatchUpload records the upload's prior metadata here before
+	// applying an edit). Empty for entries recorded by the generic
+	// AuditLog middleware.
+	Before     string    `bson:"before,omitempty" json:"before,omitempty"`
+	Status     int       `bson:"status"            json:"status"`
+	RecordedAt time.Time `bson:"recorded_at"       json:"recorded_at"`
+	// Environment names the deployment this entry was recorded in, e.g.
+	// "staging" or "production", so entries from multiple environments
+	// sharing one audit log store can be told apart. Empty when the
+	// recording Handler has no Environment configured.
+	Environment string `bson:"environment,omitempty" json:"environment,omitempty"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// DatasetProfile holds the per-dataset overrides that would otherwise be
+// hard-coded as one global config: where an upload's files land in S3,
+// which CDN path they're served from, which reviewers may approve them,
+// and which notification channel hears about them. It's keyed by
+// Upload.Dataset and managed by admins via /api/v1/admin/dataset-profiles.
+//
+// Not every field is consulted everywhere yet: RequiredReviewers gates
+// Approve today, while S3Prefix, CDNPathPrefix and NotificationChannel are
+// recorded for a dataset but not yet wired into the upload lifecycle.
+type DatasetProfile struct {
+	Dataset string `bson:"_id" json:"dataset"`
+
+	// S3Prefix is prepended to a new upload's target key for this dataset,
+	// e.g. "datasets/gdp/", so files for different datasets don't collide
+	// in the same S3 prefix.
+	S3Prefix string `bson:"s3_prefix,omitempty" json:"s3_prefix,omitempty"`
+
+	// CDNPathPrefix overrides the path an upload for this dataset is
+	// served from at the CDN, when it differs from the S3 key.
+	CDNPathPrefix string `bson:"cdn_path_prefix,omitempty" json:"cdn_path_prefix,omitempty"`
+
+	// RequiredReviewers, when non-empty, restricts who may approve or
+	// reject an upload for this dataset to the listed reviewer usernames.
+	RequiredReviewers []string `bson:"required_reviewers,omitempty" json:"required_reviewers,omitempty"`
+
+	// NotificationChannel identifies the channel notifications for this
+	// dataset's uploads should be routed to, e.g. a Slack channel name.
+	NotificationChannel string `bson:"notification_channel,omitempty" json:"notification_channel,omitempty"`
+
+	// CacheControl, when set, overrides the Cache-Control header published
+	// objects for this dataset are served with, e.g. "no-cache" for a
+	// dataset that's revised frequently, taking precedence over
+	// PublishConfig.CacheControlByExtension.
+	CacheControl string `bson:"cache_control,omitempty" json:"cache_control,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
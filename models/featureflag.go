@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// FeatureFlag records whether a named, potentially risky feature is enabled
+// in this environment, e.g. async approval or virus scanning. A flag that
+// has never been set is treated as disabled, so a fresh environment starts
+// with every gated feature off until an operator opts in.
+type FeatureFlag struct {
+	Name      string    `bson:"_id"                  json:"name"`
+	Enabled   bool      `bson:"enabled"               json:"enabled"`
+	UpdatedBy string    `bson:"updated_by,omitempty"  json:"updated_by,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"            json:"updated_at"`
+}
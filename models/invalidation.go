@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// InvalidationStatus represents the outcome of a CDN invalidation request.
+type InvalidationStatus string
+
+const (
+	InvalidationStatusCompleted InvalidationStatus = "completed"
+	InvalidationStatusFailed    InvalidationStatus = "failed"
+)
+
+// Invalidation records a CDN invalidation, whether raised manually against a
+// whole dataset prefix (e.g. after a batch publish completes and issuing
+// dozens of per-file invalidations would be wasteful) or as an ad-hoc
+// operational request not tied to any upload.
+type Invalidation struct {
+	ID             string             `bson:"_id"                     json:"id"`
+	Provider       string             `bson:"provider"                json:"provider"`
+	Dataset        string             `bson:"dataset,omitempty"       json:"dataset,omitempty"`
+	Paths          []string           `bson:"paths"                   json:"paths"`
+	InvalidationID string             `bson:"invalidation_id"         json:"invalidation_id"`
+	Status         InvalidationStatus `bson:"status"                  json:"status"`
+	Error          string             `bson:"error,omitempty"         json:"error,omitempty"`
+	TriggeredBy    string             `bson:"triggered_by,omitempty"  json:"triggered_by,omitempty"`
+	// UploadID links this invalidation back to the upload approval that
+	// raised it. Empty for ad-hoc, dataset-prefix or operational
+	// invalidations not tied to a specific upload.
+	UploadID  string    `bson:"upload_id,omitempty" json:"upload_id,omitempty"`
+	CreatedAt time.Time `bson:"created_at"          json:"created_at"`
+}
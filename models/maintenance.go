@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// MaintenanceMode records whether the service is currently rejecting writes
+// (new uploads, approvals, rejections, invalidations, releases) while still
+// serving reads, e.g. during a bucket migration. It is a singleton record:
+// store.Store keeps exactly one, identified by MaintenanceModeID.
+type MaintenanceMode struct {
+	ID      string `bson:"_id"               json:"id"`
+	Enabled bool   `bson:"enabled"           json:"enabled"`
+	// Message is returned to callers rejected by requireNotInMaintenance,
+	// e.g. "uploads are paused during a planned S3 migration".
+	Message   string    `bson:"message,omitempty" json:"message,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"        json:"updated_at"`
+}
+
+// MaintenanceModeID is the fixed document ID under which the singleton
+// MaintenanceMode record is stored.
+const MaintenanceModeID = "maintenance"
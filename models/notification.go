@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// NotificationStatus represents the outcome of a single notification
+// delivery attempt.
+type NotificationStatus string
+
+const (
+	NotificationStatusSent   NotificationStatus = "sent"
+	NotificationStatusFailed NotificationStatus = "failed"
+)
+
+// Notification records a single attempt to notify an external channel (e.g.
+// Slack) about an event in an upload's lifecycle, so failed deliveries are
+// visible and can be resent instead of being silently swallowed. PayloadHash
+// is a hex-encoded SHA-256 of Message, kept alongside it so a resend request
+// can confirm it is resending the same payload that was originally attempted.
+type Notification struct {
+	ID          string             `bson:"_id"             json:"id"`
+	UploadID    string             `bson:"upload_id"       json:"upload_id"`
+	Channel     string             `bson:"channel"         json:"channel"`
+	Message     string             `bson:"message"         json:"message"`
+	PayloadHash string             `bson:"payload_hash"    json:"payload_hash"`
+	Status      NotificationStatus `bson:"status"          json:"status"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+	Attempts    int                `bson:"attempts"        json:"attempts"`
+	CreatedAt   time.Time          `bson:"created_at"      json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"      json:"updated_at"`
+}
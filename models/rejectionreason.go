@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RejectionReason is a managed taxonomy code a reviewer attaches to a
+// rejected upload, so rejections can be reported on consistently instead of
+// depending on free-text notes. Codes are managed by admins via
+// /api/v1/admin/rejection-reasons.
+type RejectionReason struct {
+	Code  string `bson:"_id"   json:"code"`
+	Label string `bson:"label" json:"label"`
+	// Active gates whether the code can still be used on a new rejection.
+	// A code is deactivated rather than deleted once it's referenced by an
+	// existing upload, so historical rejections keep a meaningful code.
+	Active    bool      `bson:"active"     json:"active"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ReleaseStatus represents the lifecycle state of a release.
+type ReleaseStatus string
+
+const (
+	ReleaseStatusPending   ReleaseStatus = "pending"
+	ReleaseStatusPublished ReleaseStatus = "published"
+	ReleaseStatusFailed    ReleaseStatus = "failed"
+)
+
+// Release groups the uploads that must be published to S3 and invalidated
+// together, so a partially-published dataset never appears on the website:
+// if any upload in the release fails to publish, every upload already
+// published as part of it is rolled back to its pre-release state.
+type Release struct {
+	ID        string        `bson:"_id"                json:"id"`
+	Dataset   string        `bson:"dataset"             json:"dataset"`
+	UploadIDs []string      `bson:"upload_ids"          json:"upload_ids"`
+	Status    ReleaseStatus `bson:"status"              json:"status"`
+	// Error records why publication failed, and is empty otherwise.
+	Error      string    `bson:"error,omitempty"       json:"error,omitempty"`
+	ReviewedBy string    `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	CreatedAt  time.Time `bson:"created_at"            json:"created_at"`
+	UpdatedAt  time.Time `bson:"updated_at"            json:"updated_at"`
+}
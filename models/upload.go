@@ -0,0 +1,233 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+// Status represents the lifecycle state of an uploaded file.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusPublished Status = "published"
+	// StatusScheduled means an upload was approved outside the configured
+	// publish window and is queued for PublishScheduled to publish once the
+	// window next opens.
+	StatusScheduled Status = "scheduled"
+	// StatusFailed means publishing an approved or scheduled upload failed
+	// partway through (an S3 or CloudFront error), leaving FailureReason
+	// set. The staged file at TempKey is left untouched, so the upload can
+	// be retried without re-uploading.
+	StatusFailed Status = "failed"
+)
+
+// validUploadTransitions lists, for each status, the statuses an upload may
+// move to next. A status with no entry (StatusRejected, StatusPublished)
+// is terminal.
+var validUploadTransitions = map[Status][]Status{
+	StatusPending:   {StatusApproved, StatusRejected, StatusScheduled, StatusFailed},
+	StatusScheduled: {StatusApproved, StatusRejected, StatusFailed},
+	StatusFailed:    {StatusApproved, StatusRejected},
+}
+
+// CanTransition reports whether an upload may move from status from to
+// status to, so the store layer can reject an illegal transition (e.g.
+// rejected -> approved) regardless of what a handler tries to write.
+// Setting to the same status is always allowed, since a handler may
+// re-save an upload without changing its status.
+func CanTransition(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range validUploadTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// DriftStatus describes the outcome of the most recent background
+// reconciliation of an approved or published upload against S3. Empty means
+// either no reconciliation has run yet or the last one found no drift.
+type DriftStatus string
+
+const (
+	// DriftMissing means TargetKey could not be found in S3.
+	DriftMissing DriftStatus = "missing"
+	// DriftChecksumMismatch means the object at TargetKey exists but its
+	// content no longer matches Checksum.
+	DriftChecksumMismatch DriftStatus = "checksum_mismatch"
+	// DriftCheckFailed means the object was found but reconciliation could
+	// not read it to compare checksums, e.g. a transient S3 read error. It
+	// is distinct from DriftMissing: the upload should not be reported as
+	// gone from S3 just because the last check couldn't complete.
+	DriftCheckFailed DriftStatus = "check_failed"
+)
+
+// PublishStepName identifies one stage of publishUpload's workflow, in the
+// order it runs.
+type PublishStepName string
+
+const (
+	PublishStepBackup     PublishStepName = "backup"
+	PublishStepS3Upload   PublishStepName = "s3_upload"
+	PublishStepCloudFront PublishStepName = "cloudfront"
+	PublishStepCloudflare PublishStepName = "cloudflare"
+	PublishStepVerify     PublishStepName = "verify"
+)
+
+// PublishStepStatus is the outcome of a PublishStep at the point the
+// upload's record was last saved.
+type PublishStepStatus string
+
+const (
+	PublishStepPending   PublishStepStatus = "pending"
+	PublishStepRunning   PublishStepStatus = "running"
+	PublishStepSucceeded PublishStepStatus = "succeeded"
+	PublishStepFailed    PublishStepStatus = "failed"
+	// PublishStepSkipped means the step didn't apply, e.g. no Cloudflare
+	// client is configured.
+	PublishStepSkipped PublishStepStatus = "skipped"
+)
+
+// PublishStep records the progress of one stage of publishing an upload, so
+// a dashboard can show exactly where a stuck or failed publish got to.
+type PublishStep struct {
+	Name        PublishStepName   `bson:"name"                   json:"name"`
+	Status      PublishStepStatus `bson:"status"                 json:"status"`
+	StartedAt   time.Time         `bson:"started_at,omitempty"   json:"started_at,omitempty"`
+	CompletedAt time.Time         `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	Error       string            `bson:"error,omitempty"        json:"error,omitempty"`
+}
+
+// PurgeRetry tracks a Cloudflare cache purge that failed while publishing
+// an upload, so purge.Retrier can retry it in the background instead of it
+// being logged and forgotten.
+type PurgeRetry struct {
+	URLs        []string  `bson:"urls"                 json:"urls"`
+	Attempts    int       `bson:"attempts"             json:"attempts"`
+	LastError   string    `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextRetryAt time.Time `bson:"next_retry_at"        json:"next_retry_at"`
+}
+
+// Upload represents a single file submitted for review and publication.
+type Upload struct {
+	ID       string `bson:"_id"      json:"id"`
+	Dataset  string `bson:"dataset"  json:"dataset"`
+	Filename string `bson:"filename" json:"filename"`
+	// Title and Description are free-text metadata an uploader can attach
+	// and later correct via PatchUpload, e.g. to fix a typo, without
+	// touching the underlying file.
+	Title       string `bson:"title,omitempty"       json:"title,omitempty"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	TargetKey   string `bson:"target_key"             json:"target_key"`
+	TempKey     string `bson:"temp_key"               json:"temp_key"`
+	// OriginalTargetKey records the TargetKey an upload was created with,
+	// when a reviewer overrides it at approval time (e.g. to fix a typo in
+	// the destination filename). Empty means TargetKey was never renamed.
+	OriginalTargetKey string `bson:"original_target_key,omitempty" json:"original_target_key,omitempty"`
+	// InvalidationID is the CloudFront invalidation ID returned when this
+	// upload's TargetKey was invalidated at approval time. When
+	// invalidations are batched, several uploads approved close together
+	// share the same ID.
+	InvalidationID string `bson:"invalidation_id,omitempty" json:"invalidation_id,omitempty"`
+	// Checksum is the sha256 (hex-encoded) of the file content published to
+	// TargetKey. CreateUpload records a provisional value for the staged
+	// file as soon as it's uploaded; approveUpload overwrites it with the
+	// checksum of what was actually published. Empty means no checksum is
+	// available, so background reconciliation can only check for
+	// existence, not verify content.
+	Checksum string `bson:"checksum,omitempty" json:"checksum,omitempty"`
+	// Size is the byte length of the file content published to TargetKey,
+	// recorded alongside Checksum at approval time. Zero means no size is
+	// available, e.g. because no Temp backend was configured.
+	Size int64 `bson:"size,omitempty" json:"size,omitempty"`
+	// Integrity is a Subresource-Integrity-style digest ("sha384-<base64>")
+	// of the file content published to TargetKey, recorded alongside
+	// Checksum at approval time so the website can embed an integrity
+	// attribute when linking the file. Empty means no digest is available.
+	Integrity string `bson:"integrity,omitempty" json:"integrity,omitempty"`
+	// Drift and LastReconciledAt record the outcome of the most recent
+	// background reconciliation against S3 (see package reconcile). Empty
+	// Drift means the object matched, or no reconciliation has run yet.
+	Drift            DriftStatus `bson:"drift,omitempty"              json:"drift,omitempty"`
+	LastReconciledAt time.Time   `bson:"last_reconciled_at,omitempty" json:"last_reconciled_at,omitempty"`
+	UploadedBy  string `bson:"uploaded_by"            json:"uploaded_by"`
+	Status      Status `bson:"status"                 json:"status"`
+	ReviewedBy  string `bson:"reviewed_by,omitempty"  json:"reviewed_by,omitempty"`
+	ReviewNotes string `bson:"review_notes,omitempty" json:"review_notes,omitempty"`
+	// ReviewedAt is when the upload was first approved or rejected, so SLA
+	// reporting can measure time-to-first-review from CreatedAt without
+	// depending on UpdatedAt, which also moves on later, unrelated changes.
+	ReviewedAt time.Time `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	// PublishedAt is when an approved upload's file finished publishing to
+	// S3, i.e. the moment publishUpload completes successfully.
+	PublishedAt time.Time `bson:"published_at,omitempty" json:"published_at,omitempty"`
+	// RejectionReasonCode is the models.RejectionReason.Code recorded when
+	// Status is StatusRejected, so rejections can be reported on by reason
+	// rather than by parsing ReviewNotes. Empty for an upload rejected
+	// before this taxonomy existed, or via a path that doesn't collect one.
+	RejectionReasonCode string `bson:"rejection_reason_code,omitempty" json:"rejection_reason_code,omitempty"`
+	// FailureReason describes why publishing failed, set alongside
+	// StatusFailed. Empty otherwise, and cleared on a successful retry.
+	FailureReason string `bson:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	// ParentUploadID links an upload exploded from a zip bundle back to the
+	// bundle upload it came from.
+	ParentUploadID string    `bson:"parent_upload_id,omitempty" json:"parent_upload_id,omitempty"`
+	CreatedAt      time.Time `bson:"created_at"                 json:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at"                 json:"updated_at"`
+	// DiffSummary caches the structural diff against the currently
+	// published file, computed on first request to GetUploadDiff.
+	DiffSummary *validate.DiffSummary `bson:"diff_summary,omitempty" json:"diff_summary,omitempty"`
+	// Acknowledgements records the approval checklist items the reviewer
+	// confirmed when approving this upload.
+	Acknowledgements []string `bson:"acknowledgements,omitempty" json:"acknowledgements,omitempty"`
+	// PublishSteps records the progress of each stage of the most recent (or
+	// in-progress) publish attempt, in order, so a stuck or StatusFailed
+	// upload shows exactly where it got to.
+	PublishSteps []PublishStep `bson:"publish_steps,omitempty" json:"publish_steps,omitempty"`
+	// Tags are free-form labels for ad-hoc grouping (e.g. "Q3-revision",
+	// "embargoed") that don't warrant a schema change. Set at upload time
+	// or edited later by a reviewer via PATCH /uploads/{id}/tags.
+	Tags []string `bson:"tags,omitempty" json:"tags,omitempty"`
+	// PurgeRetry is set when the Cloudflare purge during publishing fails,
+	// so purge.Retrier can retry it later without blocking or re-running
+	// the rest of the publish workflow. Nil once the purge has succeeded,
+	// or if Cloudflare was never configured or purged for this upload.
+	PurgeRetry *PurgeRetry `bson:"purge_retry,omitempty" json:"purge_retry,omitempty"`
+}
+
+// UploadFilter narrows a ListUploadsFiltered query, so a reviewer dashboard
+// can build a "needs attention" view (e.g. several statuses across one
+// dataset) in a single call. Zero-value fields are unconstrained.
+type UploadFilter struct {
+	Dataset string
+	// UploadedBy matches uploads whose UploadedBy equals the given value.
+	// Empty matches any uploader.
+	UploadedBy string
+	// Statuses matches uploads whose Status is one of the listed values.
+	// Empty matches any status.
+	Statuses []Status
+	// TargetKeyPrefix matches uploads whose TargetKey starts with the given
+	// prefix. Empty matches any TargetKey.
+	TargetKeyPrefix string
+	// SortBy is the field results are ordered by. It must be one of
+	// api.AllowedUploadSortFields; empty leaves the order unspecified.
+	SortBy string
+	// SortDescending reverses the order of SortBy. Ignored when SortBy is
+	// empty.
+	SortDescending bool
+	// CreatedAfter and CreatedBefore narrow results to uploads whose
+	// CreatedAt falls within [CreatedAfter, CreatedBefore). A zero value
+	// leaves that bound unconstrained.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Tags matches uploads that carry at least one of the listed tags.
+	// Empty matches any tags.
+	Tags []string
+}
@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+func TestCanTransition(t *testing.T) {
+	cases := []struct {
+		from, to models.Status
+		want     bool
+	}{
+		{models.StatusPending, models.StatusApproved, true},
+		{models.StatusPending, models.StatusRejected, true},
+		{models.StatusPending, models.StatusScheduled, true},
+		{models.StatusScheduled, models.StatusApproved, true},
+		{models.StatusScheduled, models.StatusRejected, true},
+		{models.StatusRejected, models.StatusApproved, false},
+		{models.StatusApproved, models.StatusRejected, false},
+		{models.StatusScheduled, models.StatusPending, false},
+		{models.StatusApproved, models.StatusApproved, true},
+		{models.StatusPending, models.StatusFailed, true},
+		{models.StatusScheduled, models.StatusFailed, true},
+		{models.StatusFailed, models.StatusApproved, true},
+		{models.StatusFailed, models.StatusRejected, true},
+		{models.StatusFailed, models.StatusScheduled, false},
+	}
+
+	for _, c := range cases {
+		if got := models.CanTransition(c.from, c.to); got != c.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
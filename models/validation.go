@@ -0,0 +1,20 @@
+package models
+
+// ValidationError describes a single failed validation rule, e.g. a missing
+// checklist acknowledgement or an invalid target key override, so API
+// clients can highlight exactly what failed instead of parsing a flattened
+// sentence out of a plain-text error message.
+type ValidationError struct {
+	// Rule identifies which check failed, e.g. "target_key_no_traversal".
+	Rule string `json:"rule"`
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+	// Value is the offending input that triggered Rule, when there is one.
+	Value string `json:"value,omitempty"`
+}
+
+// ValidationErrors is the body of a 400 response when a request fails one
+// or more validation rules.
+type ValidationErrors struct {
+	Errors []ValidationError `json:"errors"`
+}
@@ -0,0 +1,171 @@
+// Package notify wraps a notifier so that sends happen on background
+// workers instead of the calling goroutine, bounded by a fixed-size queue.
+// It is shared by every notifier implementation (Slack, PagerDuty/Opsgenie
+// webhooks, and any future Teams integration) so a hung downstream call
+// cannot stall the request that triggered it.
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Notifier sends a notification for a key event in the upload lifecycle.
+// Duplicated from api.Notifier so this package does not depend on api.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// ApprovalNotifier sends a richer, interactive notification when an upload
+// becomes pending review. Duplicated from api.ApprovalNotifier so this
+// package does not depend on api.
+type ApprovalNotifier interface {
+	NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error
+}
+
+// ErrorNotifier sends a notification immediately, bypassing any batching or
+// quiet-hours suppression a Notifier otherwise applies to Notify.
+// Duplicated from api.ErrorNotifier so this package does not depend on api.
+type ErrorNotifier interface {
+	NotifyError(ctx context.Context, message string) error
+}
+
+// ErrQueueFull is returned when Async's bounded queue has no room for
+// another send. The send is dropped rather than blocking the caller.
+var ErrQueueFull = errors.New("notification queue is full")
+
+// Stats summarises Async's delivery activity since it was constructed, so
+// operators can tell a healthy backlog apart from one that's dropping
+// sends.
+type Stats struct {
+	// Queued is the number of sends currently buffered, waiting for a
+	// worker.
+	Queued int `json:"queued"`
+	// Delivered counts sends a worker handed to Notifier without error.
+	Delivered int64 `json:"delivered"`
+	// Failed counts sends a worker handed to Notifier that returned an
+	// error; the error itself went to OnError.
+	Failed int64 `json:"failed"`
+	// Dropped counts sends rejected because the queue was full.
+	Dropped int64 `json:"dropped"`
+}
+
+// Async wraps a Notifier so that Notify, and NotifyApprovalRequest/
+// NotifyError when the wrapped Notifier supports them, enqueue their send
+// and return immediately. A pool of background workers delivers queued
+// sends to Notifier concurrently, detached from the caller's context so a
+// request context cancelled after the handler returns doesn't abort
+// delivery. A send that arrives once the queue is full is dropped and
+// reported via OnError rather than blocking the caller.
+type Async struct {
+	Notifier Notifier
+	OnError  func(err error)
+
+	queue chan func() error
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	delivered int64
+	failed    int64
+	dropped   int64
+}
+
+// NewAsync starts a pool of workers workers delivering sends to notifier
+// through a queue of the given capacity. workers is clamped to at least 1.
+func NewAsync(notifier Notifier, queueSize, workers int) *Async {
+	if workers < 1 {
+		workers = 1
+	}
+	a := &Async{Notifier: notifier, queue: make(chan func() error, queueSize), done: make(chan struct{})}
+	for i := 0; i < workers; i++ {
+		a.wg.Add(1)
+		go a.run()
+	}
+	return a
+}
+
+// Notify enqueues message for asynchronous delivery, returning ErrQueueFull
+// immediately if the queue is at capacity.
+func (a *Async) Notify(_ context.Context, message string) error {
+	return a.enqueue(func() error {
+		return a.Notifier.Notify(context.Background(), message)
+	})
+}
+
+// NotifyApprovalRequest enqueues an approval notification if the wrapped
+// Notifier is an ApprovalNotifier, falling back to a plain Notify
+// otherwise.
+func (a *Async) NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error {
+	approvalNotifier, ok := a.Notifier.(ApprovalNotifier)
+	if !ok {
+		return a.Notify(ctx, "approval request: "+upload.ID)
+	}
+	return a.enqueue(func() error {
+		return approvalNotifier.NotifyApprovalRequest(context.Background(), upload, checklist)
+	})
+}
+
+// NotifyError enqueues an error notification if the wrapped Notifier is an
+// ErrorNotifier, falling back to a plain Notify otherwise.
+func (a *Async) NotifyError(ctx context.Context, message string) error {
+	errorNotifier, ok := a.Notifier.(ErrorNotifier)
+	if !ok {
+		return a.Notify(ctx, message)
+	}
+	return a.enqueue(func() error {
+		return errorNotifier.NotifyError(context.Background(), message)
+	})
+}
+
+func (a *Async) enqueue(job func() error) error {
+	select {
+	case a.queue <- job:
+		return nil
+	default:
+		atomic.AddInt64(&a.dropped, 1)
+		if a.OnError != nil {
+			a.OnError(ErrQueueFull)
+		}
+		return ErrQueueFull
+	}
+}
+
+func (a *Async) run() {
+	defer a.wg.Done()
+	for {
+		select {
+		case job := <-a.queue:
+			if err := job(); err != nil {
+				atomic.AddInt64(&a.failed, 1)
+				if a.OnError != nil {
+					a.OnError(err)
+				}
+			} else {
+				atomic.AddInt64(&a.delivered, 1)
+			}
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// Stats returns a snapshot of Async's delivery activity so far.
+func (a *Async) Stats() Stats {
+	return Stats{
+		Queued:    len(a.queue),
+		Delivered: atomic.LoadInt64(&a.delivered),
+		Failed:    atomic.LoadInt64(&a.failed),
+		Dropped:   atomic.LoadInt64(&a.dropped),
+	}
+}
+
+// Close stops the background workers and waits for any send already picked
+// up to finish. Sends still queued are discarded.
+func (a *Async) Close() {
+	close(a.done)
+	a.wg.Wait()
+}
@@ -0,0 +1,150 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/notify"
+)
+
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *recordingNotifier) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.messages...)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for condition")
+}
+
+func TestAsync_Notify(t *testing.T) {
+	t.Run("delivers on a background worker without blocking the caller", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		async := notify.NewAsync(notifier, 10, 1)
+		defer async.Close()
+
+		if err := async.Notify(context.Background(), "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		waitFor(t, func() bool { return len(notifier.received()) == 1 })
+		if stats := async.Stats(); stats.Delivered != 1 {
+			t.Fatalf("expected stats to record one delivery, got %+v", stats)
+		}
+	})
+
+	t.Run("returns ErrQueueFull once the bounded queue is saturated", func(t *testing.T) {
+		block := make(chan struct{})
+		blocked := &blockingNotifier{unblock: block}
+		async := notify.NewAsync(blocked, 1, 1)
+		defer func() {
+			close(block)
+			async.Close()
+		}()
+
+		// The first send is picked up by the single worker and blocks on
+		// <-block; the second fills the queue's single slot; the third has
+		// nowhere to go.
+		if err := async.Notify(context.Background(), "a"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitFor(t, blocked.started)
+		if err := async.Notify(context.Background(), "b"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var dropped error
+		async.OnError = func(err error) { dropped = err }
+		if err := async.Notify(context.Background(), "c"); !errors.Is(err, notify.ErrQueueFull) {
+			t.Fatalf("expected ErrQueueFull, got %v", err)
+		}
+		if !errors.Is(dropped, notify.ErrQueueFull) {
+			t.Fatalf("expected OnError to report ErrQueueFull, got %v", dropped)
+		}
+		if stats := async.Stats(); stats.Dropped != 1 {
+			t.Fatalf("expected stats to record one dropped send, got %+v", stats)
+		}
+	})
+
+	t.Run("a pool of workers delivers sends concurrently instead of one at a time", func(t *testing.T) {
+		block := make(chan struct{})
+		blocked := &blockingNotifier{unblock: block}
+		async := notify.NewAsync(blocked, 2, 2)
+		defer func() {
+			close(block)
+			async.Close()
+		}()
+
+		// With two workers, both of these sends should be picked up and
+		// block concurrently, leaving the queue empty rather than backed
+		// up behind a single worker.
+		if err := async.Notify(context.Background(), "a"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := async.Notify(context.Background(), "b"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitFor(t, func() bool { return blocked.concurrent() == 2 })
+	})
+}
+
+func TestAsync_NotifyApprovalRequest_FallsBackWhenUnsupported(t *testing.T) {
+	notifier := &recordingNotifier{}
+	async := notify.NewAsync(notifier, 10, 1)
+	defer async.Close()
+
+	upload := &models.Upload{ID: "1"}
+	if err := async.NotifyApprovalRequest(context.Background(), upload, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitFor(t, func() bool { return len(notifier.received()) == 1 })
+}
+
+type blockingNotifier struct {
+	mu      sync.Mutex
+	running int
+	unblock chan struct{}
+}
+
+func (b *blockingNotifier) Notify(_ context.Context, _ string) error {
+	b.mu.Lock()
+	b.running++
+	b.mu.Unlock()
+	<-b.unblock
+	return nil
+}
+
+func (b *blockingNotifier) started() bool {
+	return b.concurrent() >= 1
+}
+
+func (b *blockingNotifier) concurrent() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.running
+}
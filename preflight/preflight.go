@@ -0,0 +1,86 @@
+// Package preflight runs a set of named dependency checks (S3, CloudFront,
+// Cloudflare, Redis, Mongo) and produces a readiness report, so an operator
+// can see exactly which downstream is unreachable before the service starts
+// serving traffic instead of discovering it from the first failed request.
+package preflight
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Check is a single named dependency probe, e.g. "s3" running HeadBucket.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Result is the outcome of running a single Check.
+type Result struct {
+	Name     string        `json:"name"`
+	OK       bool          `json:"ok"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of running every Check in a single pass.
+type Report struct {
+	Ready   bool      `json:"ready"`
+	Checked time.Time `json:"checked"`
+	Results []Result  `json:"results"`
+}
+
+// Run executes every check in order and returns a report summarising the
+// outcome. A check that runs past ctx's deadline reports whatever error ctx
+// surfaces (e.g. context.DeadlineExceeded).
+func Run(ctx context.Context, checks ...Check) Report {
+	report := Report{Ready: true, Checked: time.Now()}
+
+	for _, c := range checks {
+		start := time.Now()
+		err := c.Check(ctx)
+		result := Result{Name: c.Name, OK: err == nil, Duration: time.Since(start)}
+		if err != nil {
+			result.Error = err.Error()
+			report.Ready = false
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// Prober re-runs a fixed set of Checks on demand and caches the last
+// report, so a readiness-gating middleware can consult it without paying
+// the cost of every dependency check on every request.
+type Prober struct {
+	Checks []Check
+
+	mu     sync.Mutex
+	report Report
+}
+
+// NewProber constructs a Prober over checks. The zero-value report (Ready:
+// false) is returned by Report until RunOnce has completed at least once,
+// so a service that gates on it fails closed until startup has actually
+// probed its dependencies.
+func NewProber(checks ...Check) *Prober {
+	return &Prober{Checks: checks}
+}
+
+// RunOnce runs every check and caches the resulting report.
+func (p *Prober) RunOnce(ctx context.Context) Report {
+	report := Run(ctx, p.Checks...)
+	p.mu.Lock()
+	p.report = report
+	p.mu.Unlock()
+	return report
+}
+
+// Report returns the most recently cached report from RunOnce.
+func (p *Prober) Report() Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.report
+}
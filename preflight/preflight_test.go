@@ -0,0 +1,73 @@
+package preflight_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/preflight"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("ready when every check succeeds", func(t *testing.T) {
+		report := preflight.Run(context.Background(),
+			preflight.Check{Name: "s3", Check: func(context.Context) error { return nil }},
+			preflight.Check{Name: "mongo", Check: func(context.Context) error { return nil }},
+		)
+		if !report.Ready {
+			t.Fatalf("expected the report to be ready, got %+v", report)
+		}
+		if len(report.Results) != 2 || !report.Results[0].OK || !report.Results[1].OK {
+			t.Fatalf("expected both checks to report OK, got %+v", report.Results)
+		}
+	})
+
+	t.Run("not ready when any check fails, but still runs the rest", func(t *testing.T) {
+		report := preflight.Run(context.Background(),
+			preflight.Check{Name: "s3", Check: func(context.Context) error { return errors.New("bucket not found") }},
+			preflight.Check{Name: "mongo", Check: func(context.Context) error { return nil }},
+		)
+		if report.Ready {
+			t.Fatalf("expected the report not to be ready, got %+v", report)
+		}
+		if len(report.Results) != 2 {
+			t.Fatalf("expected both checks to still run, got %+v", report.Results)
+		}
+		if report.Results[0].OK || report.Results[0].Error != "bucket not found" {
+			t.Fatalf("expected the s3 check to report its error, got %+v", report.Results[0])
+		}
+		if !report.Results[1].OK {
+			t.Fatalf("expected the mongo check to still report OK, got %+v", report.Results[1])
+		}
+	})
+}
+
+func TestProber(t *testing.T) {
+	t.Run("reports not ready until RunOnce has run", func(t *testing.T) {
+		p := preflight.NewProber(preflight.Check{Name: "s3", Check: func(context.Context) error { return nil }})
+		if report := p.Report(); report.Ready {
+			t.Fatalf("expected the zero-value report to be not-ready, got %+v", report)
+		}
+	})
+
+	t.Run("caches the report from the most recent RunOnce", func(t *testing.T) {
+		failing := true
+		p := preflight.NewProber(preflight.Check{Name: "s3", Check: func(context.Context) error {
+			if failing {
+				return errors.New("unreachable")
+			}
+			return nil
+		}})
+
+		p.RunOnce(context.Background())
+		if report := p.Report(); report.Ready {
+			t.Fatalf("expected the cached report to reflect the failure, got %+v", report)
+		}
+
+		failing = false
+		p.RunOnce(context.Background())
+		if report := p.Report(); !report.Ready {
+			t.Fatalf("expected the cached report to reflect recovery, got %+v", report)
+		}
+	})
+}
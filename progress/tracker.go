@@ -0,0 +1,85 @@
+// Package progress tracks bytes received for in-flight uploads so clients
+// can poll for a progress percentage on large, slow uploads.
+package progress
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Progress holds the state of a single in-flight upload.
+type Progress struct {
+	TotalSize     int64
+	bytesReceived atomic.Int64
+	done          atomic.Bool
+}
+
+// BytesReceived returns the number of bytes read so far.
+func (p *Progress) BytesReceived() int64 {
+	return p.bytesReceived.Load()
+}
+
+// Done reports whether the upload has finished (successfully or not).
+func (p *Progress) Done() bool {
+	return p.done.Load()
+}
+
+// Tracker holds Progress for every in-flight upload, keyed by an
+// upload-supplied token.
+type Tracker struct {
+	inFlight sync.Map // token -> *Progress
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Start registers a new in-flight upload of totalSize bytes under token,
+// returning its Progress for callers to inspect and a CountingReader that
+// updates it as body is read.
+func (t *Tracker) Start(token string, totalSize int64, body io.Reader) (*Progress, io.Reader) {
+	p := &Progress{TotalSize: totalSize}
+	t.inFlight.Store(token, p)
+	return p, &countingReader{r: body, progress: p}
+}
+
+// Get returns the Progress registered under token, if any.
+func (t *Tracker) Get(token string) (*Progress, bool) {
+	v, ok := t.inFlight.Load(token)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Progress), true
+}
+
+// Finish marks token's upload as complete and removes it from the tracker
+// after a short grace period isn't needed here: callers should keep polling
+// Done() before the entry disappears, so Finish leaves the entry in place
+// and only flips the done flag; Forget removes it entirely.
+func (t *Tracker) Finish(token string) {
+	if v, ok := t.inFlight.Load(token); ok {
+		v.(*Progress).done.Store(true)
+	}
+}
+
+// Forget removes token's tracked progress entirely.
+func (t *Tracker) Forget(token string) {
+	t.inFlight.Delete(token)
+}
+
+// countingReader wraps an io.Reader, updating Progress.bytesReceived as it
+// is read.
+type countingReader struct {
+	r        io.Reader
+	progress *Progress
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.progress.bytesReceived.Add(int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,73 @@
+package progress_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/progress"
+)
+
+func TestTracker_StartTracksBytesReadThroughReader(t *testing.T) {
+	tr := progress.NewTracker()
+
+	p, reader := tr.Start("token-1", 11, strings.NewReader("hello world"))
+
+	buf := make([]byte, 5)
+	if _, err := reader.Read(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.BytesReceived() != 5 {
+		t.Fatalf("expected 5 bytes received, got %d", p.BytesReceived())
+	}
+
+	if _, err := reader.Read(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.BytesReceived() != 10 {
+		t.Fatalf("expected 10 bytes received, got %d", p.BytesReceived())
+	}
+}
+
+func TestTracker_GetReturnsRegisteredProgress(t *testing.T) {
+	tr := progress.NewTracker()
+	tr.Start("token-1", 100, strings.NewReader(""))
+
+	p, ok := tr.Get("token-1")
+	if !ok {
+		t.Fatal("expected a registered progress for token-1")
+	}
+	if p.TotalSize != 100 {
+		t.Fatalf("expected total size 100, got %d", p.TotalSize)
+	}
+
+	if _, ok := tr.Get("unknown"); ok {
+		t.Fatal("expected no progress for an unregistered token")
+	}
+}
+
+func TestTracker_FinishMarksDoneWithoutRemoving(t *testing.T) {
+	tr := progress.NewTracker()
+	tr.Start("token-1", 10, strings.NewReader(""))
+
+	tr.Finish("token-1")
+
+	p, ok := tr.Get("token-1")
+	if !ok {
+		t.Fatal("expected Finish to leave the entry in place")
+	}
+	if !p.Done() {
+		t.Fatal("expected Done to be true after Finish")
+	}
+}
+
+func TestTracker_ForgetRemovesTheEntry(t *testing.T) {
+	tr := progress.NewTracker()
+	tr.Start("token-1", 10, strings.NewReader(""))
+	tr.Finish("token-1")
+
+	tr.Forget("token-1")
+
+	if _, ok := tr.Get("token-1"); ok {
+		t.Fatal("expected Forget to remove the entry")
+	}
+}
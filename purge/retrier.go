@@ -0,0 +1,120 @@
+// Package purge retries Cloudflare cache purges that failed while
+// publishing an upload, so a transient Cloudflare error doesn't leave a
+// published file's cache stale indefinitely. Like package digest and
+// package reconcile, it has no scheduling of its own: RetryOnce is
+// intended to be run periodically, e.g. as a scheduler.Job named
+// "purge-retry".
+package purge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Store is the subset of store.Store Retrier depends on. Duplicated from
+// store.Store to avoid an import cycle.
+type Store interface {
+	ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error)
+	UpdateUpload(ctx context.Context, upload *models.Upload) error
+}
+
+// Cloudflare purges a cache for the given URLs. Duplicated from
+// api.Purger to avoid an import cycle.
+type Cloudflare interface {
+	PurgeCache(ctx context.Context, urls []string) error
+}
+
+// Stats summarises the outcome of the most recently completed retry pass.
+type Stats struct {
+	LastRunAt time.Time `json:"last_run_at"`
+	Attempted int       `json:"attempted"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+}
+
+// Retrier retries the Cloudflare purge recorded in an upload's
+// models.PurgeRetry, waiting Backoff between attempts for the same upload.
+type Retrier struct {
+	Store      Store
+	Cloudflare Cloudflare
+	Backoff    time.Duration
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewRetrier constructs a Retrier backed by store and cloudflare, waiting
+// backoff between retries of the same upload's purge.
+func NewRetrier(store Store, cloudflare Cloudflare, backoff time.Duration) *Retrier {
+	return &Retrier{Store: store, Cloudflare: cloudflare, Backoff: backoff}
+}
+
+// RetryOnce retries every upload whose PurgeRetry is due, clearing it on
+// success and otherwise recording the failure and rescheduling
+// NextRetryAt.
+func (r *Retrier) RetryOnce(ctx context.Context) error {
+	uploads, err := r.Store.ListUploads(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list uploads for purge retry: %w", err)
+	}
+
+	stats := Stats{LastRunAt: time.Now()}
+	var errs []error
+
+	for _, upload := range uploads {
+		if upload.PurgeRetry == nil || stats.LastRunAt.Before(upload.PurgeRetry.NextRetryAt) {
+			continue
+		}
+		stats.Attempted++
+
+		upload.PurgeRetry.Attempts++
+		if err := r.Cloudflare.PurgeCache(ctx, upload.PurgeRetry.URLs); err != nil {
+			stats.Failed++
+			upload.PurgeRetry.LastError = err.Error()
+			upload.PurgeRetry.NextRetryAt = stats.LastRunAt.Add(r.Backoff)
+		} else {
+			stats.Succeeded++
+			upload.PurgeRetry = nil
+		}
+
+		if err := r.Store.UpdateUpload(ctx, upload); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update upload %s after purge retry: %w", upload.ID, err))
+		}
+	}
+
+	r.mu.Lock()
+	r.stats = stats
+	r.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the most recently completed retry pass. The
+// zero value means no pass has completed yet.
+func (r *Retrier) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// Pending returns every upload with a queued purge retry, for the admin API
+// to list without duplicating the store scan RetryOnce already does.
+func (r *Retrier) Pending(ctx context.Context) ([]*models.Upload, error) {
+	uploads, err := r.Store.ListUploads(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploads for pending purge retries: %w", err)
+	}
+
+	var pending []*models.Upload
+	for _, upload := range uploads {
+		if upload.PurgeRetry != nil {
+			pending = append(pending, upload)
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,116 @@
+package purge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/purge"
+)
+
+type fakeStore struct {
+	uploads []*models.Upload
+	updated []*models.Upload
+}
+
+func (s *fakeStore) ListUploads(_ context.Context, _ string) ([]*models.Upload, error) {
+	return s.uploads, nil
+}
+
+func (s *fakeStore) UpdateUpload(_ context.Context, upload *models.Upload) error {
+	s.updated = append(s.updated, upload)
+	return nil
+}
+
+type fakeCloudflare struct {
+	err error
+}
+
+func (c *fakeCloudflare) PurgeCache(_ context.Context, _ []string) error {
+	return c.err
+}
+
+func TestRetrier_RetryOnce(t *testing.T) {
+	t.Run("clears PurgeRetry on a successful retry", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:         "1",
+			PurgeRetry: &models.PurgeRetry{URLs: []string{"https://example.com/a.csv"}, NextRetryAt: time.Now().Add(-time.Minute)},
+		}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		r := purge.NewRetrier(store, &fakeCloudflare{}, time.Minute)
+
+		if err := r.RetryOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if upload.PurgeRetry != nil {
+			t.Fatalf("expected PurgeRetry to be cleared, got %+v", upload.PurgeRetry)
+		}
+		if stats := r.Stats(); stats.Attempted != 1 || stats.Succeeded != 1 || stats.Failed != 0 {
+			t.Fatalf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("reschedules and records the error on a failed retry", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:         "1",
+			PurgeRetry: &models.PurgeRetry{URLs: []string{"https://example.com/a.csv"}, Attempts: 1, NextRetryAt: time.Now().Add(-time.Minute)},
+		}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		r := purge.NewRetrier(store, &fakeCloudflare{err: errors.New("cloudflare unavailable")}, time.Minute)
+
+		if err := r.RetryOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if upload.PurgeRetry == nil {
+			t.Fatal("expected PurgeRetry to remain set")
+		}
+		if upload.PurgeRetry.Attempts != 2 {
+			t.Fatalf("expected attempts to be incremented to 2, got %d", upload.PurgeRetry.Attempts)
+		}
+		if upload.PurgeRetry.LastError != "cloudflare unavailable" {
+			t.Fatalf("expected the last error to be recorded, got %q", upload.PurgeRetry.LastError)
+		}
+		if !upload.PurgeRetry.NextRetryAt.After(time.Now()) {
+			t.Fatal("expected NextRetryAt to be rescheduled into the future")
+		}
+	})
+
+	t.Run("skips a retry that isn't due yet", func(t *testing.T) {
+		upload := &models.Upload{
+			ID:         "1",
+			PurgeRetry: &models.PurgeRetry{URLs: []string{"https://example.com/a.csv"}, NextRetryAt: time.Now().Add(time.Hour)},
+		}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		r := purge.NewRetrier(store, &fakeCloudflare{}, time.Minute)
+
+		if err := r.RetryOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if upload.PurgeRetry == nil {
+			t.Fatal("expected the not-yet-due PurgeRetry to be left untouched")
+		}
+		if stats := r.Stats(); stats.Attempted != 0 {
+			t.Fatalf("expected no attempts, got %+v", stats)
+		}
+	})
+}
+
+func TestRetrier_Pending(t *testing.T) {
+	withRetry := &models.Upload{ID: "1", PurgeRetry: &models.PurgeRetry{URLs: []string{"https://example.com/a.csv"}}}
+	withoutRetry := &models.Upload{ID: "2"}
+	store := &fakeStore{uploads: []*models.Upload{withRetry, withoutRetry}}
+	r := purge.NewRetrier(store, &fakeCloudflare{}, time.Minute)
+
+	pending, err := r.Pending(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != "1" {
+		t.Fatalf("expected only upload 1 to be pending, got %+v", pending)
+	}
+}
@@ -0,0 +1,189 @@
+// Package reconcile periodically verifies that approved and published
+// uploads still exist in S3 with matching checksums, flagging any drift so
+// it surfaces via the stats endpoint and notifications instead of being
+// discovered only when a reader hits a stale or missing file.
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Downloader fetches the current contents of an S3 object. Duplicated from
+// api.Downloader to avoid an import cycle.
+type Downloader interface {
+	DownloadFile(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Store is the subset of store.Store the reconciler depends on. Duplicated
+// from store.Store to avoid an import cycle.
+type Store interface {
+	ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error)
+	UpdateUpload(ctx context.Context, upload *models.Upload) error
+}
+
+// Notifier reports drift findings as they're detected. Duplicated from
+// api.Notifier to avoid an import cycle.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// Stats summarises the outcome of the most recently completed
+// reconciliation pass.
+type Stats struct {
+	LastRunAt  time.Time `json:"last_run_at"`
+	Checked    int       `json:"checked"`
+	Missing    int       `json:"missing"`
+	Mismatched int       `json:"mismatched"`
+	// Failed counts uploads whose drift could not be determined (e.g. a
+	// transient S3 read error) or whose finding could not be persisted or
+	// notified, so a run degraded by infrastructure failures is visible
+	// rather than silently reported as "all clear".
+	Failed int `json:"failed"`
+}
+
+// Reconciler periodically verifies that every approved or published
+// upload's TargetKey still exists in S3 with the checksum recorded at
+// publish time, flagging missing objects or checksum mismatches as drift on
+// the upload record. A nil Notifier disables reporting; drift is still
+// recorded on the upload and visible via Stats.
+type Reconciler struct {
+	Store    Store
+	S3       Downloader
+	Notifier Notifier
+	Interval time.Duration
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewReconciler constructs a Reconciler backed by store and s3. A zero
+// interval disables Run; ReconcileOnce can still be called directly, e.g.
+// from an ops command.
+func NewReconciler(store Store, s3 Downloader, notifier Notifier, interval time.Duration) *Reconciler {
+	return &Reconciler{Store: store, S3: s3, Notifier: notifier, Interval: interval}
+}
+
+// Run executes ReconcileOnce every Interval until ctx is cancelled. It is a
+// no-op if Interval is zero.
+func (r *Reconciler) Run(ctx context.Context) {
+	if r.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.ReconcileOnce(ctx)
+		}
+	}
+}
+
+// ReconcileOnce checks every approved or published upload's TargetKey
+// against S3, flagging missing objects or checksum mismatches as drift on
+// the upload record and, when a Notifier is configured, reporting each
+// finding immediately.
+func (r *Reconciler) ReconcileOnce(ctx context.Context) error {
+	uploads, err := r.Store.ListUploads(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list uploads for reconciliation: %w", err)
+	}
+
+	stats := Stats{LastRunAt: time.Now()}
+	var errs []error
+
+	for _, upload := range uploads {
+		if !reconcilable(upload.Status) {
+			continue
+		}
+		stats.Checked++
+
+		drift := r.checkUpload(ctx, upload)
+		if drift == "" {
+			continue
+		}
+
+		switch drift {
+		case models.DriftMissing:
+			stats.Missing++
+		case models.DriftChecksumMismatch:
+			stats.Mismatched++
+		case models.DriftCheckFailed:
+			stats.Failed++
+		}
+
+		upload.Drift = drift
+		upload.LastReconciledAt = stats.LastRunAt
+		if err := r.Store.UpdateUpload(ctx, upload); err != nil {
+			stats.Failed++
+			errs = append(errs, fmt.Errorf("failed to record drift for upload %s: %w", upload.ID, err))
+			continue
+		}
+
+		if r.Notifier != nil {
+			if err := r.Notifier.Notify(ctx, fmt.Sprintf("drift detected for upload %s (%s): %s", upload.ID, upload.TargetKey, drift)); err != nil {
+				stats.Failed++
+				errs = append(errs, fmt.Errorf("failed to notify of drift for upload %s: %w", upload.ID, err))
+			}
+		}
+	}
+
+	r.mu.Lock()
+	r.stats = stats
+	r.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the most recently completed reconciliation
+// pass. The zero value means no pass has completed yet.
+func (r *Reconciler) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// checkUpload downloads upload's TargetKey and compares it against
+// upload.Checksum, returning the drift found, or "" if none.
+func (r *Reconciler) checkUpload(ctx context.Context, upload *models.Upload) models.DriftStatus {
+	rc, err := r.S3.DownloadFile(ctx, upload.TargetKey)
+	if err != nil {
+		return models.DriftMissing
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		// The object was found (DownloadFile succeeded); a failure to read
+		// its body is a transient error, not evidence the object is gone.
+		return models.DriftCheckFailed
+	}
+
+	if upload.Checksum == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != upload.Checksum {
+		return models.DriftChecksumMismatch
+	}
+
+	return ""
+}
+
+func reconcilable(status models.Status) bool {
+	return status == models.StatusApproved || status == models.StatusPublished
+}
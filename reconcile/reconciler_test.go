@@ -0,0 +1,180 @@
+package reconcile_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/reconcile"
+)
+
+type fakeStore struct {
+	uploads   []*models.Upload
+	updated   []*models.Upload
+	updateErr error
+}
+
+func (s *fakeStore) ListUploads(_ context.Context, _ string) ([]*models.Upload, error) {
+	return s.uploads, nil
+}
+
+func (s *fakeStore) UpdateUpload(_ context.Context, upload *models.Upload) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	s.updated = append(s.updated, upload)
+	return nil
+}
+
+type fakeDownloader struct {
+	objects map[string][]byte
+	// readErrs, when set for a key, is returned when reading the object's
+	// body rather than an error from DownloadFile itself, exercising the
+	// "found but couldn't be read" path distinct from "not found".
+	readErrs map[string]error
+}
+
+func (d *fakeDownloader) DownloadFile(_ context.Context, key string) (io.ReadCloser, error) {
+	content, ok := d.objects[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	if err := d.readErrs[key]; err != nil {
+		return io.NopCloser(&errorReader{err: err}), nil
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+type errorReader struct{ err error }
+
+func (r *errorReader) Read([]byte) (int, error) { return 0, r.err }
+
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestReconciler_ReconcileOnce(t *testing.T) {
+	t.Run("flags a missing object as drift and notifies", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusApproved, Checksum: checksum([]byte("data"))}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeDownloader{objects: map[string][]byte{}}
+		notifier := &recordingNotifier{}
+		r := reconcile.NewReconciler(store, s3, notifier, 0)
+
+		if err := r.ReconcileOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(store.updated) != 1 || store.updated[0].Drift != models.DriftMissing {
+			t.Fatalf("expected upload to be flagged missing, got %+v", store.updated)
+		}
+		if len(notifier.messages) != 1 {
+			t.Fatalf("expected one drift notification, got %d", len(notifier.messages))
+		}
+		if stats := r.Stats(); stats.Checked != 1 || stats.Missing != 1 {
+			t.Fatalf("expected stats to record one missing object, got %+v", stats)
+		}
+	})
+
+	t.Run("flags a checksum mismatch as drift", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusPublished, Checksum: checksum([]byte("expected"))}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeDownloader{objects: map[string][]byte{"cpi/data.csv": []byte("actual")}}
+		r := reconcile.NewReconciler(store, s3, nil, 0)
+
+		if err := r.ReconcileOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(store.updated) != 1 || store.updated[0].Drift != models.DriftChecksumMismatch {
+			t.Fatalf("expected upload to be flagged as a checksum mismatch, got %+v", store.updated)
+		}
+	})
+
+	t.Run("matching checksum is not drift", func(t *testing.T) {
+		content := []byte("matches")
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusApproved, Checksum: checksum(content)}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeDownloader{objects: map[string][]byte{"cpi/data.csv": content}}
+		r := reconcile.NewReconciler(store, s3, nil, 0)
+
+		if err := r.ReconcileOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(store.updated) != 0 {
+			t.Fatalf("expected no updates for a matching upload, got %+v", store.updated)
+		}
+	})
+
+	t.Run("a read failure degrades to check_failed instead of being reported as missing", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusApproved, Checksum: checksum([]byte("data"))}
+		store := &fakeStore{uploads: []*models.Upload{upload}}
+		s3 := &fakeDownloader{
+			objects:  map[string][]byte{"cpi/data.csv": []byte("data")},
+			readErrs: map[string]error{"cpi/data.csv": errors.New("connection reset")},
+		}
+		r := reconcile.NewReconciler(store, s3, nil, 0)
+
+		if err := r.ReconcileOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(store.updated) != 1 || store.updated[0].Drift != models.DriftCheckFailed {
+			t.Fatalf("expected upload to be flagged check_failed, not missing, got %+v", store.updated)
+		}
+		if stats := r.Stats(); stats.Failed != 1 || stats.Missing != 0 {
+			t.Fatalf("expected stats to record one failed check, got %+v", stats)
+		}
+	})
+
+	t.Run("a failure to persist drift is reported rather than swallowed", func(t *testing.T) {
+		upload := &models.Upload{ID: "1", TargetKey: "cpi/data.csv", Status: models.StatusApproved, Checksum: checksum([]byte("data"))}
+		store := &fakeStore{uploads: []*models.Upload{upload}, updateErr: errors.New("mongo unavailable")}
+		s3 := &fakeDownloader{objects: map[string][]byte{}}
+		r := reconcile.NewReconciler(store, s3, nil, 0)
+
+		err := r.ReconcileOnce(context.Background())
+		if err == nil {
+			t.Fatalf("expected the store failure to be reported")
+		}
+		if stats := r.Stats(); stats.Failed != 1 {
+			t.Fatalf("expected stats to record one failure, got %+v", stats)
+		}
+	})
+
+	t.Run("skips pending and rejected uploads", func(t *testing.T) {
+		uploads := []*models.Upload{
+			{ID: "1", TargetKey: "cpi/pending.csv", Status: models.StatusPending},
+			{ID: "2", TargetKey: "cpi/rejected.csv", Status: models.StatusRejected},
+		}
+		store := &fakeStore{uploads: uploads}
+		s3 := &fakeDownloader{objects: map[string][]byte{}}
+		r := reconcile.NewReconciler(store, s3, nil, 0)
+
+		if err := r.ReconcileOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stats := r.Stats(); stats.Checked != 0 {
+			t.Fatalf("expected pending/rejected uploads to be skipped, got %+v", stats)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+// Package scan defines a pluggable antivirus scanning capability and a
+// checksum-keyed cache in front of it, so identical file content isn't
+// rescanned every time it's uploaded.
+package scan
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// cacheKeyPrefix namespaces scan verdicts within the shared temp storage
+// keyspace, so they can't collide with staged upload keys.
+const cacheKeyPrefix = "scan-verdict:"
+
+// Verdict is the outcome of scanning a file's content for malware.
+type Verdict struct {
+	Clean bool `json:"clean"`
+	// Signature names the threat that was detected. Empty when Clean.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Scanner scans file content for malware. No implementation exists yet;
+// this is the seam a real antivirus engine (e.g. a ClamAV client) would
+// implement.
+type Scanner interface {
+	Scan(ctx context.Context, data []byte) (Verdict, error)
+}
+
+// CachingScanner wraps a Scanner, short-circuiting Scan for content whose
+// sha256 checksum was already scanned within TTL. Verdicts are cached in
+// Cache keyed by checksum, so a file uploaded more than once (or by more
+// than one user) is only ever scanned once per TTL window.
+type CachingScanner struct {
+	Scanner Scanner
+	Cache   temp.Storage
+	TTL     time.Duration
+
+	// OnCacheHit, if set, is called whenever Scan is short-circuited by a
+	// cached verdict, so the caller can record the hit (e.g. as an audit
+	// log entry). It is not called on a cache miss, even though that scan
+	// result is also cached for next time.
+	OnCacheHit func(ctx context.Context, checksum string, verdict Verdict)
+}
+
+// NewCachingScanner returns a CachingScanner delegating to scanner on a
+// cache miss, caching verdicts in cache for ttl.
+func NewCachingScanner(scanner Scanner, cache temp.Storage, ttl time.Duration) *CachingScanner {
+	return &CachingScanner{Scanner: scanner, Cache: cache, TTL: ttl}
+}
+
+// Scan returns the cached verdict for data's sha256 checksum if one exists,
+// otherwise scans data with the wrapped Scanner and caches the result.
+func (c *CachingScanner) Scan(ctx context.Context, data []byte) (Verdict, error) {
+	checksum := checksumOf(data)
+
+	if verdict, ok := c.lookup(ctx, checksum); ok {
+		if c.OnCacheHit != nil {
+			c.OnCacheHit(ctx, checksum, verdict)
+		}
+		return verdict, nil
+	}
+
+	verdict, err := c.Scanner.Scan(ctx, data)
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	c.store(ctx, checksum, verdict)
+	return verdict, nil
+}
+
+func (c *CachingScanner) lookup(ctx context.Context, checksum string) (Verdict, bool) {
+	rc, err := c.Cache.Get(ctx, cacheKeyPrefix+checksum)
+	if err != nil {
+		return Verdict{}, false
+	}
+	defer rc.Close()
+
+	var verdict Verdict
+	if err := json.NewDecoder(rc).Decode(&verdict); err != nil {
+		return Verdict{}, false
+	}
+	return verdict, true
+}
+
+// store caches verdict for checksum. Caching is best-effort: a failure here
+// only means the next identical upload gets rescanned, not that this scan
+// fails.
+func (c *CachingScanner) store(ctx context.Context, checksum string, verdict Verdict) {
+	encoded, err := json.Marshal(verdict)
+	if err != nil {
+		return
+	}
+
+	_ = c.Cache.Store(ctx, cacheKeyPrefix+checksum, bytes.NewReader(encoded), int64(len(encoded)), c.TTL)
+}
+
+func checksumOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
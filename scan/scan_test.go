@@ -0,0 +1,129 @@
+package scan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/scan"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+type fakeScanner struct {
+	calls   int
+	verdict scan.Verdict
+	err     error
+}
+
+func (f *fakeScanner) Scan(_ context.Context, _ []byte) (scan.Verdict, error) {
+	f.calls++
+	return f.verdict, f.err
+}
+
+func TestCachingScanner_Scan(t *testing.T) {
+	t.Run("scans once and caches the verdict for identical content", func(t *testing.T) {
+		scanner := &fakeScanner{verdict: scan.Verdict{Clean: true}}
+		cache := temp.NewInMemoryStorage(0, 0)
+		cs := scan.NewCachingScanner(scanner, cache, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			verdict, err := cs.Scan(context.Background(), []byte("hello world"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !verdict.Clean {
+				t.Fatalf("expected a clean verdict, got %+v", verdict)
+			}
+		}
+
+		if scanner.calls != 1 {
+			t.Fatalf("expected the scanner to be called once, got %d", scanner.calls)
+		}
+	})
+
+	t.Run("scans different content separately", func(t *testing.T) {
+		scanner := &fakeScanner{verdict: scan.Verdict{Clean: true}}
+		cache := temp.NewInMemoryStorage(0, 0)
+		cs := scan.NewCachingScanner(scanner, cache, time.Minute)
+
+		if _, err := cs.Scan(context.Background(), []byte("one")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cs.Scan(context.Background(), []byte("two")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if scanner.calls != 2 {
+			t.Fatalf("expected the scanner to be called twice, got %d", scanner.calls)
+		}
+	})
+
+	t.Run("caches a known-bad verdict too", func(t *testing.T) {
+		scanner := &fakeScanner{verdict: scan.Verdict{Clean: false, Signature: "EICAR-Test-File"}}
+		cache := temp.NewInMemoryStorage(0, 0)
+		cs := scan.NewCachingScanner(scanner, cache, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			verdict, err := cs.Scan(context.Background(), []byte("bad content"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if verdict.Clean || verdict.Signature != "EICAR-Test-File" {
+				t.Fatalf("expected the cached bad verdict to be returned, got %+v", verdict)
+			}
+		}
+
+		if scanner.calls != 1 {
+			t.Fatalf("expected the scanner to be called once, got %d", scanner.calls)
+		}
+	})
+
+	t.Run("does not cache a scan error", func(t *testing.T) {
+		scanner := &fakeScanner{err: errors.New("scanner unavailable")}
+		cache := temp.NewInMemoryStorage(0, 0)
+		cs := scan.NewCachingScanner(scanner, cache, time.Minute)
+
+		if _, err := cs.Scan(context.Background(), []byte("hello")); err == nil {
+			t.Fatalf("expected an error")
+		}
+		if _, err := cs.Scan(context.Background(), []byte("hello")); err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		if scanner.calls != 2 {
+			t.Fatalf("expected the scanner to be retried after an error, got %d calls", scanner.calls)
+		}
+	})
+
+	t.Run("invokes OnCacheHit only on a cache hit", func(t *testing.T) {
+		scanner := &fakeScanner{verdict: scan.Verdict{Clean: true}}
+		cache := temp.NewInMemoryStorage(0, 0)
+		cs := scan.NewCachingScanner(scanner, cache, time.Minute)
+
+		var hits int
+		cs.OnCacheHit = func(_ context.Context, checksum string, verdict scan.Verdict) {
+			hits++
+			if checksum == "" {
+				t.Fatalf("expected a non-empty checksum")
+			}
+			if !verdict.Clean {
+				t.Fatalf("expected the cached verdict to be passed through, got %+v", verdict)
+			}
+		}
+
+		if _, err := cs.Scan(context.Background(), []byte("hello")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hits != 0 {
+			t.Fatalf("expected no cache hit on first scan, got %d", hits)
+		}
+
+		if _, err := cs.Scan(context.Background(), []byte("hello")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if hits != 1 {
+			t.Fatalf("expected exactly one cache hit, got %d", hits)
+		}
+	})
+}
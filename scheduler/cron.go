@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// matchesCron reports whether t falls within the minute described by expr, a
+// standard 5-field cron expression (minute hour day-of-month month
+// day-of-weekday). Each field accepts "*", a comma-separated list of
+// integers, or a "*/step" stride; ranges ("1-5") are not supported. Seconds
+// are ignored: a job fires at most once per matching minute, since Scheduler
+// only ticks once a minute.
+func matchesCron(expr string, t time.Time) (bool, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return false, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	values := [5]int{t.Minute(), t.Hour(), t.Day(), int(t.Month()), int(t.Weekday())}
+	for i, field := range fields {
+		ok, err := matchesField(field, values[i])
+		if err != nil {
+			return false, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesField reports whether value satisfies a single cron field.
+func matchesField(field string, value int) (bool, error) {
+	if field == "*" {
+		return true, nil
+	}
+
+	if step, ok := strings.CutPrefix(field, "*/"); ok {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return false, fmt.Errorf("invalid step %q", field)
+		}
+		return value%n == 0, nil
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return false, fmt.Errorf("invalid value %q", part)
+		}
+		if n == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchesCron(t *testing.T) {
+	// 2026-08-08 is a Saturday.
+	morning := time.Date(2026, 8, 8, 8, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"every minute matches", "* * * * *", morning, true},
+		{"exact match", "0 8 * * *", morning, true},
+		{"wrong minute", "5 8 * * *", morning, false},
+		{"wrong hour", "0 9 * * *", morning, false},
+		{"comma list matches", "0 6,8,10 * * *", morning, true},
+		{"comma list misses", "0 6,10 * * *", morning, false},
+		{"step matches", "*/15 * * * *", morning, true},
+		{"step misses", "*/7 * * * *", time.Date(2026, 8, 8, 8, 5, 0, 0, time.UTC), false},
+		{"day of week matches", "0 8 * * 6", morning, true},
+		{"day of week misses", "0 8 * * 1", morning, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := matchesCron(tc.expr, tc.t)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("matchesCron(%q, %v) = %v, want %v", tc.expr, tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesCron_InvalidExpression(t *testing.T) {
+	if _, err := matchesCron("* * *", time.Now()); err == nil {
+		t.Fatal("expected an error for a malformed cron expression")
+	}
+}
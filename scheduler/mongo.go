@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoElector is an Elector backed by a single lease document in a
+// MongoDB collection, for deployments running MongoDB (the primary upload
+// store) without Redis. Leadership is a lease: whichever replica most
+// recently won the upsert in IsLeader holds it until TTL after its last
+// successful call, after which any replica can claim it.
+type MongoElector struct {
+	collection *mongo.Collection
+	leaseID    string
+	instanceID string
+	ttl        time.Duration
+}
+
+// NewMongoElector returns a MongoElector contending for leaseID within
+// collection, identifying this replica as instanceID (e.g. a pod name or
+// generated UUID).
+func NewMongoElector(collection *mongo.Collection, leaseID, instanceID string, ttl time.Duration) *MongoElector {
+	return &MongoElector{collection: collection, leaseID: leaseID, instanceID: instanceID, ttl: ttl}
+}
+
+// InstanceID reports this replica's identity, satisfying InstanceIdentifier.
+func (e *MongoElector) InstanceID() string {
+	return e.instanceID
+}
+
+// IsLeader attempts to acquire or renew the lease identified by e.leaseID:
+// the update applies if no lease document exists yet, the existing one has
+// expired, or it's already held by this replica. Losing the race for a
+// brand new lease surfaces as a duplicate key error on the upsert, which is
+// reported as "not leader" rather than an error.
+func (e *MongoElector) IsLeader(ctx context.Context) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": e.leaseID,
+		"$or": bson.A{
+			bson.M{"holder": e.instanceID},
+			bson.M{"lease_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": e.instanceID, "lease_until": now.Add(e.ttl)}}
+
+	err := e.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetUpsert(true)).Err()
+	switch {
+	case err == nil:
+		return true, nil
+	case err == mongo.ErrNoDocuments, mongo.IsDuplicateKeyError(err):
+		// Either an unexpired lease is held by another replica, or another
+		// replica won the race to create a brand new one.
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to acquire scheduler leader lease: %w", err)
+	}
+}
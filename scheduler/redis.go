@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisElector is an Elector backed by a single Redis key, so a
+// multi-replica deployment can share one Scheduler configuration without
+// every replica running each due Job. Leadership is held by whichever
+// replica most recently won SetNX on Key; IsLeader renews the lease on
+// every call so a live leader never loses it to expiry mid-deployment,
+// while a leader that stops calling IsLeader (e.g. it crashed) releases the
+// key automatically after TTL.
+type RedisElector struct {
+	client *redis.Client
+	key    string
+	ttl    time.Duration
+	// instanceID identifies this replica in Redis, so IsLeader can tell its
+	// own held lease apart from one held by another replica.
+	instanceID string
+}
+
+// NewRedisElector returns a RedisElector contending for key, identifying
+// this replica as instanceID (e.g. a pod name or generated UUID).
+func NewRedisElector(client *redis.Client, key, instanceID string, ttl time.Duration) *RedisElector {
+	return &RedisElector{client: client, key: key, ttl: ttl, instanceID: instanceID}
+}
+
+// InstanceID reports this replica's identity, satisfying InstanceIdentifier.
+func (e *RedisElector) InstanceID() string {
+	return e.instanceID
+}
+
+// IsLeader attempts to acquire or renew leadership of e.key.
+func (e *RedisElector) IsLeader(ctx context.Context) (bool, error) {
+	acquired, err := e.client.SetNX(ctx, e.key, e.instanceID, e.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire scheduler leader lock: %w", err)
+	}
+	if acquired {
+		return true, nil
+	}
+
+	holder, err := e.client.Get(ctx, e.key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			// The key expired between SetNX and Get; leadership is up for
+			// grabs again next tick.
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read scheduler leader lock: %w", err)
+	}
+	if holder != e.instanceID {
+		return false, nil
+	}
+
+	if err := e.client.Expire(ctx, e.key, e.ttl).Err(); err != nil {
+		return false, fmt.Errorf("failed to renew scheduler leader lock: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,85 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ONSdigital/dis-imf-uploader/scheduler"
+)
+
+func newTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	return redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestRedisElector(t *testing.T) {
+	client := newTestRedis(t)
+	ctx := context.Background()
+
+	first := scheduler.NewRedisElector(client, "scheduler:leader", "instance-1", time.Minute)
+	second := scheduler.NewRedisElector(client, "scheduler:leader", "instance-2", time.Minute)
+
+	leader, err := first.IsLeader(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !leader {
+		t.Fatal("expected the first contender to win an unclaimed lease")
+	}
+
+	leader, err = second.IsLeader(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if leader {
+		t.Fatal("expected the second contender to lose to the held lease")
+	}
+
+	leader, err = first.IsLeader(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !leader {
+		t.Fatal("expected the holder to renew its own lease")
+	}
+
+	if first.InstanceID() != "instance-1" {
+		t.Fatalf("expected InstanceID %q, got %q", "instance-1", first.InstanceID())
+	}
+}
+
+func TestRedisElector_ClaimsAnExpiredLease(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+
+	first := scheduler.NewRedisElector(client, "scheduler:leader", "instance-1", time.Millisecond)
+	second := scheduler.NewRedisElector(client, "scheduler:leader", "instance-2", time.Minute)
+
+	if leader, err := first.IsLeader(ctx); err != nil || !leader {
+		t.Fatalf("expected the first contender to win, got leader=%v err=%v", leader, err)
+	}
+
+	mr.FastForward(10 * time.Millisecond)
+
+	leader, err := second.IsLeader(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !leader {
+		t.Fatal("expected the second contender to claim the expired lease")
+	}
+}
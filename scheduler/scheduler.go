@@ -0,0 +1,207 @@
+// Package scheduler provides a single, shared cron-style trigger for the
+// service's background jobs (reconciliation, digests, cleanup, reminders),
+// replacing the fixed-interval tickers those jobs would otherwise each roll
+// individually. It adds three things a plain ticker doesn't: cron
+// expressions read from config, jitter to avoid every replica hitting a
+// dependency in the same instant, and leader election so a job configured
+// on every replica of a multi-replica deployment still runs only once.
+package scheduler
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Elector decides whether this replica currently holds the right to run
+// scheduled jobs. A nil Elector means every replica is treated as the
+// leader, matching a single-replica deployment.
+type Elector interface {
+	IsLeader(ctx context.Context) (bool, error)
+}
+
+// InstanceIdentifier is implemented by an Elector that can report the
+// identity it contends for leadership under, so LeadershipStatus can report
+// who currently holds it. Not all Electors need to support it; check via a
+// type assertion.
+type InstanceIdentifier interface {
+	InstanceID() string
+}
+
+// LeadershipStatus is the most recent outcome of Scheduler's per-minute
+// leadership check, cached so it can be reported (e.g. via an admin
+// endpoint) without triggering an extra round trip to the Elector's
+// backing store.
+type LeadershipStatus struct {
+	// IsLeader is true if this replica held leadership as of CheckedAt. It
+	// is always true, and CheckedAt always zero, when no Elector is
+	// configured.
+	IsLeader bool
+	// InstanceID is this replica's identity, populated only when Elector
+	// implements InstanceIdentifier.
+	InstanceID string
+	CheckedAt  time.Time
+}
+
+// Job is a single named unit of scheduled work.
+type Job struct {
+	// Name identifies the job in Run history and log output.
+	Name string
+	// Cron is a 5-field cron expression (see matchesCron); an empty Cron
+	// disables the job.
+	Cron string
+	// Jitter, when set, delays each run by a random duration in [0, Jitter)
+	// so replicas sharing a Cron don't all call downstream dependencies at
+	// exactly the same instant.
+	Jitter time.Duration
+	// Run performs the job's work.
+	Run func(ctx context.Context) error
+}
+
+// Run records the outcome of a single execution of a Job, kept in
+// Scheduler's in-memory history for the admin jobs endpoint.
+type Run struct {
+	JobName     string    `json:"job_name"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	// Err is the error Run returned, or "" on success.
+	Err string `json:"error,omitempty"`
+}
+
+// historyLimit bounds how many Run records Scheduler retains per Job,
+// keeping the admin endpoint's response bounded without needing a store.
+const historyLimit = 20
+
+// Scheduler ticks once a minute, running every enabled Job whose Cron
+// matches the current minute. It has no persistence: history is lost on
+// restart, and Jobs must be supplied fully constructed by the caller (e.g.
+// service.go), the same wiring convention as reconcile.Reconciler.
+type Scheduler struct {
+	Jobs    []Job
+	Elector Elector
+
+	mu         sync.Mutex
+	history    map[string][]Run
+	leadership LeadershipStatus
+}
+
+// NewScheduler constructs a Scheduler running jobs, electing leadership via
+// elector. A nil elector means this replica always runs due jobs.
+func NewScheduler(jobs []Job, elector Elector) *Scheduler {
+	return &Scheduler{
+		Jobs:       jobs,
+		Elector:    elector,
+		history:    make(map[string][]Run),
+		leadership: LeadershipStatus{IsLeader: elector == nil},
+	}
+}
+
+// Run ticks once a minute until ctx is cancelled, executing every due Job.
+// Each Job runs in its own goroutine so a slow or jittered job never delays
+// the tick for the others.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+// tick evaluates leadership for this minute and fires every Job due at now,
+// provided this replica holds leadership.
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	if !s.checkLeadership(ctx, now) {
+		return
+	}
+
+	for _, job := range s.Jobs {
+		if job.Cron == "" {
+			continue
+		}
+		matched, err := matchesCron(job.Cron, now)
+		if err != nil || !matched {
+			continue
+		}
+		go s.runJob(ctx, job, now)
+	}
+}
+
+// checkLeadership refreshes s.leadership via s.Elector and reports whether
+// this replica currently holds it. A nil Elector always reports true.
+func (s *Scheduler) checkLeadership(ctx context.Context, now time.Time) bool {
+	status := LeadershipStatus{IsLeader: true}
+	if s.Elector != nil {
+		if identifier, ok := s.Elector.(InstanceIdentifier); ok {
+			status.InstanceID = identifier.InstanceID()
+		}
+		leader, err := s.Elector.IsLeader(ctx)
+		status.IsLeader = err == nil && leader
+		status.CheckedAt = now
+	}
+
+	s.mu.Lock()
+	s.leadership = status
+	s.mu.Unlock()
+
+	return status.IsLeader
+}
+
+// Leadership returns the most recently checked LeadershipStatus, without
+// contacting the Elector.
+func (s *Scheduler) Leadership() LeadershipStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leadership
+}
+
+// runJob waits out job.Jitter, executes job.Run, and records the outcome.
+func (s *Scheduler) runJob(ctx context.Context, job Job, scheduledAt time.Time) {
+	if job.Jitter > 0 {
+		select {
+		case <-time.After(time.Duration(rand.Int63n(int64(job.Jitter)))):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	run := Run{JobName: job.Name, ScheduledAt: scheduledAt, StartedAt: time.Now()}
+	if err := job.Run(ctx); err != nil {
+		run.Err = err.Error()
+	}
+	run.FinishedAt = time.Now()
+
+	s.record(run)
+}
+
+// record appends run to the job's history, trimming to historyLimit.
+func (s *Scheduler) record(run Run) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := append(s.history[run.JobName], run)
+	if len(runs) > historyLimit {
+		runs = runs[len(runs)-historyLimit:]
+	}
+	s.history[run.JobName] = runs
+}
+
+// History returns a copy of every recorded Run, most job-recent last, for
+// every job that has executed at least once.
+func (s *Scheduler) History() []Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var all []Run
+	for _, runs := range s.history {
+		all = append(all, runs...)
+	}
+	return all
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeElector struct {
+	leader bool
+	err    error
+}
+
+func (e *fakeElector) IsLeader(_ context.Context) (bool, error) {
+	return e.leader, e.err
+}
+
+func TestScheduler_Tick_RunsDueJobs(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+
+	now := time.Date(2026, 8, 8, 8, 0, 0, 0, time.UTC)
+	s := NewScheduler([]Job{
+		{Name: "due", Cron: "0 8 * * *", Run: func(context.Context) error {
+			mu.Lock()
+			ran = append(ran, "due")
+			mu.Unlock()
+			return nil
+		}},
+		{Name: "not-due", Cron: "0 9 * * *", Run: func(context.Context) error {
+			mu.Lock()
+			ran = append(ran, "not-due")
+			mu.Unlock()
+			return nil
+		}},
+		{Name: "disabled", Cron: "", Run: func(context.Context) error {
+			mu.Lock()
+			ran = append(ran, "disabled")
+			mu.Unlock()
+			return nil
+		}},
+	}, nil)
+
+	s.tick(context.Background(), now)
+	waitForHistory(t, s, 1)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ran) != 1 || ran[0] != "due" {
+		t.Fatalf("expected only the due job to run, got %v", ran)
+	}
+}
+
+func TestScheduler_Tick_SkipsWhenNotLeader(t *testing.T) {
+	ranCh := make(chan struct{}, 1)
+	now := time.Date(2026, 8, 8, 8, 0, 0, 0, time.UTC)
+	s := NewScheduler([]Job{
+		{Name: "due", Cron: "0 8 * * *", Run: func(context.Context) error {
+			ranCh <- struct{}{}
+			return nil
+		}},
+	}, &fakeElector{leader: false})
+
+	s.tick(context.Background(), now)
+
+	select {
+	case <-ranCh:
+		t.Fatal("job should not have run without leadership")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestScheduler_RunJob_RecordsHistory(t *testing.T) {
+	now := time.Date(2026, 8, 8, 8, 0, 0, 0, time.UTC)
+	s := NewScheduler(nil, nil)
+
+	s.runJob(context.Background(), Job{Name: "failing", Run: func(context.Context) error {
+		return errors.New("boom")
+	}}, now)
+
+	history := s.History()
+	if len(history) != 1 {
+		t.Fatalf("expected 1 recorded run, got %d", len(history))
+	}
+	if history[0].JobName != "failing" || history[0].Err != "boom" {
+		t.Fatalf("unexpected run record: %+v", history[0])
+	}
+}
+
+// waitForHistory polls until s has recorded n runs, since due jobs execute
+// in their own goroutine.
+func waitForHistory(t *testing.T, s *Scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if len(s.History()) >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d recorded runs, got %d", n, len(s.History()))
+}
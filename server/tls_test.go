@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/server"
+)
+
+func writeSelfSignedCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshalling key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}), 0o600); err != nil {
+		t.Fatalf("writing cert: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0o600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+
+	t.Run("loads a valid keypair", func(t *testing.T) {
+		tlsConfig, err := server.NewTLSConfig(server.TLSConfig{CertFile: certFile, KeyFile: keyFile})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cert, err := tlsConfig.GetCertificate(nil)
+		if err != nil || cert == nil {
+			t.Fatalf("expected a certificate to be served, got %v, err %v", cert, err)
+		}
+	})
+
+	t.Run("rejects an unsupported min version", func(t *testing.T) {
+		_, err := server.NewTLSConfig(server.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.0"})
+		if err == nil {
+			t.Fatalf("expected an error for an unsupported min version")
+		}
+	})
+
+	t.Run("errors on a missing certificate", func(t *testing.T) {
+		_, err := server.NewTLSConfig(server.TLSConfig{CertFile: "/no/such/cert.pem", KeyFile: keyFile})
+		if err == nil {
+			t.Fatalf("expected an error for a missing certificate file")
+		}
+	})
+
+	t.Run("errors on an unparsable client CA bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		caFile := filepath.Join(dir, "ca.pem")
+		if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatalf("writing ca file: %v", err)
+		}
+
+		_, err := server.NewTLSConfig(server.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+		if err == nil {
+			t.Fatalf("expected an error for an unparsable client CA bundle")
+		}
+	})
+}
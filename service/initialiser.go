@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ONSdigital/dis-imf-uploader/config"
+	"github.com/ONSdigital/dis-imf-uploader/store"
+	"github.com/ONSdigital/dis-imf-uploader/store/mongo"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// Initialiser builds the external dependencies New wires into a Service.
+// Swapping it out is how tests run Init without touching a real Mongo or
+// Redis: pass an Initialiser backed by store/mock and an in-memory
+// temp.Storage instead of ExternalInitialiser.
+type Initialiser interface {
+	DoGetMongoDataStore(ctx context.Context, cfg config.MongoConfig) (store.Store, error)
+	DoGetTempStorage(ctx context.Context, cfg config.TempStorageConfig) (temp.Storage, error)
+}
+
+// ExternalInitialiser is the production Initialiser used by main.
+type ExternalInitialiser struct{}
+
+// DoGetMongoDataStore connects to the Mongo instance described by cfg.
+func (e *ExternalInitialiser) DoGetMongoDataStore(ctx context.Context, cfg config.MongoConfig) (store.Store, error) {
+	return mongo.New(ctx, cfg)
+}
+
+// DoGetTempStorage builds the temp.Storage backend selected by cfg.Backend.
+// "s3" is deliberately unsupported here: this repo has no production S3
+// client construction yet (no AWS region/credential config exists), so a
+// caller who needs it must build a *s3.Client themselves and use
+// temp.NewS3Storage directly rather than going through Service.
+func (e *ExternalInitialiser) DoGetTempStorage(ctx context.Context, cfg config.TempStorageConfig) (temp.Storage, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return temp.NewInMemoryStorage(cfg.TempStorageMaxTotal, cfg.MemoryJanitorInterval), nil
+	case "disk":
+		return temp.NewDiskStorage(cfg.TempStorageDiskDir, cfg.TempStorageMaxTotal)
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr, Password: cfg.RedisPassword})
+		return temp.NewRedisStorage(client, cfg.TempStorageMaxTotal, cfg.TempStorageMaxPerFile), nil
+	default:
+		return nil, fmt.Errorf("unsupported temp storage backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,72 @@
+// Package service wires this uploader's dependencies together behind a
+// single Init/Start/Close lifecycle, instead of leaving that wiring to be
+// duplicated by hand wherever the process starts.
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/api"
+	"github.com/ONSdigital/dis-imf-uploader/config"
+)
+
+// Service owns the running HTTP server for this uploader and the handler
+// behind it.
+type Service struct {
+	Config *config.Config
+	API    *api.Handler
+	Server *http.Server
+}
+
+// New builds a Service from cfg. initialiser constructs the Mongo data
+// store and temp storage backend; apiOpts are passed through to
+// api.NewHandler unchanged, so dependencies with no generic construction
+// path here — S3, CloudFront, Cloudflare, the Slack notifier, permissions —
+// are supplied the same way api.NewHandler's own With* options already
+// require, rather than Service needing to know about every integration.
+func New(ctx context.Context, cfg *config.Config, initialiser Initialiser, apiOpts ...api.Option) (*Service, error) {
+	dataStore, err := initialiser.DoGetMongoDataStore(ctx, cfg.MongoConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise mongo data store: %w", err)
+	}
+
+	tempStorage, err := initialiser.DoGetTempStorage(ctx, cfg.TempStorageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise temp storage: %w", err)
+	}
+
+	opts := append([]api.Option{
+		api.WithTemp(tempStorage, cfg.TempStorageConfig.TempStorageTTL),
+		api.WithEnvironment(cfg.Environment),
+	}, apiOpts...)
+	handler := api.NewHandler(dataStore, opts...)
+
+	mux := http.NewServeMux()
+	handler.Routes(mux)
+
+	return &Service{
+		Config: cfg,
+		API:    handler,
+		Server: &http.Server{Addr: cfg.BindAddr, Handler: mux},
+	}, nil
+}
+
+// Start begins serving HTTP traffic. It blocks until the server stops, so
+// callers typically run it in its own goroutine. A clean shutdown via
+// Close is reported as a nil error, not http.ErrServerClosed.
+func (svc *Service) Start() error {
+	if err := svc.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("http server error: %w", err)
+	}
+	return nil
+}
+
+// Close gracefully shuts the HTTP server down, waiting up to
+// svc.Config.GracefulShutdownTimeout for in-flight requests to finish.
+func (svc *Service) Close(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, svc.Config.GracefulShutdownTimeout)
+	defer cancel()
+	return svc.Server.Shutdown(ctx)
+}
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/config"
+	"github.com/ONSdigital/dis-imf-uploader/service"
+	"github.com/ONSdigital/dis-imf-uploader/store"
+	storemock "github.com/ONSdigital/dis-imf-uploader/store/mock"
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+// fakeInitialiser lets tests build a Service without a real Mongo or
+// Redis, mirroring how api.NewHandler's own With* options are faked in
+// component tests.
+type fakeInitialiser struct {
+	store store.Store
+	temp  temp.Storage
+}
+
+func (f *fakeInitialiser) DoGetMongoDataStore(context.Context, config.MongoConfig) (store.Store, error) {
+	return f.store, nil
+}
+
+func (f *fakeInitialiser) DoGetTempStorage(context.Context, config.TempStorageConfig) (temp.Storage, error) {
+	return f.temp, nil
+}
+
+func TestNew(t *testing.T) {
+	cfg := &config.Config{BindAddr: "localhost:0"}
+	initialiser := &fakeInitialiser{
+		store: &storemock.StoreMock{},
+		temp:  temp.NewInMemoryStorage(1<<20, 0),
+	}
+
+	svc, err := service.New(context.Background(), cfg, initialiser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.API == nil {
+		t.Fatal("expected an api.Handler to be built")
+	}
+	if svc.Server.Addr != cfg.BindAddr {
+		t.Fatalf("expected server addr %q, got %q", cfg.BindAddr, svc.Server.Addr)
+	}
+}
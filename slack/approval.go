@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// ActionValue is encoded into an approval button's value so the
+// interactivity callback can drive the approval flow without a round trip
+// to re-fetch the checklist. Clicking Approve submits Acknowledged as the
+// full checklist shown in the message, since the message itself is the
+// reviewer's record of having seen it.
+type ActionValue struct {
+	UploadID     string   `json:"upload_id"`
+	Acknowledged []string `json:"acknowledged,omitempty"`
+}
+
+// BuildApprovalMessage renders a Block Kit message describing upload, with
+// an Approve/Reject action pair and the acknowledgement checklist shown as
+// supporting context. The message text itself is rendered from tmpls, or
+// DefaultTemplates if tmpls is nil.
+func BuildApprovalMessage(upload *models.Upload, checklist []string, tmpls *Templates, dashboardBaseURL, environment string) (Message, error) {
+	if tmpls == nil {
+		tmpls = DefaultTemplates()
+	}
+
+	text, err := tmpls.renderApproval(TemplateData{Upload: upload, DashboardURL: dashboardBaseURL, Environment: environment})
+	if err != nil {
+		return Message{}, err
+	}
+
+	approveValue, err := json.Marshal(ActionValue{UploadID: upload.ID, Acknowledged: checklist})
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode approve action value: %w", err)
+	}
+
+	rejectValue, err := json.Marshal(ActionValue{UploadID: upload.ID})
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode reject action value: %w", err)
+	}
+
+	blocks := []interface{}{
+		SectionBlock{
+			Type: "section",
+			Text: TextObject{Type: "mrkdwn", Text: text},
+		},
+	}
+
+	if len(checklist) > 0 {
+		blocks = append(blocks, ContextBlock{
+			Type:     "context",
+			Elements: []TextObject{{Type: "mrkdwn", Text: "Checklist: " + strings.Join(checklist, ", ")}},
+		})
+	}
+
+	blocks = append(blocks, ActionsBlock{
+		Type: "actions",
+		Elements: []Button{
+			{Type: "button", ActionID: "imf_approve", Text: TextObject{Type: "plain_text", Text: "Approve"}, Style: "primary", Value: string(approveValue)},
+			{Type: "button", ActionID: "imf_reject", Text: TextObject{Type: "plain_text", Text: "Reject"}, Style: "danger", Value: string(rejectValue)},
+		},
+	})
+
+	return Message{Blocks: blocks}, nil
+}
@@ -0,0 +1,38 @@
+package slack_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+func TestBuildApprovalMessage_EncodesChecklistIntoApproveValue(t *testing.T) {
+	upload := &models.Upload{ID: "upload-1", Filename: "cpi.csv", Dataset: "cpi"}
+	checklist := []string{"figures verified"}
+
+	msg, err := slack.BuildApprovalMessage(upload, checklist, nil, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions, ok := msg.Blocks[len(msg.Blocks)-1].(slack.ActionsBlock)
+	if !ok {
+		t.Fatalf("expected last block to be an actions block, got %T", msg.Blocks[len(msg.Blocks)-1])
+	}
+	if len(actions.Elements) != 2 {
+		t.Fatalf("expected two buttons, got %d", len(actions.Elements))
+	}
+
+	var value slack.ActionValue
+	if err := json.Unmarshal([]byte(actions.Elements[0].Value), &value); err != nil {
+		t.Fatalf("unexpected error decoding approve value: %v", err)
+	}
+	if value.UploadID != upload.ID {
+		t.Fatalf("expected upload id %q, got %q", upload.ID, value.UploadID)
+	}
+	if len(value.Acknowledged) != 1 || value.Acknowledged[0] != "figures verified" {
+		t.Fatalf("expected approve value to carry the checklist, got %+v", value.Acknowledged)
+	}
+}
@@ -0,0 +1,27 @@
+package slack
+
+import "encoding/json"
+
+// InteractionPayload is the JSON body Slack sends (as the "payload" form
+// field) when a user clicks a Block Kit button.
+type InteractionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	ResponseURL string `json:"response_url"`
+}
+
+// ParseInteractionPayload decodes the "payload" form field of a Slack
+// interactivity callback request.
+func ParseInteractionPayload(raw string) (*InteractionPayload, error) {
+	var payload InteractionPayload
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Client posts messages to a Slack incoming webhook. It implements
+// api.Notifier and api.ApprovalNotifier.
+type Client struct {
+	WebhookURL string
+	HTTPClient *http.Client
+
+	// DashboardBaseURL is exposed to message templates as {{.DashboardURL}},
+	// e.g. to link an approval message back to the review UI. Empty omits
+	// the link.
+	DashboardBaseURL string
+	// Environment is exposed to message templates as {{.Environment}},
+	// rendered as a footer, e.g. to tell apart the same alert firing from
+	// staging versus production. Empty omits it.
+	Environment string
+	// Templates renders the text of outgoing messages. Nil uses
+	// DefaultTemplates.
+	Templates *Templates
+}
+
+// New constructs a Client posting to webhookURL using http.DefaultClient and
+// the default message templates.
+func New(webhookURL string) *Client {
+	return &Client{WebhookURL: webhookURL, HTTPClient: http.DefaultClient}
+}
+
+// Notify posts message to the webhook as a plain text section block,
+// rendered through c.Templates.Notify.
+func (c *Client) Notify(ctx context.Context, message string) error {
+	text, err := c.templates().renderNotify(NotifyTemplateData{Message: message, DashboardURL: c.DashboardBaseURL, Environment: c.Environment})
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, Message{Blocks: []interface{}{
+		SectionBlock{Type: "section", Text: TextObject{Type: "mrkdwn", Text: text}},
+	}})
+}
+
+// NotifyApprovalRequest posts a Block Kit message with Approve/Reject
+// buttons for upload.
+func (c *Client) NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error {
+	msg, err := BuildApprovalMessage(upload, checklist, c.templates(), c.DashboardBaseURL, c.Environment)
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, msg)
+}
+
+func (c *Client) templates() *Templates {
+	if c.Templates != nil {
+		return c.Templates
+	}
+	return DefaultTemplates()
+}
+
+func (c *Client) post(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Notifier is the notification capability Digest batches. It is satisfied
+// by *Client and any api.Notifier.
+type Notifier interface {
+	Notify(ctx context.Context, message string) error
+}
+
+// ApprovalNotifier is the optional richer notification capability Digest
+// passes straight through to the wrapped Notifier, unbatched. An approval
+// message's Approve/Reject buttons are only actionable per-upload, so they
+// can't be folded into a text digest the way plain lifecycle messages can.
+type ApprovalNotifier interface {
+	NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error
+}
+
+// QuietHours defines a daily window, in a fixed location, during which
+// Digest suppresses regular notifications. Start and End are "HH:MM" in
+// 24-hour time; a window where End is earlier than Start wraps past
+// midnight, e.g. Start "22:00", End "07:00".
+type QuietHours struct {
+	Start    string
+	End      string
+	Location *time.Location
+}
+
+// Active reports whether t falls within the quiet hours window.
+func (q QuietHours) Active(t time.Time) bool {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return false
+	}
+	if start == end {
+		return false
+	}
+
+	loc := q.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+
+	if start < end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end // window wraps midnight
+}
+
+func parseClock(hhmm string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(hhmm, "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid clock value %q: %w", hhmm, err)
+	}
+	return h*60 + m, nil
+}
+
+// Digest batches Notify calls into a periodic summary message, so a burst of
+// upload lifecycle events (e.g. during a bulk release) doesn't flood the
+// Slack channel with one message each. Notifications are also buffered for
+// the duration of QuietHours, regardless of Interval. NotifyError always
+// bypasses batching, since it reports something a reviewer needs to see
+// straight away.
+type Digest struct {
+	Notifier   Notifier
+	Interval   time.Duration
+	QuietHours *QuietHours
+
+	mu      sync.Mutex
+	pending []string
+}
+
+// NewDigest constructs a Digest posting through notifier. A zero interval
+// and nil quietHours make Notify behave exactly like calling notifier
+// directly.
+func NewDigest(notifier Notifier, interval time.Duration, quietHours *QuietHours) *Digest {
+	return &Digest{Notifier: notifier, Interval: interval, QuietHours: quietHours}
+}
+
+// Notify buffers message for the next scheduled Flush when digest batching
+// is enabled or quiet hours are active, otherwise it sends immediately.
+func (d *Digest) Notify(ctx context.Context, message string) error {
+	if d.shouldBuffer() {
+		d.mu.Lock()
+		d.pending = append(d.pending, message)
+		d.mu.Unlock()
+		return nil
+	}
+	return d.Notifier.Notify(ctx, message)
+}
+
+// NotifyError sends message immediately, bypassing batching and quiet
+// hours.
+func (d *Digest) NotifyError(ctx context.Context, message string) error {
+	return d.Notifier.Notify(ctx, "ERROR: "+message)
+}
+
+// NotifyApprovalRequest delegates to the wrapped Notifier's ApprovalNotifier
+// capability, bypassing batching, or falls back to a plain (still batched)
+// Notify summary if it doesn't have one.
+func (d *Digest) NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error {
+	approvalNotifier, ok := d.Notifier.(ApprovalNotifier)
+	if !ok {
+		return d.Notify(ctx, fmt.Sprintf("approval request: %s (%s)", upload.Filename, upload.Dataset))
+	}
+	return approvalNotifier.NotifyApprovalRequest(ctx, upload, checklist)
+}
+
+func (d *Digest) shouldBuffer() bool {
+	if d.QuietHours != nil && d.QuietHours.Active(time.Now()) {
+		return true
+	}
+	return d.Interval > 0
+}
+
+// Run flushes buffered notifications every Interval until ctx is cancelled.
+// It is a no-op if Interval is zero; callers relying purely on QuietHours
+// buffering should call Flush themselves once the window ends.
+func (d *Digest) Run(ctx context.Context) {
+	if d.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = d.Flush(ctx)
+		}
+	}
+}
+
+// Flush sends any buffered notifications as a single summary message. It is
+// a no-op, and leaves the buffer untouched, while quiet hours are active.
+func (d *Digest) Flush(ctx context.Context) error {
+	if d.QuietHours != nil && d.QuietHours.Active(time.Now()) {
+		return nil
+	}
+
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	summary := fmt.Sprintf("*%d updates*\n%s", len(pending), strings.Join(pending, "\n"))
+	return d.Notifier.Notify(ctx, summary)
+}
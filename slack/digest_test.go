@@ -0,0 +1,113 @@
+package slack_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+type recordingNotifier struct {
+	messages []string
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, message string) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestDigest_Notify(t *testing.T) {
+	t.Run("sends immediately when batching and quiet hours are both disabled", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		digest := slack.NewDigest(notifier, 0, nil)
+
+		if err := digest.Notify(context.Background(), "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 1 {
+			t.Fatalf("expected the message to be sent immediately, got %v", notifier.messages)
+		}
+	})
+
+	t.Run("buffers when a digest interval is configured", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		digest := slack.NewDigest(notifier, time.Minute, nil)
+
+		if err := digest.Notify(context.Background(), "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 0 {
+			t.Fatalf("expected the message to be buffered, got %v", notifier.messages)
+		}
+
+		if err := digest.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 1 || !strings.Contains(notifier.messages[0], "upload approved") {
+			t.Fatalf("expected a flushed summary containing the message, got %v", notifier.messages)
+		}
+	})
+
+	t.Run("buffers during quiet hours regardless of interval", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		now := time.Now().UTC()
+		start := now.Add(-time.Hour).Format("15:04")
+		end := now.Add(time.Hour).Format("15:04")
+		digest := slack.NewDigest(notifier, 0, &slack.QuietHours{Start: start, End: end, Location: time.UTC})
+
+		if err := digest.Notify(context.Background(), "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 0 {
+			t.Fatalf("expected the message to be buffered during quiet hours, got %v", notifier.messages)
+		}
+
+		if err := digest.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifier.messages) != 0 {
+			t.Fatalf("expected flush to be a no-op during quiet hours, got %v", notifier.messages)
+		}
+	})
+}
+
+func TestDigest_NotifyError_BypassesBatching(t *testing.T) {
+	notifier := &recordingNotifier{}
+	digest := slack.NewDigest(notifier, time.Minute, nil)
+
+	if err := digest.NotifyError(context.Background(), "s3 publish failed"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.messages) != 1 || !strings.Contains(notifier.messages[0], "s3 publish failed") {
+		t.Fatalf("expected the error to be sent immediately, got %v", notifier.messages)
+	}
+}
+
+func TestQuietHours_Active(t *testing.T) {
+	overnight := slack.QuietHours{Start: "22:00", End: "07:00", Location: time.UTC}
+
+	cases := []struct {
+		name string
+		time string
+		want bool
+	}{
+		{"before window", "18:00", false},
+		{"after midnight, inside window", "02:00", true},
+		{"start boundary is inclusive", "22:00", true},
+		{"end boundary is exclusive", "07:00", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := time.Parse("15:04", tc.time)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := overnight.Active(parsed); got != tc.want {
+				t.Fatalf("expected Active(%s) = %v, got %v", tc.time, tc.want, got)
+			}
+		})
+	}
+}
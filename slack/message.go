@@ -0,0 +1,46 @@
+// Package slack implements a lightweight Slack integration: posting Block
+// Kit approval messages via an incoming webhook, and handling the
+// interactivity callbacks (button clicks) and /imf slash command that
+// Slack sends back for the review workflow.
+package slack
+
+// TextObject is a Slack Block Kit text composition object.
+type TextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Button is a Block Kit interactive button element.
+type Button struct {
+	Type     string     `json:"type"`
+	Text     TextObject `json:"text"`
+	ActionID string     `json:"action_id"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+// SectionBlock is a Block Kit section block.
+type SectionBlock struct {
+	Type string     `json:"type"`
+	Text TextObject `json:"text"`
+}
+
+// ContextBlock is a Block Kit context block, typically used for supporting
+// text such as a checklist rendered alongside an approval request.
+type ContextBlock struct {
+	Type     string       `json:"type"`
+	Elements []TextObject `json:"elements"`
+}
+
+// ActionsBlock is a Block Kit actions block holding interactive elements.
+type ActionsBlock struct {
+	Type     string   `json:"type"`
+	Elements []Button `json:"elements"`
+}
+
+// Message is a Slack Block Kit message payload posted to a webhook or
+// chat.postMessage. Blocks holds a mix of SectionBlock, ContextBlock and
+// ActionsBlock values.
+type Message struct {
+	Blocks []interface{} `json:"blocks"`
+}
@@ -0,0 +1,79 @@
+package slack
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Route sends notifications for datasets matching Prefix through Client,
+// optionally prefixing the message with MentionGroup (e.g. a Slack user
+// group mention like "<!subteam^S0123>") so the owning team is paged
+// in-channel.
+type Route struct {
+	Prefix       string
+	Client       *Client
+	MentionGroup string
+}
+
+// Router sends a notification through the Route whose Prefix is the
+// longest match for the upload's dataset, falling back to Default when no
+// route matches. It implements api.Notifier, api.ApprovalNotifier and
+// api.DatasetNotifier.
+type Router struct {
+	Routes  []Route
+	Default *Client
+}
+
+// NewRouter constructs a Router that falls back to defaultClient when a
+// dataset matches none of routes.
+func NewRouter(defaultClient *Client, routes ...Route) *Router {
+	return &Router{Routes: routes, Default: defaultClient}
+}
+
+// Notify sends message through the default client. Routing by dataset
+// requires NotifyDataset or NotifyApprovalRequest, which carry a dataset
+// to route on; Notify alone doesn't.
+func (r *Router) Notify(ctx context.Context, message string) error {
+	return r.Default.Notify(ctx, message)
+}
+
+// NotifyDataset routes message to the channel configured for dataset,
+// prefixed with that route's mention group if one is set.
+func (r *Router) NotifyDataset(ctx context.Context, dataset, message string) error {
+	route := r.match(dataset)
+	if route == nil {
+		return r.Default.Notify(ctx, message)
+	}
+	if route.MentionGroup != "" {
+		message = route.MentionGroup + " " + message
+	}
+	return route.Client.Notify(ctx, message)
+}
+
+// NotifyApprovalRequest routes an approval request to the channel
+// configured for upload.Dataset.
+func (r *Router) NotifyApprovalRequest(ctx context.Context, upload *models.Upload, checklist []string) error {
+	route := r.match(upload.Dataset)
+	if route == nil {
+		return r.Default.NotifyApprovalRequest(ctx, upload, checklist)
+	}
+	return route.Client.NotifyApprovalRequest(ctx, upload, checklist)
+}
+
+// match returns the Route with the longest Prefix matching dataset, or nil
+// if none match.
+func (r *Router) match(dataset string) *Route {
+	var best *Route
+	for i := range r.Routes {
+		route := &r.Routes[i]
+		if route.Prefix == "" || !strings.HasPrefix(dataset, route.Prefix) {
+			continue
+		}
+		if best == nil || len(route.Prefix) > len(best.Prefix) {
+			best = route
+		}
+	}
+	return best
+}
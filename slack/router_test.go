@@ -0,0 +1,75 @@
+package slack_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+func newTestServer(t *testing.T, received *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*received = append(*received, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRouter_NotifyDataset(t *testing.T) {
+	t.Run("routes to the longest matching prefix, with the mention group prepended", func(t *testing.T) {
+		var cpiReceived, defaultReceived []string
+		cpiServer := newTestServer(t, &cpiReceived)
+		defaultServer := newTestServer(t, &defaultReceived)
+
+		cpiClient := slack.New(cpiServer.URL)
+		defaultClient := slack.New(defaultServer.URL)
+		router := slack.NewRouter(defaultClient, slack.Route{Prefix: "cpi", Client: cpiClient, MentionGroup: "<!subteam^S123>"})
+
+		if err := router.NotifyDataset(context.Background(), "cpi-monthly", "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(cpiReceived) != 1 {
+			t.Fatalf("expected the cpi route to receive one message, got %d", len(cpiReceived))
+		}
+		if len(defaultReceived) != 0 {
+			t.Fatalf("expected the default route to receive nothing, got %d", len(defaultReceived))
+		}
+	})
+
+	t.Run("falls back to the default client when no prefix matches", func(t *testing.T) {
+		var defaultReceived []string
+		defaultServer := newTestServer(t, &defaultReceived)
+		defaultClient := slack.New(defaultServer.URL)
+		router := slack.NewRouter(defaultClient, slack.Route{Prefix: "cpi", Client: slack.New("http://unused.invalid")})
+
+		if err := router.NotifyDataset(context.Background(), "gdp-quarterly", "upload approved"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(defaultReceived) != 1 {
+			t.Fatalf("expected the default route to receive one message, got %d", len(defaultReceived))
+		}
+	})
+}
+
+func TestRouter_NotifyApprovalRequest_RoutesByUploadDataset(t *testing.T) {
+	var cpiReceived []string
+	cpiServer := newTestServer(t, &cpiReceived)
+	cpiClient := slack.New(cpiServer.URL)
+	router := slack.NewRouter(slack.New("http://unused.invalid"), slack.Route{Prefix: "cpi", Client: cpiClient})
+
+	upload := &models.Upload{ID: "1", Dataset: "cpi-monthly", Filename: "data.csv"}
+	if err := router.NotifyApprovalRequest(context.Background(), upload, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cpiReceived) != 1 {
+		t.Fatalf("expected the cpi route to receive the approval request, got %d", len(cpiReceived))
+	}
+}
@@ -0,0 +1,46 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidSignature is returned when a request's signature does not match
+// the expected HMAC for the configured signing secret, or the request
+// timestamp is outside the replay-attack tolerance.
+var ErrInvalidSignature = errors.New("slack: invalid request signature")
+
+// maxRequestAge is the tolerance applied to the X-Slack-Request-Timestamp
+// header, matching Slack's own guidance to reject requests older than five
+// minutes to prevent replay attacks.
+const maxRequestAge = 5 * time.Minute
+
+// VerifySignature checks a request against Slack's signing scheme:
+// https://api.slack.com/authentication/verifying-requests-from-slack
+func VerifySignature(signingSecret, timestamp, body, signature string) error {
+	if signingSecret == "" {
+		return ErrInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
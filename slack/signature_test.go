@@ -0,0 +1,48 @@
+package slack_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+func sign(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature_AcceptsValidSignature(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := "payload=hello"
+	signature := sign("shhh", timestamp, body)
+
+	if err := slack.VerifySignature("shhh", timestamp, body, signature); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifySignature_RejectsWrongSecret(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := "payload=hello"
+	signature := sign("shhh", timestamp, body)
+
+	if err := slack.VerifySignature("different", timestamp, body, signature); err == nil {
+		t.Fatalf("expected error for mismatched secret")
+	}
+}
+
+func TestVerifySignature_RejectsStaleTimestamp(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	body := "payload=hello"
+	signature := sign("shhh", timestamp, body)
+
+	if err := slack.VerifySignature("shhh", timestamp, body, signature); err == nil {
+		t.Fatalf("expected error for stale timestamp")
+	}
+}
@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// DefaultApprovalTemplate is the approval message text rendered above the
+// Approve/Reject buttons when no override is configured.
+const DefaultApprovalTemplate = `*New upload pending review*
+{{.Upload.Filename}} ({{.Upload.Dataset}}){{if .DashboardURL}}
+<{{.DashboardURL}}/uploads/{{.Upload.ID}}|View in dashboard>{{end}}{{if .Environment}}
+_{{.Environment}}_{{end}}`
+
+// DefaultNotifyTemplate is the plain notification text rendered by Notify
+// when no override is configured.
+const DefaultNotifyTemplate = `{{.Message}}{{if .Environment}}
+_{{.Environment}}_{{end}}`
+
+// TemplateData is the set of variables available to an approval message
+// template.
+type TemplateData struct {
+	Upload       *models.Upload
+	DashboardURL string
+	// Environment names the deployment this message was sent from, e.g.
+	// "staging" or "production", rendered as a footer. Empty omits it.
+	Environment string
+}
+
+// NotifyTemplateData is the set of variables available to a plain
+// notification template.
+type NotifyTemplateData struct {
+	Message      string
+	DashboardURL string
+	// Environment names the deployment this message was sent from, e.g.
+	// "staging" or "production", rendered as a footer. Empty omits it.
+	Environment string
+}
+
+// Templates holds the parsed Go templates used to render Slack message
+// text. Use ParseTemplates to build one from configured overrides, or
+// DefaultTemplates for the built-in text.
+type Templates struct {
+	Approval *template.Template
+	Notify   *template.Template
+}
+
+// DefaultTemplates returns the built-in templates.
+func DefaultTemplates() *Templates {
+	return &Templates{
+		Approval: template.Must(template.New("approval").Parse(DefaultApprovalTemplate)),
+		Notify:   template.Must(template.New("notify").Parse(DefaultNotifyTemplate)),
+	}
+}
+
+// ParseTemplates parses approvalTmpl and notifyTmpl as Go templates, keeping
+// the built-in default for either that is empty.
+func ParseTemplates(approvalTmpl, notifyTmpl string) (*Templates, error) {
+	tmpls := DefaultTemplates()
+
+	if approvalTmpl != "" {
+		parsed, err := template.New("approval").Parse(approvalTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse slack approval template: %w", err)
+		}
+		tmpls.Approval = parsed
+	}
+
+	if notifyTmpl != "" {
+		parsed, err := template.New("notify").Parse(notifyTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse slack notify template: %w", err)
+		}
+		tmpls.Notify = parsed
+	}
+
+	return tmpls, nil
+}
+
+func (t *Templates) renderApproval(data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Approval.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render slack approval template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (t *Templates) renderNotify(data NotifyTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Notify.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render slack notify template: %w", err)
+	}
+	return buf.String(), nil
+}
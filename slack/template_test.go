@@ -0,0 +1,65 @@
+package slack_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"github.com/ONSdigital/dis-imf-uploader/slack"
+)
+
+func TestBuildApprovalMessage_UsesConfiguredTemplate(t *testing.T) {
+	upload := &models.Upload{ID: "upload-1", Filename: "cpi.csv", Dataset: "cpi"}
+
+	tmpls, err := slack.ParseTemplates("Please review {{.Upload.Filename}} at {{.DashboardURL}}", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := slack.BuildApprovalMessage(upload, nil, tmpls, "https://dashboard.example.internal", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	section, ok := msg.Blocks[0].(slack.SectionBlock)
+	if !ok {
+		t.Fatalf("expected first block to be a section block, got %T", msg.Blocks[0])
+	}
+	if want := "Please review cpi.csv at https://dashboard.example.internal"; section.Text.Text != want {
+		t.Fatalf("expected rendered text %q, got %q", want, section.Text.Text)
+	}
+}
+
+func TestParseTemplates_InvalidTemplateErrors(t *testing.T) {
+	if _, err := slack.ParseTemplates("{{.Unclosed", ""); err == nil {
+		t.Fatal("expected an error for an invalid approval template")
+	}
+}
+
+func TestDefaultApprovalTemplate_OmitsDashboardLinkWhenURLIsEmpty(t *testing.T) {
+	upload := &models.Upload{ID: "upload-1", Filename: "cpi.csv", Dataset: "cpi"}
+
+	msg, err := slack.BuildApprovalMessage(upload, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	section := msg.Blocks[0].(slack.SectionBlock)
+	if strings.Contains(section.Text.Text, "dashboard") {
+		t.Fatalf("expected no dashboard link, got %q", section.Text.Text)
+	}
+}
+
+func TestDefaultApprovalTemplate_IncludesEnvironmentFooterWhenSet(t *testing.T) {
+	upload := &models.Upload{ID: "upload-1", Filename: "cpi.csv", Dataset: "cpi"}
+
+	msg, err := slack.BuildApprovalMessage(upload, nil, nil, "", "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	section := msg.Blocks[0].(slack.SectionBlock)
+	if !strings.Contains(section.Text.Text, "staging") {
+		t.Fatalf("expected the environment footer, got %q", section.Text.Text)
+	}
+}
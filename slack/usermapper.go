@@ -0,0 +1,21 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// StaticUserMapper maps Slack user IDs to service usernames from a fixed
+// lookup table, e.g. loaded from config.
+type StaticUserMapper struct {
+	Users map[string]string
+}
+
+// MapUser implements api.UserMapper.
+func (m StaticUserMapper) MapUser(_ context.Context, slackUserID string) (string, error) {
+	user, ok := m.Users[slackUserID]
+	if !ok {
+		return "", fmt.Errorf("no service user mapped for slack user %q", slackUserID)
+	}
+	return user, nil
+}
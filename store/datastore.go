@@ -0,0 +1,64 @@
+package store
+
+//go:generate moq -pkg mock -out mock/datastore.go . Store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+)
+
+// Store defines the persistence operations required by the API handlers.
+// It is implemented by store/mongo.Mongo and faked in tests via store/mock.
+type Store interface {
+	CreateUpload(ctx context.Context, upload *models.Upload) error
+	GetUpload(ctx context.Context, id string) (*models.Upload, error)
+	ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error)
+	ListUploadsFiltered(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error)
+	CountUploadsByStatus(ctx context.Context) (map[models.Status]int, error)
+	UpdateUpload(ctx context.Context, upload *models.Upload) error
+	DeleteUpload(ctx context.Context, id string) error
+
+	CreateNotification(ctx context.Context, notification *models.Notification) error
+	GetNotification(ctx context.Context, id string) (*models.Notification, error)
+	ListNotifications(ctx context.Context, uploadID string) ([]*models.Notification, error)
+	UpdateNotification(ctx context.Context, notification *models.Notification) error
+
+	CreateInvalidation(ctx context.Context, invalidation *models.Invalidation) error
+	ListInvalidations(ctx context.Context, dataset string) ([]*models.Invalidation, error)
+
+	CreateRelease(ctx context.Context, release *models.Release) error
+	GetRelease(ctx context.Context, id string) (*models.Release, error)
+	ListReleases(ctx context.Context, dataset string) ([]*models.Release, error)
+	UpdateRelease(ctx context.Context, release *models.Release) error
+
+	GetMaintenanceMode(ctx context.Context) (*models.MaintenanceMode, error)
+	SetMaintenanceMode(ctx context.Context, mode *models.MaintenanceMode) error
+
+	GetFeatureFlag(ctx context.Context, name string) (*models.FeatureFlag, error)
+	ListFeatureFlags(ctx context.Context) ([]*models.FeatureFlag, error)
+	SetFeatureFlag(ctx context.Context, flag *models.FeatureFlag) error
+
+	GetRejectionReason(ctx context.Context, code string) (*models.RejectionReason, error)
+	ListRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error)
+	SetRejectionReason(ctx context.Context, reason *models.RejectionReason) error
+	DeleteRejectionReason(ctx context.Context, code string) error
+
+	GetDatasetProfile(ctx context.Context, dataset string) (*models.DatasetProfile, error)
+	ListDatasetProfiles(ctx context.Context) ([]*models.DatasetProfile, error)
+	SetDatasetProfile(ctx context.Context, profile *models.DatasetProfile) error
+	DeleteDatasetProfile(ctx context.Context, dataset string) error
+
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	UpdateAPIKey(ctx context.Context, key *models.APIKey) error
+
+	CreateAuditLogEntry(ctx context.Context, entry *models.AuditLogEntry) error
+	ListAuditLogEntries(ctx context.Context) ([]*models.AuditLogEntry, error)
+	ListAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) ([]*models.AuditLogEntry, error)
+	DeleteAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) error
+
+	Checker(ctx context.Context) error
+}
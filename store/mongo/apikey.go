@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrAPIKeyNotFound is returned when a requested API key does not exist.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// CreateAPIKey inserts a new API key record.
+func (m *Mongo) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	if _, err := m.apiKeys.InsertOne(ctx, key); err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey fetches a single API key by ID.
+func (m *Mongo) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := m.apiKeys.FindOne(ctx, bson.M{"_id": id}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key %q: %w", id, err)
+	}
+	return &key, nil
+}
+
+// ListAPIKeys returns every API key.
+func (m *Mongo) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	cursor, err := m.apiKeys.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// UpdateAPIKey replaces an existing API key record, e.g. to record a
+// rotation or revocation.
+func (m *Mongo) UpdateAPIKey(ctx context.Context, key *models.APIKey) error {
+	result, err := m.apiKeys.ReplaceOne(ctx, bson.M{"_id": key.ID}, key)
+	if err != nil {
+		return fmt.Errorf("failed to update api key %q: %w", key.ID, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateAuditLogEntry inserts a record of a mutating admin API call.
+func (m *Mongo) CreateAuditLogEntry(ctx context.Context, entry *models.AuditLogEntry) error {
+	if _, err := m.auditLog.InsertOne(ctx, entry); err != nil {
+		return fmt.Errorf("failed to insert audit log entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditLogEntries returns every recorded audit log entry.
+func (m *Mongo) ListAuditLogEntries(ctx context.Context) ([]*models.AuditLogEntry, error) {
+	cursor, err := m.auditLog.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*models.AuditLogEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode audit log entries: %w", err)
+	}
+	return entries, nil
+}
+
+// ListAuditLogEntriesOlderThan returns every audit log entry recorded
+// before cutoff, so a retention job can export them before they're deleted.
+func (m *Mongo) ListAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) ([]*models.AuditLogEntry, error) {
+	cursor, err := m.auditLog.Find(ctx, bson.M{"recorded_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired audit log entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*models.AuditLogEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode expired audit log entries: %w", err)
+	}
+	return entries, nil
+}
+
+// DeleteAuditLogEntriesOlderThan removes every audit log entry recorded
+// before cutoff in a single bulk delete, so a retention job doesn't need to
+// know or rely on each entry's ID.
+func (m *Mongo) DeleteAuditLogEntriesOlderThan(ctx context.Context, cutoff time.Time) error {
+	if _, err := m.auditLog.DeleteMany(ctx, bson.M{"recorded_at": bson.M{"$lt": cutoff}}); err != nil {
+		return fmt.Errorf("failed to delete expired audit log entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetDatasetProfile fetches the profile for the given dataset.
+func (m *Mongo) GetDatasetProfile(ctx context.Context, dataset string) (*models.DatasetProfile, error) {
+	var profile models.DatasetProfile
+	err := m.datasetProfiles.FindOne(ctx, bson.M{"_id": dataset}).Decode(&profile)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("dataset profile %q not found", dataset)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataset profile %q: %w", dataset, err)
+	}
+	return &profile, nil
+}
+
+// ListDatasetProfiles returns every managed dataset profile.
+func (m *Mongo) ListDatasetProfiles(ctx context.Context) ([]*models.DatasetProfile, error) {
+	cursor, err := m.datasetProfiles.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dataset profiles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var profiles []*models.DatasetProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to decode dataset profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// SetDatasetProfile creates or updates the profile keyed by
+// profile.Dataset, preserving CreatedAt across an update.
+func (m *Mongo) SetDatasetProfile(ctx context.Context, profile *models.DatasetProfile) error {
+	if existing, err := m.GetDatasetProfile(ctx, profile.Dataset); err == nil {
+		profile.CreatedAt = existing.CreatedAt
+	} else {
+		profile.CreatedAt = time.Now()
+	}
+	profile.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.datasetProfiles.ReplaceOne(ctx, bson.M{"_id": profile.Dataset}, profile, opts); err != nil {
+		return fmt.Errorf("failed to set dataset profile %q: %w", profile.Dataset, err)
+	}
+	return nil
+}
+
+// DeleteDatasetProfile removes the profile for the given dataset.
+func (m *Mongo) DeleteDatasetProfile(ctx context.Context, dataset string) error {
+	if _, err := m.datasetProfiles.DeleteOne(ctx, bson.M{"_id": dataset}); err != nil {
+		return fmt.Errorf("failed to delete dataset profile %q: %w", dataset, err)
+	}
+	return nil
+}
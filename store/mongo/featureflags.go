@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetFeatureFlag fetches the named feature flag, or a disabled default if it
+// has never been set.
+func (m *Mongo) GetFeatureFlag(ctx context.Context, name string) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := m.featureFlags.FindOne(ctx, bson.M{"_id": name}).Decode(&flag)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.FeatureFlag{Name: name}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flag %q: %w", name, err)
+	}
+	return &flag, nil
+}
+
+// ListFeatureFlags returns every feature flag that has been explicitly set.
+func (m *Mongo) ListFeatureFlags(ctx context.Context) ([]*models.FeatureFlag, error) {
+	cursor, err := m.featureFlags.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var flags []*models.FeatureFlag
+	if err := cursor.All(ctx, &flags); err != nil {
+		return nil, fmt.Errorf("failed to decode feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+// SetFeatureFlag upserts the named feature flag.
+func (m *Mongo) SetFeatureFlag(ctx context.Context, flag *models.FeatureFlag) error {
+	flag.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.featureFlags.ReplaceOne(ctx, bson.M{"_id": flag.Name}, flag, opts); err != nil {
+		return fmt.Errorf("failed to set feature flag %q: %w", flag.Name, err)
+	}
+	return nil
+}
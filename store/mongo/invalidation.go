@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateInvalidation inserts a record of a dataset-prefix invalidation.
+func (m *Mongo) CreateInvalidation(ctx context.Context, invalidation *models.Invalidation) error {
+	if _, err := m.invalidations.InsertOne(ctx, invalidation); err != nil {
+		return fmt.Errorf("failed to insert invalidation: %w", err)
+	}
+	return nil
+}
+
+// ListInvalidations returns the invalidations recorded for dataset, or every
+// recorded invalidation when dataset is empty.
+func (m *Mongo) ListInvalidations(ctx context.Context, dataset string) ([]*models.Invalidation, error) {
+	filter := bson.M{}
+	if dataset != "" {
+		filter["dataset"] = dataset
+	}
+
+	cursor, err := m.invalidations.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invalidations for dataset %q: %w", dataset, err)
+	}
+	defer cursor.Close(ctx)
+
+	var invalidations []*models.Invalidation
+	if err := cursor.All(ctx, &invalidations); err != nil {
+		return nil, fmt.Errorf("failed to decode invalidations: %w", err)
+	}
+	return invalidations, nil
+}
@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetMaintenanceMode fetches the singleton maintenance mode record, or a
+// disabled default if it has never been set.
+func (m *Mongo) GetMaintenanceMode(ctx context.Context) (*models.MaintenanceMode, error) {
+	var mode models.MaintenanceMode
+	err := m.maintenance.FindOne(ctx, bson.M{"_id": models.MaintenanceModeID}).Decode(&mode)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.MaintenanceMode{ID: models.MaintenanceModeID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	return &mode, nil
+}
+
+// SetMaintenanceMode upserts the singleton maintenance mode record.
+func (m *Mongo) SetMaintenanceMode(ctx context.Context, mode *models.MaintenanceMode) error {
+	mode.ID = models.MaintenanceModeID
+	mode.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.maintenance.ReplaceOne(ctx, bson.M{"_id": models.MaintenanceModeID}, mode, opts); err != nil {
+		return fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package mongo provides the MongoDB-backed implementation of the upload
+// store, including connection setup for authenticated, TLS and AWS
+// DocumentDB clusters.
+package mongo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Mongo is the MongoDB implementation of the upload store.
+type Mongo struct {
+	cfg              config.MongoConfig
+	client           *mongo.Client
+	uploads          *mongo.Collection
+	notifications    *mongo.Collection
+	invalidations    *mongo.Collection
+	releases         *mongo.Collection
+	maintenance      *mongo.Collection
+	featureFlags     *mongo.Collection
+	apiKeys          *mongo.Collection
+	auditLog         *mongo.Collection
+	rejectionReasons *mongo.Collection
+	datasetProfiles  *mongo.Collection
+	// uploadsRead is uploads cloned with a secondary-preferred read
+	// preference when cfg.PreferSecondaryForReads is set, used by the
+	// heavy list/stats/export queries so they can't compete with writes
+	// and status checks for the primary's capacity. It is the same handle
+	// as uploads (primary preferred) otherwise.
+	uploadsRead *mongo.Collection
+}
+
+// New connects to the configured MongoDB cluster and returns a ready-to-use
+// Mongo store. It supports username/password authentication, TLS (including
+// the CA bundle required for AWS DocumentDB) and replica-set aware
+// read/write concerns.
+func New(ctx context.Context, cfg config.MongoConfig) (*Mongo, error) {
+	opts := options.Client().ApplyURI(buildURI(cfg))
+
+	opts.SetReadConcern(readconcern.Majority())
+	opts.SetWriteConcern(writeconcern.Majority())
+
+	if cfg.ReplicaSet != "" {
+		opts.SetReplicaSet(cfg.ReplicaSet)
+	}
+
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SlowQueryThreshold > 0 {
+		opts.SetMonitor(slowQueryMonitor(cfg.SlowQueryThreshold))
+	}
+
+	if cfg.Username != "" {
+		opts.SetAuth(options.Credential{
+			Username:      cfg.Username,
+			Password:      cfg.Password,
+			AuthSource:    cfg.Database,
+			AuthMechanism: "SCRAM-SHA-1",
+		})
+	}
+
+	if cfg.IsSSL || cfg.IsDocumentDB {
+		tlsConfig, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build mongo tls config: %w", err)
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if cfg.IsDocumentDB {
+		// DocumentDB does not support retryable writes or Majority read
+		// concern on secondaries the way vanilla MongoDB does.
+		opts.SetRetryWrites(false)
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, connectTimeout(cfg))
+	defer cancel()
+
+	client, err := mongo.Connect(connectCtx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+
+	pingCtx, pingCancel := context.WithTimeout(ctx, connectTimeout(cfg))
+	defer pingCancel()
+	if err := client.Ping(pingCtx, nil); err != nil {
+		return nil, fmt.Errorf("failed to ping mongodb: %w", err)
+	}
+
+	uploads := client.Database(cfg.Database).Collection(cfg.UploadsCollection)
+	uploadsRead := uploads
+	if cfg.PreferSecondaryForReads {
+		uploadsRead, err = uploads.Clone(options.Collection().SetReadPreference(readpref.SecondaryPreferred()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure secondary-preferred read handle: %w", err)
+		}
+	}
+
+	m := &Mongo{
+		cfg:              cfg,
+		client:           client,
+		uploads:          uploads,
+		uploadsRead:      uploadsRead,
+		notifications:    client.Database(cfg.Database).Collection(cfg.NotificationsCollection),
+		invalidations:    client.Database(cfg.Database).Collection(cfg.InvalidationsCollection),
+		releases:         client.Database(cfg.Database).Collection(cfg.ReleasesCollection),
+		maintenance:      client.Database(cfg.Database).Collection(cfg.MaintenanceCollection),
+		featureFlags:     client.Database(cfg.Database).Collection(cfg.FeatureFlagsCollection),
+		apiKeys:          client.Database(cfg.Database).Collection(cfg.APIKeysCollection),
+		auditLog:         client.Database(cfg.Database).Collection(cfg.AuditLogCollection),
+		rejectionReasons: client.Database(cfg.Database).Collection(cfg.RejectionReasonsCollection),
+		datasetProfiles:  client.Database(cfg.Database).Collection(cfg.DatasetProfilesCollection),
+	}
+
+	indexCtx, indexCancel := context.WithTimeout(ctx, connectTimeout(cfg))
+	defer indexCancel()
+	if err := m.ensureUploadIndexes(indexCtx); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ensureUploadIndexes creates the compound indexes ListUploads and
+// ListUploadsFiltered rely on to avoid a collection scan as the uploads
+// collection grows: dataset+status for the "needs attention" query,
+// status+created_at and uploaded_by+created_at for the common sort orders
+// on those fields, and tags for filtering on ad-hoc groupings. It is
+// idempotent, so it's safe to run on every startup; creation failures are
+// returned rather than ignored, since a missing index degrades silently
+// until the collection is large enough to notice.
+func (m *Mongo) ensureUploadIndexes(ctx context.Context) error {
+	_, err := m.uploads.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "dataset", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "uploaded_by", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create upload indexes: %w", err)
+	}
+	return nil
+}
+
+// Close disconnects the underlying mongo client.
+func (m *Mongo) Close(ctx context.Context) error {
+	return m.client.Disconnect(ctx)
+}
+
+// Checker performs a lightweight connectivity check against the cluster,
+// suitable for use in a healthcheck.
+func (m *Mongo) Checker(ctx context.Context) error {
+	return m.client.Ping(ctx, nil)
+}
+
+// slowQueryMonitor returns a CommandMonitor that logs any command taking at
+// least threshold, keyed by command name and collection, so a reporting
+// burst that starts hammering the DB shows up by endpoint rather than only
+// as an aggregate latency spike. Start times are tracked by RequestID,
+// since Started and Succeeded/Failed for the same command fire on the same
+// connection but aren't otherwise correlated.
+func slowQueryMonitor(threshold time.Duration) *event.CommandMonitor {
+	var starts sync.Map // int64 RequestID -> time.Time
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			starts.Store(evt.RequestID, time.Now())
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			logSlowCommand(&starts, evt.RequestID, evt.CommandName, evt.DatabaseName, threshold)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			logSlowCommand(&starts, evt.RequestID, evt.CommandName, evt.DatabaseName, threshold)
+		},
+	}
+}
+
+func logSlowCommand(starts *sync.Map, requestID int64, commandName, databaseName string, threshold time.Duration) {
+	startedAt, ok := starts.LoadAndDelete(requestID)
+	if !ok {
+		return
+	}
+	if elapsed := time.Since(startedAt.(time.Time)); elapsed >= threshold {
+		log.Printf("slow mongo query: db=%s command=%s duration=%s", databaseName, commandName, elapsed)
+	}
+}
+
+func buildURI(cfg config.MongoConfig) string {
+	scheme := "mongodb"
+	return fmt.Sprintf("%s://%s", scheme, cfg.ClusterEndpoint)
+}
+
+func connectTimeout(cfg config.MongoConfig) time.Duration {
+	if cfg.ConnectTimeoutSecs <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(cfg.ConnectTimeoutSecs) * time.Second
+}
+
+func buildTLSConfig(cfg config.MongoConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !cfg.VerifyCert, //nolint:gosec // explicit opt-out for local/dev clusters only
+	}
+
+	if cfg.CACertPath == "" {
+		return tlsConfig, nil
+	}
+
+	caCert, err := os.ReadFile(cfg.CACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mongo ca cert %q: %w", cfg.CACertPath, err)
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caCert); !ok {
+		return nil, fmt.Errorf("failed to parse mongo ca cert %q", cfg.CACertPath)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
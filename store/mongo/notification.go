@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrNotificationNotFound is returned when a requested notification does
+// not exist.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// CreateNotification inserts a new notification delivery record.
+func (m *Mongo) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	if _, err := m.notifications.InsertOne(ctx, notification); err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+// GetNotification fetches a single notification by ID.
+func (m *Mongo) GetNotification(ctx context.Context, id string) (*models.Notification, error) {
+	var notification models.Notification
+	if err := m.notifications.FindOne(ctx, bson.M{"_id": id}).Decode(&notification); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification %q: %w", id, err)
+	}
+	return &notification, nil
+}
+
+// ListNotifications returns the delivery attempts recorded for uploadID.
+func (m *Mongo) ListNotifications(ctx context.Context, uploadID string) ([]*models.Notification, error) {
+	cursor, err := m.notifications.Find(ctx, bson.M{"upload_id": uploadID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications for upload %q: %w", uploadID, err)
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []*models.Notification
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// UpdateNotification replaces an existing notification record.
+func (m *Mongo) UpdateNotification(ctx context.Context, notification *models.Notification) error {
+	result, err := m.notifications.ReplaceOne(ctx, bson.M{"_id": notification.ID}, notification)
+	if err != nil {
+		return fmt.Errorf("failed to update notification %q: %w", notification.ID, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
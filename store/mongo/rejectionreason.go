@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetRejectionReason fetches the rejection reason with the given code.
+func (m *Mongo) GetRejectionReason(ctx context.Context, code string) (*models.RejectionReason, error) {
+	var reason models.RejectionReason
+	err := m.rejectionReasons.FindOne(ctx, bson.M{"_id": code}).Decode(&reason)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("rejection reason %q not found", code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rejection reason %q: %w", code, err)
+	}
+	return &reason, nil
+}
+
+// ListRejectionReasons returns every managed rejection reason.
+func (m *Mongo) ListRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error) {
+	cursor, err := m.rejectionReasons.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rejection reasons: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var reasons []*models.RejectionReason
+	if err := cursor.All(ctx, &reasons); err != nil {
+		return nil, fmt.Errorf("failed to decode rejection reasons: %w", err)
+	}
+	return reasons, nil
+}
+
+// SetRejectionReason creates or updates the rejection reason keyed by
+// reason.Code, preserving CreatedAt across an update.
+func (m *Mongo) SetRejectionReason(ctx context.Context, reason *models.RejectionReason) error {
+	if existing, err := m.GetRejectionReason(ctx, reason.Code); err == nil {
+		reason.CreatedAt = existing.CreatedAt
+	} else {
+		reason.CreatedAt = time.Now()
+	}
+	reason.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := m.rejectionReasons.ReplaceOne(ctx, bson.M{"_id": reason.Code}, reason, opts); err != nil {
+		return fmt.Errorf("failed to set rejection reason %q: %w", reason.Code, err)
+	}
+	return nil
+}
+
+// DeleteRejectionReason removes the rejection reason with the given code.
+func (m *Mongo) DeleteRejectionReason(ctx context.Context, code string) error {
+	if _, err := m.rejectionReasons.DeleteOne(ctx, bson.M{"_id": code}); err != nil {
+		return fmt.Errorf("failed to delete rejection reason %q: %w", code, err)
+	}
+	return nil
+}
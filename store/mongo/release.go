@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrReleaseNotFound is returned when a requested release does not exist.
+var ErrReleaseNotFound = errors.New("release not found")
+
+// CreateRelease inserts a new release record.
+func (m *Mongo) CreateRelease(ctx context.Context, release *models.Release) error {
+	if _, err := m.releases.InsertOne(ctx, release); err != nil {
+		return fmt.Errorf("failed to insert release: %w", err)
+	}
+	return nil
+}
+
+// GetRelease fetches a single release by ID.
+func (m *Mongo) GetRelease(ctx context.Context, id string) (*models.Release, error) {
+	var release models.Release
+	if err := m.releases.FindOne(ctx, bson.M{"_id": id}).Decode(&release); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrReleaseNotFound
+		}
+		return nil, fmt.Errorf("failed to get release %q: %w", id, err)
+	}
+	return &release, nil
+}
+
+// ListReleases returns all releases, optionally filtered by dataset.
+func (m *Mongo) ListReleases(ctx context.Context, dataset string) ([]*models.Release, error) {
+	filter := bson.M{}
+	if dataset != "" {
+		filter["dataset"] = dataset
+	}
+
+	cursor, err := m.releases.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var releases []*models.Release
+	if err := cursor.All(ctx, &releases); err != nil {
+		return nil, fmt.Errorf("failed to decode releases: %w", err)
+	}
+	return releases, nil
+}
+
+// UpdateRelease replaces an existing release record.
+func (m *Mongo) UpdateRelease(ctx context.Context, release *models.Release) error {
+	result, err := m.releases.ReplaceOne(ctx, bson.M{"_id": release.ID}, release)
+	if err != nil {
+		return fmt.Errorf("failed to update release %q: %w", release.ID, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrReleaseNotFound
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ONSdigital/dis-imf-uploader/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrUploadNotFound is returned when a requested upload does not exist.
+var ErrUploadNotFound = errors.New("upload not found")
+
+// ErrInvalidTransition is returned by UpdateUpload when upload.Status is
+// not reachable from the upload's current status, per
+// models.CanTransition.
+var ErrInvalidTransition = errors.New("invalid upload status transition")
+
+// CreateUpload inserts a new upload record.
+func (m *Mongo) CreateUpload(ctx context.Context, upload *models.Upload) error {
+	if _, err := m.uploads.InsertOne(ctx, upload); err != nil {
+		return fmt.Errorf("failed to insert upload: %w", err)
+	}
+	return nil
+}
+
+// GetUpload fetches a single upload by ID.
+func (m *Mongo) GetUpload(ctx context.Context, id string) (*models.Upload, error) {
+	var upload models.Upload
+	if err := m.uploads.FindOne(ctx, bson.M{"_id": id}).Decode(&upload); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload %q: %w", id, err)
+	}
+	return &upload, nil
+}
+
+// ListUploads returns all uploads, optionally filtered by dataset. It reads
+// from uploadsRead, so on a replica set configured with
+// PreferSecondaryForReads it can be served by a secondary.
+func (m *Mongo) ListUploads(ctx context.Context, dataset string) ([]*models.Upload, error) {
+	filter := bson.M{}
+	if dataset != "" {
+		filter["dataset"] = dataset
+	}
+
+	cursor, err := m.uploadsRead.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploads: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var uploads []*models.Upload
+	if err := cursor.All(ctx, &uploads); err != nil {
+		return nil, fmt.Errorf("failed to decode uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+// ListUploadsFiltered returns uploads matching filter, sorted by
+// filter.SortBy if set, backed by the compound indexes created in New so a
+// "needs attention" query spanning several statuses (or a sorted listing)
+// stays efficient at scale. Like ListUploads, it reads from uploadsRead.
+func (m *Mongo) ListUploadsFiltered(ctx context.Context, filter models.UploadFilter) ([]*models.Upload, error) {
+	query := bson.M{}
+	if filter.Dataset != "" {
+		query["dataset"] = filter.Dataset
+	}
+	if filter.UploadedBy != "" {
+		query["uploaded_by"] = filter.UploadedBy
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+	if filter.TargetKeyPrefix != "" {
+		query["target_key"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.TargetKeyPrefix)}
+	}
+	if len(filter.Tags) > 0 {
+		query["tags"] = bson.M{"$in": filter.Tags}
+	}
+	if !filter.CreatedAfter.IsZero() || !filter.CreatedBefore.IsZero() {
+		createdAt := bson.M{}
+		if !filter.CreatedAfter.IsZero() {
+			createdAt["$gte"] = filter.CreatedAfter
+		}
+		if !filter.CreatedBefore.IsZero() {
+			createdAt["$lt"] = filter.CreatedBefore
+		}
+		query["created_at"] = createdAt
+	}
+
+	findOptions := options.Find()
+	if filter.SortBy != "" {
+		order := 1
+		if filter.SortDescending {
+			order = -1
+		}
+		findOptions.SetSort(bson.D{{Key: filter.SortBy, Value: order}})
+	}
+
+	cursor, err := m.uploadsRead.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filtered uploads: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var uploads []*models.Upload
+	if err := cursor.All(ctx, &uploads); err != nil {
+		return nil, fmt.Errorf("failed to decode uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+// CountUploadsByStatus returns the number of uploads in each status, via a
+// single aggregation rather than one query per status, so the dashboard's
+// nav badges stay cheap to render. Like ListUploads, it reads from
+// uploadsRead.
+func (m *Mongo) CountUploadsByStatus(ctx context.Context) (map[models.Status]int, error) {
+	cursor, err := m.uploadsRead.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$status"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count uploads by status: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Status models.Status `bson:"_id"`
+		Count  int           `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode upload status counts: %w", err)
+	}
+
+	counts := make(map[models.Status]int, len(results))
+	for _, r := range results {
+		counts[r.Status] = r.Count
+	}
+	return counts, nil
+}
+
+// UpdateUpload replaces an existing upload record, refusing to write an
+// illegal status transition (per models.CanTransition) regardless of what
+// the caller sends. The replace is conditioned on the record's status
+// still matching what was just read, so a concurrent status change loses
+// the race rather than being silently overwritten; either case surfaces as
+// ErrUploadNotFound, since telling them apart would need a second read
+// this method has no other reason to make.
+func (m *Mongo) UpdateUpload(ctx context.Context, upload *models.Upload) error {
+	current, err := m.GetUpload(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+
+	if !models.CanTransition(current.Status, upload.Status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current.Status, upload.Status)
+	}
+
+	result, err := m.uploads.ReplaceOne(ctx, bson.M{"_id": upload.ID, "status": current.Status}, upload)
+	if err != nil {
+		return fmt.Errorf("failed to update upload %q: %w", upload.ID, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrUploadNotFound
+	}
+	return nil
+}
+
+// DeleteUpload removes an upload record by ID.
+func (m *Mongo) DeleteUpload(ctx context.Context, id string) error {
+	result, err := m.uploads.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete upload %q: %w", id, err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrUploadNotFound
+	}
+	return nil
+}
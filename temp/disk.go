@@ -0,0 +1,249 @@
+package temp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DiskStorage is a filesystem-backed implementation of Storage, useful for
+// single-node or local-dev deployments that don't run Redis or have a
+// separate staging S3 bucket. Writes are atomic (write to a temp file in the
+// same directory, then rename) so a crash mid-write never leaves a partial
+// object visible.
+type DiskStorage struct {
+	dir         string
+	maxTotal    int64
+	mu          sync.Mutex
+	currentSize int64
+}
+
+type diskMeta struct {
+	ExpiresAt time.Time
+}
+
+// NewDiskStorage returns a DiskStorage rooted at dir, enforcing maxTotal
+// bytes across all stored objects (0 means unlimited). Recover should be
+// called once at startup to reconcile currentSize with what's on disk and
+// remove anything already expired.
+func NewDiskStorage(dir string, maxTotal int64) (*DiskStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create temp storage dir %q: %w", dir, err)
+	}
+	return &DiskStorage{dir: dir, maxTotal: maxTotal}, nil
+}
+
+// Recover scans dir for previously stored files, deleting anything past its
+// expiry and rebuilding the current usage total. It should be called once
+// during service startup, before the storage is exposed to handlers.
+func (d *DiskStorage) Recover(ctx context.Context) error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("failed to scan temp storage dir %q: %w", d.dir, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) == ".meta" || filepath.Ext(entry.Name()) == ".tmp" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		expiresAt, ok := d.readExpiry(entry.Name())
+		if ok && time.Now().After(expiresAt) {
+			_ = os.Remove(d.dataPath(entry.Name()))
+			_ = os.Remove(d.metaPath(entry.Name()))
+			continue
+		}
+
+		total += info.Size()
+	}
+	d.currentSize = total
+
+	return nil
+}
+
+func (d *DiskStorage) dataPath(key string) string { return filepath.Join(d.dir, key) }
+func (d *DiskStorage) metaPath(key string) string { return filepath.Join(d.dir, key+".meta") }
+
+// Store writes data to disk atomically via a temp file + rename.
+func (d *DiskStorage) Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error {
+	d.mu.Lock()
+	if d.maxTotal > 0 && d.currentSize+size > d.maxTotal {
+		d.mu.Unlock()
+		return fmt.Errorf("%w: %d bytes used, %d requested, limit %d", ErrCapacityExceeded, d.currentSize, size, d.maxTotal)
+	}
+	d.mu.Unlock()
+
+	tmpFile, err := os.CreateTemp(d.dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for %q: %w", key, err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	written, err := io.Copy(tmpFile, data)
+	if err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write %q: %w", key, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to finalise %q: %w", key, err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), d.dataPath(key)); err != nil {
+		return fmt.Errorf("failed to commit %q: %w", key, err)
+	}
+
+	if err := d.writeExpiry(key, time.Now().Add(ttl)); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.currentSize += written
+	d.mu.Unlock()
+
+	return nil
+}
+
+// Get returns the stored data for key.
+func (d *DiskStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(d.dataPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open %q: %w", key, err)
+	}
+	return f, nil
+}
+
+// GetRange opens key and seeks to offset, returning a reader limited to
+// length bytes (or everything remaining if length <= 0).
+func (d *DiskStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	f, err := os.Open(d.dataPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open %q: %w", key, err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to seek %q to offset %d: %w", key, offset, err)
+	}
+	if length <= 0 {
+		return f, nil
+	}
+	return limitedReadCloser{Reader: io.LimitReader(f, length), Closer: f}, nil
+}
+
+// limitedReadCloser pairs an io.Reader (typically an io.LimitReader) with
+// the io.Closer of the underlying file it reads from, since io.LimitReader
+// itself doesn't implement io.Closer.
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// Delete removes key and its metadata from disk.
+func (d *DiskStorage) Delete(ctx context.Context, key string) error {
+	info, err := os.Stat(d.dataPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat %q: %w", key, err)
+	}
+
+	if err := os.Remove(d.dataPath(key)); err != nil {
+		return fmt.Errorf("failed to delete %q: %w", key, err)
+	}
+	_ = os.Remove(d.metaPath(key))
+
+	d.mu.Lock()
+	d.currentSize -= info.Size()
+	d.mu.Unlock()
+
+	return nil
+}
+
+// SetTTL rewrites the expiry metadata for an already-stored key.
+func (d *DiskStorage) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	if _, err := os.Stat(d.dataPath(key)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to stat %q: %w", key, err)
+	}
+	return d.writeExpiry(key, time.Now().Add(ttl))
+}
+
+func (d *DiskStorage) writeExpiry(key string, expiresAt time.Time) error {
+	if err := os.WriteFile(d.metaPath(key), []byte(expiresAt.Format(time.RFC3339)), 0o640); err != nil {
+		return fmt.Errorf("failed to write expiry metadata for %q: %w", key, err)
+	}
+	return nil
+}
+
+// List enumerates all objects currently stored on disk.
+func (d *DiskStorage) List(ctx context.Context) ([]ObjectInfo, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan temp storage dir %q: %w", d.dir, err)
+	}
+
+	var objects []ObjectInfo
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) == ".meta" || filepath.Ext(entry.Name()) == ".tmp" {
+			continue
+		}
+		info, err := d.Stat(ctx, entry.Name())
+		if err != nil {
+			continue
+		}
+		objects = append(objects, info)
+	}
+	return objects, nil
+}
+
+// Stat describes a single stored object without reading its contents.
+func (d *DiskStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	info, err := os.Stat(d.dataPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ObjectInfo{}, ErrNotFound
+		}
+		return ObjectInfo{}, fmt.Errorf("failed to stat %q: %w", key, err)
+	}
+	expiresAt, _ := d.readExpiry(key)
+	return ObjectInfo{
+		Key:       key,
+		Size:      info.Size(),
+		CreatedAt: info.ModTime(),
+		Age:       time.Since(info.ModTime()),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (d *DiskStorage) readExpiry(key string) (time.Time, bool) {
+	raw, err := os.ReadFile(d.metaPath(key))
+	if err != nil {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, string(raw))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
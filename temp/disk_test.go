@@ -0,0 +1,122 @@
+package temp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestDiskStorage_StoreGetDelete(t *testing.T) {
+	ds, err := temp.NewDiskStorage(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := ds.Store(ctx, "a.txt", strings.NewReader("hello"), 5, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rc, err := ds.Get(ctx, "a.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", data)
+	}
+
+	if err := ds.Delete(ctx, "a.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ds.Get(ctx, "a.txt"); !errors.Is(err, temp.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDiskStorage_SetTTLUpdatesExpiresAt(t *testing.T) {
+	ds, err := temp.NewDiskStorage(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := ds.Store(ctx, "a.txt", strings.NewReader("hello"), 5, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ds.SetTTL(ctx, "a.txt", time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := ds.Stat(ctx, "a.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.ExpiresAt.Before(time.Now().Add(50 * time.Minute)) {
+		t.Fatalf("expected ExpiresAt to reflect the extended ttl, got %v", info.ExpiresAt)
+	}
+}
+
+func TestDiskStorage_GetRange(t *testing.T) {
+	ds, err := temp.NewDiskStorage(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := ds.Store(ctx, "a.txt", strings.NewReader("hello world"), 11, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rc, err := ds.GetRange(ctx, "a.txt", 6, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "world" {
+		t.Fatalf("expected %q, got %q", "world", data)
+	}
+}
+
+func TestDiskStorage_StoreRejectsOverCapacity(t *testing.T) {
+	ds, err := temp.NewDiskStorage(t.TempDir(), 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := ds.Store(context.Background(), "big.txt", strings.NewReader("hello"), 5, time.Hour); !errors.Is(err, temp.ErrCapacityExceeded) {
+		t.Fatalf("expected ErrCapacityExceeded, got %v", err)
+	}
+}
+
+func TestDiskStorage_RecoverRemovesExpired(t *testing.T) {
+	dir := t.TempDir()
+	ds, err := temp.NewDiskStorage(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := ds.Store(ctx, "stale.txt", strings.NewReader("hi"), 2, -time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fresh, err := temp.NewDiskStorage(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fresh.Recover(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := fresh.Get(ctx, "stale.txt"); !errors.Is(err, temp.ErrNotFound) {
+		t.Fatalf("expected stale file to be removed on recovery, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package temp
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrDecryptionFailed is returned when a stored object cannot be decrypted,
+// e.g. because its key has been revoked or the ciphertext is corrupt. It is
+// distinct from ErrNotFound so callers such as the approval flow can tell
+// "missing" apart from "present but unreadable".
+var ErrDecryptionFailed = errors.New("temp storage: failed to decrypt object")
+
+// KeyProvider resolves encryption keys by ID and reports the current key ID
+// new objects should be encrypted with. Implementations back onto config or
+// a KMS data-key cache; rotating the current key ID lets old objects
+// (still readable via their stored key ID) coexist with new ones.
+type KeyProvider interface {
+	CurrentKeyID() string
+	Key(keyID string) ([32]byte, error)
+}
+
+// EncryptedStorage wraps another Storage backend, transparently encrypting
+// objects with AES-256-GCM before they reach it and decrypting them on Get.
+// Each object is prefixed with the ID of the key used to encrypt it, so
+// rotating KeyProvider's current key doesn't break decryption of
+// already-stored objects.
+type EncryptedStorage struct {
+	backend Storage
+	keys    KeyProvider
+}
+
+// NewEncryptedStorage wraps backend with transparent encryption using keys.
+func NewEncryptedStorage(backend Storage, keys KeyProvider) *EncryptedStorage {
+	return &EncryptedStorage{backend: backend, keys: keys}
+}
+
+// Store encrypts data under the current key before delegating to backend.
+func (e *EncryptedStorage) Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error {
+	plaintext, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("failed to read %q for encryption: %w", key, err)
+	}
+
+	keyID := e.keys.CurrentKeyID()
+	encKey, err := e.keys.Key(keyID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve encryption key %q: %w", keyID, err)
+	}
+
+	ciphertext, err := seal(encKey, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %q: %w", key, err)
+	}
+
+	envelope := encodeEnvelope(keyID, ciphertext)
+	return e.backend.Store(ctx, key, bytes.NewReader(envelope), int64(len(envelope)), ttl)
+}
+
+// Get decrypts the object at key using the key ID recorded in its envelope.
+func (e *EncryptedStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	rc, err := e.backend.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	envelope, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", key, err)
+	}
+
+	keyID, ciphertext, err := decodeEnvelope(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: malformed envelope: %v", ErrDecryptionFailed, key, err)
+	}
+
+	encKey, err := e.keys.Key(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: unknown key id %q", ErrDecryptionFailed, key, keyID)
+	}
+
+	plaintext, err := open(encKey, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrDecryptionFailed, key, err)
+	}
+
+	return io.NopCloser(bytes.NewReader(plaintext)), nil
+}
+
+// Delete delegates to the backend.
+func (e *EncryptedStorage) Delete(ctx context.Context, key string) error {
+	return e.backend.Delete(ctx, key)
+}
+
+// SetTTL delegates to the backend.
+func (e *EncryptedStorage) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	return e.backend.SetTTL(ctx, key, ttl)
+}
+
+func seal(key [32]byte, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key [32]byte, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+// encodeEnvelope prefixes ciphertext with a length-delimited key ID so the
+// key used to encrypt an object travels with it.
+func encodeEnvelope(keyID string, ciphertext []byte) []byte {
+	buf := make([]byte, 0, 2+len(keyID)+len(ciphertext))
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(keyID)))
+	buf = append(buf, keyID...)
+	buf = append(buf, ciphertext...)
+	return buf
+}
+
+func decodeEnvelope(envelope []byte) (keyID string, ciphertext []byte, err error) {
+	if len(envelope) < 2 {
+		return "", nil, errors.New("envelope too short")
+	}
+	idLen := binary.BigEndian.Uint16(envelope[:2])
+	if len(envelope) < 2+int(idLen) {
+		return "", nil, errors.New("envelope truncated")
+	}
+	keyID = string(envelope[2 : 2+idLen])
+	ciphertext = envelope[2+idLen:]
+	return keyID, ciphertext, nil
+}
@@ -0,0 +1,106 @@
+package temp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestEncryptedStorage_RoundTrip(t *testing.T) {
+	backend := newMemStorage()
+	keys := &temp.StaticKeyProvider{CurrentID: "k1", Keys: map[string][32]byte{"k1": {1}}}
+	enc := temp.NewEncryptedStorage(backend, keys)
+
+	ctx := context.Background()
+	if err := enc.Store(ctx, "a", strings.NewReader("secret"), 6, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if backend.data["a"] != nil && strings.Contains(string(backend.data["a"]), "secret") {
+		t.Fatalf("expected ciphertext at rest, found plaintext")
+	}
+
+	rc, err := enc.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "secret" {
+		t.Fatalf("expected %q, got %q", "secret", data)
+	}
+}
+
+func TestEncryptedStorage_KeyRotationKeepsOldObjectsReadable(t *testing.T) {
+	backend := newMemStorage()
+	keys := &temp.StaticKeyProvider{CurrentID: "k1", Keys: map[string][32]byte{"k1": {1}}}
+	enc := temp.NewEncryptedStorage(backend, keys)
+
+	ctx := context.Background()
+	if err := enc.Store(ctx, "a", strings.NewReader("secret"), 6, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys.CurrentID = "k2"
+	keys.Keys["k2"] = [32]byte{2}
+
+	rc, err := enc.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("expected object encrypted with old key to remain readable: %v", err)
+	}
+	rc.Close()
+}
+
+func TestEncryptedStorage_UnknownKeyReturnsDecryptionFailed(t *testing.T) {
+	backend := newMemStorage()
+	keys := &temp.StaticKeyProvider{CurrentID: "k1", Keys: map[string][32]byte{"k1": {1}}}
+	enc := temp.NewEncryptedStorage(backend, keys)
+
+	ctx := context.Background()
+	if err := enc.Store(ctx, "a", strings.NewReader("secret"), 6, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delete(keys.Keys, "k1")
+
+	if _, err := enc.Get(ctx, "a"); !errors.Is(err, temp.ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+}
+
+// memStorage is a minimal in-memory Storage used to assert on ciphertext at
+// rest without depending on the real InMemoryStorage implementation.
+type memStorage struct {
+	data map[string][]byte
+}
+
+func newMemStorage() *memStorage { return &memStorage{data: map[string][]byte{}} }
+
+func (m *memStorage) Store(_ context.Context, key string, data io.Reader, _ int64, _ time.Duration) error {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	m.data[key] = b
+	return nil
+}
+
+func (m *memStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	b, ok := m.data[key]
+	if !ok {
+		return nil, temp.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(string(b))), nil
+}
+
+func (m *memStorage) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memStorage) SetTTL(_ context.Context, _ string, _ time.Duration) error { return nil }
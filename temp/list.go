@@ -0,0 +1,40 @@
+package temp
+
+import (
+	"context"
+	"time"
+)
+
+// ObjectInfo describes a single object held in temp storage, for
+// introspection by operators.
+type ObjectInfo struct {
+	Key       string
+	Size      int64
+	CreatedAt time.Time
+	Age       time.Duration
+	// ExpiresAt is when the object's current TTL, as last set by Store or
+	// SetTTL, elapses. The zero value means the backend doesn't track it
+	// (e.g. it was stored with no TTL), so callers should fall back to
+	// deriving an estimate from CreatedAt/Age and a known TTL instead.
+	ExpiresAt time.Time
+}
+
+// Lister is implemented by backends that can enumerate their stored
+// objects. Not all backends need to support it directly; check via a type
+// assertion (e.g. `if l, ok := storage.(temp.Lister); ok { ... }`).
+type Lister interface {
+	List(ctx context.Context) ([]ObjectInfo, error)
+}
+
+// Stater is implemented by backends that can describe a single stored
+// object without fetching its contents.
+type Stater interface {
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+}
+
+// Pinger is implemented by backends that can verify connectivity to their
+// underlying store (e.g. a Redis PING) without a full read/write round
+// trip, suitable for use in a startup preflight check.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
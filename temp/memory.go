@@ -0,0 +1,225 @@
+package temp
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// InMemoryStorage is a process-local implementation of Storage, used as a
+// fallback in dev/staging when no Redis is configured. Objects are evicted
+// once their TTL expires (checked by a background janitor) or, if MaxBytes
+// is exceeded, oldest-first, so the fallback mode can't grow unbounded.
+type InMemoryStorage struct {
+	MaxBytes int64
+
+	mu        sync.Mutex
+	objects   map[string]*memObject
+	order     *list.List // oldest-first list of keys, for LRU-style eviction
+	elements  map[string]*list.Element
+	totalSize int64
+
+	evictions int64
+
+	stopJanitor chan struct{}
+}
+
+type memObject struct {
+	data      []byte
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewInMemoryStorage returns an InMemoryStorage capped at maxBytes total
+// (0 means unlimited) with a background janitor sweeping expired keys every
+// janitorInterval.
+func NewInMemoryStorage(maxBytes int64, janitorInterval time.Duration) *InMemoryStorage {
+	s := &InMemoryStorage{
+		MaxBytes:    maxBytes,
+		objects:     map[string]*memObject{},
+		order:       list.New(),
+		elements:    map[string]*list.Element{},
+		stopJanitor: make(chan struct{}),
+	}
+	if janitorInterval > 0 {
+		go s.runJanitor(janitorInterval)
+	}
+	return s
+}
+
+// Close stops the background janitor goroutine.
+func (s *InMemoryStorage) Close() {
+	close(s.stopJanitor)
+}
+
+func (s *InMemoryStorage) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.sweepExpired()
+		case <-s.stopJanitor:
+			return
+		}
+	}
+}
+
+func (s *InMemoryStorage) sweepExpired() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, obj := range s.objects {
+		if !obj.expiresAt.IsZero() && now.After(obj.expiresAt) {
+			s.removeLocked(key)
+		}
+	}
+}
+
+// Store keeps data in memory, evicting the oldest objects first if adding it
+// would exceed MaxBytes.
+func (s *InMemoryStorage) Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error {
+	buf, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.MaxBytes > 0 {
+		if len(buf) > int(s.MaxBytes) {
+			return ErrCapacityExceeded
+		}
+		for s.totalSize+int64(len(buf)) > s.MaxBytes && s.order.Len() > 0 {
+			oldest := s.order.Front()
+			s.removeLocked(oldest.Value.(string))
+			s.evictions++
+		}
+	}
+
+	if existing, ok := s.objects[key]; ok {
+		s.totalSize -= int64(len(existing.data))
+		s.order.Remove(s.elements[key])
+	}
+
+	// ttl == 0 means unlimited (zero expiresAt); ttl < 0 means already
+	// expired, which we still need a non-zero expiresAt to represent so
+	// Get's IsZero check doesn't mistake it for unlimited.
+	var expiresAt time.Time
+	switch {
+	case ttl > 0:
+		expiresAt = time.Now().Add(ttl)
+	case ttl < 0:
+		expiresAt = time.Now()
+	}
+
+	s.objects[key] = &memObject{data: buf, expiresAt: expiresAt, createdAt: time.Now()}
+	s.elements[key] = s.order.PushBack(key)
+	s.totalSize += int64(len(buf))
+
+	return nil
+}
+
+// Get returns the stored data for key.
+func (s *InMemoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !obj.expiresAt.IsZero() && time.Now().After(obj.expiresAt) {
+		s.removeLocked(key)
+		return nil, ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(obj.data)), nil
+}
+
+// Delete removes key.
+func (s *InMemoryStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(key)
+	return nil
+}
+
+// SetTTL updates the expiry of an already-stored key.
+func (s *InMemoryStorage) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return ErrNotFound
+	}
+	if ttl > 0 {
+		obj.expiresAt = time.Now().Add(ttl)
+	} else {
+		obj.expiresAt = time.Time{}
+	}
+	return nil
+}
+
+// Evictions reports the number of objects evicted to stay under MaxBytes,
+// for use as a metric.
+func (s *InMemoryStorage) Evictions() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.evictions
+}
+
+// List enumerates all objects currently held in memory.
+func (s *InMemoryStorage) List(ctx context.Context) ([]ObjectInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := make([]ObjectInfo, 0, len(s.objects))
+	for key, obj := range s.objects {
+		objects = append(objects, ObjectInfo{
+			Key:       key,
+			Size:      int64(len(obj.data)),
+			CreatedAt: obj.createdAt,
+			Age:       time.Since(obj.createdAt),
+			ExpiresAt: obj.expiresAt,
+		})
+	}
+	return objects, nil
+}
+
+// Stat describes a single stored object without fetching its contents.
+func (s *InMemoryStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return ObjectInfo{
+		Key:       key,
+		Size:      int64(len(obj.data)),
+		CreatedAt: obj.createdAt,
+		Age:       time.Since(obj.createdAt),
+		ExpiresAt: obj.expiresAt,
+	}, nil
+}
+
+func (s *InMemoryStorage) removeLocked(key string) {
+	obj, ok := s.objects[key]
+	if !ok {
+		return
+	}
+	s.totalSize -= int64(len(obj.data))
+	delete(s.objects, key)
+	if el, ok := s.elements[key]; ok {
+		s.order.Remove(el)
+		delete(s.elements, key)
+	}
+}
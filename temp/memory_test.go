@@ -0,0 +1,66 @@
+package temp_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func TestInMemoryStorage_TTLExpiry(t *testing.T) {
+	s := temp.NewInMemoryStorage(0, 0)
+	defer s.Close()
+
+	ctx := context.Background()
+	if err := s.Store(ctx, "a", strings.NewReader("data"), 4, -time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, temp.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for already-expired key, got %v", err)
+	}
+}
+
+func TestInMemoryStorage_SetTTLUpdatesExpiresAt(t *testing.T) {
+	s := temp.NewInMemoryStorage(0, 0)
+	defer s.Close()
+
+	ctx := context.Background()
+	if err := s.Store(ctx, "a", strings.NewReader("data"), 4, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.SetTTL(ctx, "a", time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := s.Stat(ctx, "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.ExpiresAt.Before(time.Now().Add(50 * time.Minute)) {
+		t.Fatalf("expected ExpiresAt to reflect the extended ttl, got %v", info.ExpiresAt)
+	}
+}
+
+func TestInMemoryStorage_EvictsOldestOverCapacity(t *testing.T) {
+	s := temp.NewInMemoryStorage(10, 0)
+	defer s.Close()
+
+	ctx := context.Background()
+	if err := s.Store(ctx, "a", strings.NewReader("0123456789"), 10, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Store(ctx, "b", strings.NewReader("0123456789"), 10, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, temp.ErrNotFound) {
+		t.Fatalf("expected oldest key to have been evicted, got %v", err)
+	}
+	if s.Evictions() != 1 {
+		t.Fatalf("expected 1 eviction, got %d", s.Evictions())
+	}
+}
@@ -0,0 +1,19 @@
+package temp
+
+import (
+	"context"
+	"io"
+)
+
+// RangeReader is implemented by backends that can read a byte range of a
+// stored object without fetching the whole thing, so a reviewer dashboard
+// can preview only the start of a large staged file. Not all backends need
+// to support it directly; check via a type assertion (e.g. `if rr, ok :=
+// storage.(temp.RangeReader); ok { ... }`). A backend that doesn't
+// implement it can still be read in full via Get.
+type RangeReader interface {
+	// GetRange returns the data stored at key starting at offset, up to
+	// length bytes. length <= 0 means everything from offset to the end of
+	// the object.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
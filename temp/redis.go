@@ -0,0 +1,196 @@
+package temp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// usageKey tracks the running total of bytes held in temp storage so that
+// RedisStorage can enforce MaxTotalBytes without a full key scan.
+const usageKey = "temp_storage:usage_bytes"
+
+// createdAtHashKey records when each key was stored, since Redis does not
+// expose an object's original creation time. Entries here can outlive the
+// key they describe (it may have expired naturally); List/Stat treat a
+// missing underlying key as evidence the entry is stale and skip it.
+const createdAtHashKey = "temp_storage:created_at"
+
+// RedisStorage is a Redis-backed implementation of Storage, suitable for
+// smaller pending files that benefit from Redis's speed and don't need to
+// survive a Redis restart.
+type RedisStorage struct {
+	client       *redis.Client
+	maxTotalSize int64
+	maxKeySize   int64
+}
+
+// NewRedisStorage returns a RedisStorage backed by client, rejecting any
+// single object larger than maxKeySize or that would push total usage above
+// maxTotalSize.
+func NewRedisStorage(client *redis.Client, maxTotalSize, maxKeySize int64) *RedisStorage {
+	return &RedisStorage{client: client, maxTotalSize: maxTotalSize, maxKeySize: maxKeySize}
+}
+
+// Store writes data under key with the given ttl, applied atomically via
+// Redis's SET...EX so keys can never be left without an expiry.
+func (r *RedisStorage) Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error {
+	if r.maxKeySize > 0 && size > r.maxKeySize {
+		return fmt.Errorf("%w: object of %d bytes exceeds per-key limit of %d bytes", ErrCapacityExceeded, size, r.maxKeySize)
+	}
+
+	if r.maxTotalSize > 0 {
+		used, err := r.client.Get(ctx, usageKey).Int64()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to read temp storage usage: %w", err)
+		}
+		if used+size > r.maxTotalSize {
+			return fmt.Errorf("%w: %d bytes used, %d requested, limit %d", ErrCapacityExceeded, used, size, r.maxTotalSize)
+		}
+	}
+
+	buf, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("failed to read temp storage payload: %w", err)
+	}
+
+	if err := r.client.Set(ctx, key, buf, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store %q in redis: %w", key, err)
+	}
+
+	if err := r.client.IncrBy(ctx, usageKey, int64(len(buf))).Err(); err != nil {
+		return fmt.Errorf("failed to update temp storage usage counter: %w", err)
+	}
+
+	if err := r.client.HSet(ctx, createdAtHashKey, key, time.Now().Unix()).Err(); err != nil {
+		return fmt.Errorf("failed to record creation time for %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get returns the stored data for key.
+func (r *RedisStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	val, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get %q from redis: %w", key, err)
+	}
+	return io.NopCloser(bytes.NewReader(val)), nil
+}
+
+// Delete removes key and decrements the usage counter by its size.
+func (r *RedisStorage) Delete(ctx context.Context, key string) error {
+	size, err := r.client.StrLen(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to size %q before delete: %w", key, err)
+	}
+
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to delete %q from redis: %w", key, err)
+	}
+
+	if size > 0 {
+		if err := r.client.DecrBy(ctx, usageKey, size).Err(); err != nil {
+			return fmt.Errorf("failed to update temp storage usage counter: %w", err)
+		}
+	}
+
+	_ = r.client.HDel(ctx, createdAtHashKey, key).Err()
+
+	return nil
+}
+
+// SetTTL updates the expiry of an already-stored key.
+func (r *RedisStorage) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	ok, err := r.client.Expire(ctx, key, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to set ttl on %q: %w", key, err)
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List enumerates all keys currently stored, skipping the internal
+// bookkeeping keys used for usage tracking and creation times.
+func (r *RedisStorage) List(ctx context.Context) ([]ObjectInfo, error) {
+	created, err := r.client.HGetAll(ctx, createdAtHashKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list temp storage creation times: %w", err)
+	}
+
+	var objects []ObjectInfo
+	for key := range created {
+		info, err := r.Stat(ctx, key)
+		if err != nil {
+			if err == ErrNotFound {
+				continue // key expired naturally; creation-time entry is stale
+			}
+			return nil, err
+		}
+		objects = append(objects, info)
+	}
+	return objects, nil
+}
+
+// Stat describes a single stored object without fetching its contents.
+func (r *RedisStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	size, err := r.client.StrLen(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return ObjectInfo{}, ErrNotFound
+		}
+		return ObjectInfo{}, fmt.Errorf("failed to stat %q: %w", key, err)
+	}
+	if size == 0 {
+		return ObjectInfo{}, ErrNotFound
+	}
+
+	var createdAt time.Time
+	if ts, err := r.client.HGet(ctx, createdAtHashKey, key).Int64(); err == nil {
+		createdAt = time.Unix(ts, 0)
+	}
+
+	var expiresAt time.Time
+	if ttl, err := r.client.TTL(ctx, key).Result(); err == nil && ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	return ObjectInfo{
+		Key:       key,
+		Size:      size,
+		CreatedAt: createdAt,
+		Age:       time.Since(createdAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// UsageBytes reports the total number of bytes currently tracked in temp
+// storage, for use as a metric.
+func (r *RedisStorage) UsageBytes(ctx context.Context) (int64, error) {
+	used, err := r.client.Get(ctx, usageKey).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read temp storage usage: %w", err)
+	}
+	return used, nil
+}
+
+// Ping verifies connectivity to the underlying Redis server, satisfying
+// Pinger for use in a startup preflight check.
+func (r *RedisStorage) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping redis: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package temp_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ONSdigital/dis-imf-uploader/temp"
+)
+
+func newTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	return redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestRedisStorage_StoreAppliesTTL(t *testing.T) {
+	client := newTestRedis(t)
+	rs := temp.NewRedisStorage(client, 0, 0)
+
+	if err := rs.Store(context.Background(), "key1", strings.NewReader("data"), 4, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ttl, err := client.TTL(context.Background(), "key1").Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ttl <= 0 {
+		t.Fatalf("expected a positive ttl to have been set, got %v", ttl)
+	}
+}
+
+func TestRedisStorage_ListAndStat(t *testing.T) {
+	client := newTestRedis(t)
+	rs := temp.NewRedisStorage(client, 0, 0)
+	ctx := context.Background()
+
+	if err := rs.Store(ctx, "key1", strings.NewReader("data"), 4, time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	objects, err := rs.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "key1" || objects[0].Size != 4 {
+		t.Fatalf("unexpected objects: %+v", objects)
+	}
+
+	info, err := rs.Stat(ctx, "key1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Size != 4 {
+		t.Fatalf("expected size 4, got %d", info.Size)
+	}
+	if info.ExpiresAt.Before(time.Now().Add(50 * time.Minute)) {
+		t.Fatalf("expected ExpiresAt to reflect the stored ttl, got %v", info.ExpiresAt)
+	}
+}
+
+func TestRedisStorage_SetTTLUpdatesExpiresAt(t *testing.T) {
+	client := newTestRedis(t)
+	rs := temp.NewRedisStorage(client, 0, 0)
+	ctx := context.Background()
+
+	if err := rs.Store(ctx, "key1", strings.NewReader("data"), 4, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rs.SetTTL(ctx, "key1", time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := rs.Stat(ctx, "key1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.ExpiresAt.Before(time.Now().Add(50 * time.Minute)) {
+		t.Fatalf("expected ExpiresAt to reflect the extended ttl, got %v", info.ExpiresAt)
+	}
+}
+
+func TestRedisStorage_StoreRejectsOverCapacity(t *testing.T) {
+	client := newTestRedis(t)
+	rs := temp.NewRedisStorage(client, 10, 0)
+
+	if err := rs.Store(context.Background(), "big", strings.NewReader("0123456789extra"), 15, 0); !errors.Is(err, temp.ErrCapacityExceeded) {
+		t.Fatalf("expected ErrCapacityExceeded, got %v", err)
+	}
+}
+
+func TestRedisStorage_Ping(t *testing.T) {
+	client := newTestRedis(t)
+	rs := temp.NewRedisStorage(client, 0, 0)
+
+	if err := rs.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package temp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// s3Client is the subset of *s3.Client's methods S3Storage depends on,
+// allowing tests to inject a fake rather than talk to real S3.
+type s3Client interface {
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+}
+
+// S3Storage is an S3-backed implementation of Storage for pending files too
+// large to comfortably hold in Redis. Objects are written under Prefix in
+// Bucket, which is expected to have a lifecycle rule expiring objects after
+// the longest TTL the service applies (SetTTL re-tags the object's expiry
+// metadata but relies on the bucket lifecycle rule to actually delete it,
+// since S3 objects have no native per-object TTL).
+type S3Storage struct {
+	client s3Client
+	Bucket string
+	Prefix string
+}
+
+// NewS3Storage returns an S3Storage writing objects to bucket under prefix.
+func NewS3Storage(client *s3.Client, bucket, prefix string) *S3Storage {
+	return &S3Storage{client: client, Bucket: bucket, Prefix: prefix}
+}
+
+func (s *S3Storage) objectKey(key string) string {
+	return s.Prefix + "/" + key
+}
+
+// Store uploads data to S3, tagging the object with the expiry time derived
+// from ttl so it can be surfaced via List/Stat even though S3 itself only
+// expires objects via the bucket's lifecycle configuration.
+func (s *S3Storage) Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.Bucket),
+		Key:           aws.String(s.objectKey(key)),
+		Body:          data,
+		ContentLength: aws.Int64(size),
+		Metadata: map[string]string{
+			"expires-at": expiresAt.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put %q in s3 temp storage: %w", key, err)
+	}
+	return nil
+}
+
+// Get returns the stored data for key.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %q from s3 temp storage: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+// GetRange returns the byte range [offset, offset+length) of key via S3's
+// native Range header, so a caller previewing a large object doesn't have
+// to download it in full first. length <= 0 requests everything from
+// offset to the end of the object.
+func (s *S3Storage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	rangeHeader := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		rangeHeader = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+		Range:  aws.String(rangeHeader),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range of %q from s3 temp storage: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+// Delete removes key from S3.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	}); err != nil {
+		return fmt.Errorf("failed to delete %q from s3 temp storage: %w", key, err)
+	}
+	return nil
+}
+
+// CheckFileExists reports whether key is present in S3. A genuine "not
+// found" is reported as (false, nil); any other failure (permissions,
+// throttling, network) is propagated rather than swallowed, since callers
+// such as BackupFile must not treat an unrelated S3 error as "safe to
+// overwrite".
+func (s *S3Storage) CheckFileExists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "404") {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to check existence of %q in s3 temp storage: %w", key, err)
+}
+
+// SetTTL re-uploads the object's metadata with a new expires-at tag. S3 has
+// no native per-object TTL, so actual deletion still relies on the bucket
+// lifecycle rule; this only affects what List/Stat report.
+func (s *S3Storage) SetTTL(ctx context.Context, key string, ttl time.Duration) error {
+	objKey := s.objectKey(key)
+	expiresAt := time.Now().Add(ttl)
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(s.Bucket),
+		Key:               aws.String(objKey),
+		CopySource:        aws.String(s.Bucket + "/" + objKey),
+		Metadata:          map[string]string{"expires-at": expiresAt.Format(time.RFC3339)},
+		MetadataDirective: types.MetadataDirectiveReplace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set ttl on %q: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package temp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+type fakeS3Client struct {
+	headObjectFunc func(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+}
+
+func (f *fakeS3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return f.headObjectFunc(ctx, params, optFns...)
+}
+
+func (f *fakeS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeS3Client) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeS3Client) CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+type genericAPIError struct {
+	code string
+}
+
+func (e *genericAPIError) Error() string       { return "api error: " + e.code }
+func (e *genericAPIError) ErrorCode() string    { return e.code }
+func (e *genericAPIError) ErrorMessage() string { return e.code }
+func (e *genericAPIError) ErrorFault() smithy.ErrorFault {
+	return smithy.FaultUnknown
+}
+
+func newTestS3Storage(client s3Client) *S3Storage {
+	return &S3Storage{client: client, Bucket: "imf-temp", Prefix: "pending"}
+}
+
+func TestS3StorageCheckFileExists(t *testing.T) {
+	t.Run("returns true when the object is found", func(t *testing.T) {
+		s := newTestS3Storage(&fakeS3Client{
+			headObjectFunc: func(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+				return &s3.HeadObjectOutput{}, nil
+			},
+		})
+
+		exists, err := s.CheckFileExists(context.Background(), "a.csv")
+		if err != nil || !exists {
+			t.Fatalf("expected (true, nil), got (%v, %v)", exists, err)
+		}
+	})
+
+	t.Run("returns false, nil for a typed NotFound error", func(t *testing.T) {
+		s := newTestS3Storage(&fakeS3Client{
+			headObjectFunc: func(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+				return nil, &types.NotFound{}
+			},
+		})
+
+		exists, err := s.CheckFileExists(context.Background(), "a.csv")
+		if err != nil || exists {
+			t.Fatalf("expected (false, nil), got (%v, %v)", exists, err)
+		}
+	})
+
+	t.Run("returns false, nil for a smithy APIError with code NotFound", func(t *testing.T) {
+		s := newTestS3Storage(&fakeS3Client{
+			headObjectFunc: func(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+				return nil, &genericAPIError{code: "NotFound"}
+			},
+		})
+
+		exists, err := s.CheckFileExists(context.Background(), "a.csv")
+		if err != nil || exists {
+			t.Fatalf("expected (false, nil), got (%v, %v)", exists, err)
+		}
+	})
+
+	t.Run("propagates unrelated errors instead of swallowing them", func(t *testing.T) {
+		s := newTestS3Storage(&fakeS3Client{
+			headObjectFunc: func(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+				return nil, &genericAPIError{code: "AccessDenied"}
+			},
+		})
+
+		exists, err := s.CheckFileExists(context.Background(), "a.csv")
+		if err == nil || exists {
+			t.Fatalf("expected the access-denied error to be propagated, got (%v, %v)", exists, err)
+		}
+	})
+}
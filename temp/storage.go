@@ -0,0 +1,30 @@
+// Package temp defines pluggable backends for temporary storage of files
+// pending review, before they are approved and published to S3.
+package temp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist in temp storage.
+var ErrNotFound = errors.New("temp storage: key not found")
+
+// ErrCapacityExceeded is returned when storing an object would exceed the
+// configured capacity limit for the backend.
+var ErrCapacityExceeded = errors.New("temp storage: capacity exceeded")
+
+// Storage is implemented by each temp-storage backend (Redis, S3, disk,
+// in-memory).
+type Storage interface {
+	// Store writes data under key, expiring it automatically after ttl.
+	Store(ctx context.Context, key string, data io.Reader, size int64, ttl time.Duration) error
+	// Get returns the stored data for key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes key, if present.
+	Delete(ctx context.Context, key string) error
+	// SetTTL updates the expiry of an already-stored key.
+	SetTTL(ctx context.Context, key string, ttl time.Duration) error
+}
@@ -0,0 +1,143 @@
+package validate
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"regexp"
+)
+
+// DiffSummary describes a structural comparison between the file currently
+// published at an upload's target key and the staged replacement.
+type DiffSummary struct {
+	RowDelta    int   `bson:"row_delta,omitempty"    json:"row_delta,omitempty"`
+	ColumnDelta int   `bson:"column_delta,omitempty" json:"column_delta,omitempty"`
+	PageDelta   int   `bson:"page_delta,omitempty"   json:"page_delta,omitempty"`
+	SizeDelta   int64 `bson:"size_delta"             json:"size_delta"`
+}
+
+// DiffCSV compares row and column counts between the currently published
+// CSV file and the incoming replacement. A file that fails to parse as CSV
+// is treated as having no rows or columns, so the delta still reflects the
+// size difference.
+func DiffCSV(published, incoming []byte) DiffSummary {
+	publishedRows, publishedCols := countCSV(published)
+	incomingRows, incomingCols := countCSV(incoming)
+
+	return DiffSummary{
+		RowDelta:    incomingRows - publishedRows,
+		ColumnDelta: incomingCols - publishedCols,
+		SizeDelta:   int64(len(incoming)) - int64(len(published)),
+	}
+}
+
+func countCSV(data []byte) (rows, cols int) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	for {
+		record, err := r.Read()
+		if err != nil {
+			break
+		}
+		rows++
+		if len(record) > cols {
+			cols = len(record)
+		}
+	}
+
+	return rows, cols
+}
+
+var (
+	xlsxRowPattern     = regexp.MustCompile(`<row[ >]`)
+	xlsxCellRefPattern = regexp.MustCompile(`r="([A-Z]+)\d+"`)
+)
+
+// DiffXLSX compares row and column counts between the first worksheet of
+// the currently published workbook and the incoming replacement.
+func DiffXLSX(published, incoming []byte) DiffSummary {
+	publishedRows, publishedCols := countXLSXSheet(published)
+	incomingRows, incomingCols := countXLSXSheet(incoming)
+
+	return DiffSummary{
+		RowDelta:    incomingRows - publishedRows,
+		ColumnDelta: incomingCols - publishedCols,
+		SizeDelta:   int64(len(incoming)) - int64(len(published)),
+	}
+}
+
+func countXLSXSheet(data []byte) (rows, cols int) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return 0, 0
+	}
+
+	for _, f := range reader.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return 0, 0
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return 0, 0
+		}
+
+		rows = len(xlsxRowPattern.FindAll(content, -1))
+		for _, match := range xlsxCellRefPattern.FindAllSubmatch(content, -1) {
+			if col := columnLetterToIndex(string(match[1])); col > cols {
+				cols = col
+			}
+		}
+	}
+
+	return rows, cols
+}
+
+func columnLetterToIndex(letters string) int {
+	index := 0
+	for _, ch := range letters {
+		index = index*26 + int(ch-'A'+1)
+	}
+	return index
+}
+
+var pdfTypeSpacePattern = regexp.MustCompile(`/Type\s+/Page`)
+
+// DiffPDF compares an approximate page count and total size between the
+// currently published PDF and the incoming replacement. Page counting scans
+// for "/Type/Page" object markers rather than walking the PDF's page tree,
+// which is enough to flag a materially different document for review.
+func DiffPDF(published, incoming []byte) DiffSummary {
+	return DiffSummary{
+		PageDelta: countPDFPages(incoming) - countPDFPages(published),
+		SizeDelta: int64(len(incoming)) - int64(len(published)),
+	}
+}
+
+func countPDFPages(data []byte) int {
+	normalized := pdfTypeSpacePattern.ReplaceAll(data, []byte("/Type/Page"))
+	marker := []byte("/Type/Page")
+
+	count := 0
+	for i := 0; i < len(normalized); {
+		idx := bytes.Index(normalized[i:], marker)
+		if idx < 0 {
+			break
+		}
+		pos := i + idx
+		end := pos + len(marker)
+		if end >= len(normalized) || normalized[end] != 's' {
+			count++
+		}
+		i = end
+	}
+
+	return count
+}
@@ -0,0 +1,53 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func TestDiffCSV_ReportsRowAndColumnDeltas(t *testing.T) {
+	published := []byte("a,b\n1,2\n")
+	incoming := []byte("a,b,c\n1,2,3\n4,5,6\n")
+
+	summary := validate.DiffCSV(published, incoming)
+
+	if summary.RowDelta != 1 {
+		t.Fatalf("expected row delta 1, got %d", summary.RowDelta)
+	}
+	if summary.ColumnDelta != 1 {
+		t.Fatalf("expected column delta 1, got %d", summary.ColumnDelta)
+	}
+	if summary.SizeDelta != int64(len(incoming)-len(published)) {
+		t.Fatalf("unexpected size delta: %d", summary.SizeDelta)
+	}
+}
+
+func TestDiffXLSX_ReportsRowAndColumnDeltas(t *testing.T) {
+	published := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1"/><c r="B1"/></row></sheetData></worksheet>`,
+	})
+	incoming := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1"/><c r="B1"/><c r="C1"/></row><row r="2"><c r="A2"/></row></sheetData></worksheet>`,
+	})
+
+	summary := validate.DiffXLSX(published, incoming)
+
+	if summary.RowDelta != 1 {
+		t.Fatalf("expected row delta 1, got %d", summary.RowDelta)
+	}
+	if summary.ColumnDelta != 1 {
+		t.Fatalf("expected column delta 1, got %d", summary.ColumnDelta)
+	}
+}
+
+func TestDiffPDF_ReportsPageDelta(t *testing.T) {
+	published := buildPDF("<< /Type /Page >>")
+	incoming := buildPDF("<< /Type /Page >>", "<< /Type /Page >>", "<< /Type /Pages /Kids [] >>")
+
+	summary := validate.DiffPDF(published, incoming)
+
+	if summary.PageDelta != 1 {
+		t.Fatalf("expected page delta 1, got %d", summary.PageDelta)
+	}
+}
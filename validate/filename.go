@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// FilenamePolicy configures the naming convention an uploaded file's
+// filename must follow, per dataset, e.g. "weo" -> `^imf_weo_\d{6}\.xlsx$`
+// for imf_weo_YYYYMM.xlsx. A dataset absent from Patterns has no naming
+// convention enforced.
+type FilenamePolicy struct {
+	Patterns map[string]*regexp.Regexp
+}
+
+// ValidateFilename checks filename against the pattern configured for
+// dataset, if any.
+func ValidateFilename(dataset, filename string, policy FilenamePolicy) *Result {
+	result := newResult()
+
+	pattern, ok := policy.Patterns[dataset]
+	if !ok || pattern == nil {
+		return result
+	}
+
+	if !pattern.MatchString(filename) {
+		result.addFinding(Finding{
+			Code:     "filename.pattern_mismatch",
+			Message:  fmt.Sprintf("filename %q does not match the naming convention for dataset %q", filename, dataset),
+			Severity: SeverityReject,
+		})
+	}
+
+	return result
+}
+
+// DetectDataset returns the dataset in policy whose pattern matches
+// filename, and whether exactly one was found. It is used when a caller
+// doesn't specify a dataset explicitly, e.g. a file exploded from a zip
+// bundle, so the dataset can still be recorded on the resulting upload. A
+// filename matching more than one dataset's pattern is ambiguous and is
+// reported as not found, since patterns are expected to be mutually
+// exclusive.
+func DetectDataset(filename string, policy FilenamePolicy) (dataset string, ok bool) {
+	for candidate, pattern := range policy.Patterns {
+		if pattern == nil || !pattern.MatchString(filename) {
+			continue
+		}
+		if ok {
+			return "", false
+		}
+		dataset, ok = candidate, true
+	}
+	return dataset, ok
+}
@@ -0,0 +1,61 @@
+package validate_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func weoPolicy() validate.FilenamePolicy {
+	return validate.FilenamePolicy{
+		Patterns: map[string]*regexp.Regexp{
+			"weo": regexp.MustCompile(`^imf_weo_\d{6}\.xlsx$`),
+			"gfs": regexp.MustCompile(`^imf_gfs_\d{6}\.xlsx$`),
+		},
+	}
+}
+
+func TestValidateFilename(t *testing.T) {
+	t.Run("accepts a filename matching its dataset's pattern", func(t *testing.T) {
+		result := validate.ValidateFilename("weo", "imf_weo_202401.xlsx", weoPolicy())
+
+		if !result.Valid {
+			t.Fatalf("expected valid result, got %+v", result)
+		}
+	})
+
+	t.Run("rejects a filename not matching its dataset's pattern", func(t *testing.T) {
+		result := validate.ValidateFilename("weo", "weo-january.xlsx", weoPolicy())
+
+		if result.Valid {
+			t.Fatalf("expected invalid result for a non-conforming filename")
+		}
+	})
+
+	t.Run("does not restrict a dataset with no configured pattern", func(t *testing.T) {
+		result := validate.ValidateFilename("unconfigured", "anything.xlsx", weoPolicy())
+
+		if !result.Valid {
+			t.Fatalf("expected an unconfigured dataset to pass, got %+v", result)
+		}
+	})
+}
+
+func TestDetectDataset(t *testing.T) {
+	t.Run("finds the dataset whose pattern matches", func(t *testing.T) {
+		dataset, ok := validate.DetectDataset("imf_gfs_202401.xlsx", weoPolicy())
+
+		if !ok || dataset != "gfs" {
+			t.Fatalf("expected to detect dataset %q, got %q (ok=%v)", "gfs", dataset, ok)
+		}
+	})
+
+	t.Run("reports not found when no pattern matches", func(t *testing.T) {
+		_, ok := validate.DetectDataset("notes.csv", weoPolicy())
+
+		if ok {
+			t.Fatalf("expected no dataset to be detected")
+		}
+	})
+}
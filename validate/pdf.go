@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// PDFAction selects how a PDF containing active content is handled.
+type PDFAction string
+
+const (
+	// PDFActionReject fails validation outright when active content is found.
+	PDFActionReject PDFAction = "reject"
+	// PDFActionSanitize strips the offending objects instead of rejecting
+	// the file, recording a warning finding for each one removed.
+	PDFActionSanitize PDFAction = "sanitize"
+)
+
+// PDFPolicy configures how PDF uploads are screened for active content.
+type PDFPolicy struct {
+	// Action selects reject or sanitize behaviour. The zero value behaves as
+	// PDFActionReject.
+	Action PDFAction
+}
+
+var pdfActiveContentMarkers = []struct {
+	code    string
+	marker  []byte
+	message string
+}{
+	{"pdf.embedded_javascript", []byte("/JavaScript"), "document contains embedded JavaScript"},
+	{"pdf.embedded_javascript", []byte("/JS"), "document contains an embedded JavaScript action"},
+	{"pdf.launch_action", []byte("/Launch"), "document contains a launch action"},
+	{"pdf.embedded_file", []byte("/EmbeddedFile"), "document contains an embedded file attachment"},
+}
+
+// pdfObjectPattern matches a single indirect object, "<n> <gen> obj ...
+// endobj", so offending objects can be located and stripped independently
+// of where they fall in the file.
+var pdfObjectPattern = regexp.MustCompile(`(?s)\d+ \d+ obj.*?endobj`)
+
+// ValidatePDF scans data for embedded JavaScript, launch actions and file
+// attachments, recording a finding for each marker found. Findings are
+// SeverityReject under PDFActionReject (the default) and SeverityWarning
+// under PDFActionSanitize, since sanitization is expected to remove the
+// offending content before the file can be approved.
+func ValidatePDF(data []byte, policy PDFPolicy) *Result {
+	result := newResult()
+
+	severity := SeverityReject
+	if policy.Action == PDFActionSanitize {
+		severity = SeverityWarning
+	}
+
+	for _, m := range pdfActiveContentMarkers {
+		if bytes.Contains(data, m.marker) {
+			result.addFinding(Finding{Code: m.code, Message: m.message, Severity: severity})
+		}
+	}
+
+	return result
+}
+
+// SanitizePDF returns a copy of data with every indirect object that
+// contains an active-content marker replaced by an empty dictionary object,
+// neutralising embedded JavaScript, launch actions and attachments while
+// leaving the rest of the document's objects untouched. It is a targeted
+// pass over the active-content markers ValidatePDF checks for, not a
+// general-purpose PDF parser.
+func SanitizePDF(data []byte) []byte {
+	return pdfObjectPattern.ReplaceAllFunc(data, func(obj []byte) []byte {
+		for _, m := range pdfActiveContentMarkers {
+			if bytes.Contains(obj, m.marker) {
+				return []byte("0 0 obj\n<< >>\nendobj")
+			}
+		}
+		return obj
+	})
+}
@@ -0,0 +1,76 @@
+package validate_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func buildPDF(objects ...string) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("%PDF-1.7\n")
+	for i, body := range objects {
+		buf.WriteString(itoa(i+1) + " 0 obj\n" + body + "\nendobj\n")
+	}
+	buf.WriteString("%%EOF")
+	return buf.Bytes()
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	digits := ""
+	for n > 0 {
+		digits = string(rune('0'+n%10)) + digits
+		n /= 10
+	}
+	return digits
+}
+
+func TestValidatePDF_RejectsEmbeddedJavaScript(t *testing.T) {
+	data := buildPDF("<< /S /JavaScript /JS (app.alert('hi')) >>")
+
+	result := validate.ValidatePDF(data, validate.PDFPolicy{})
+
+	if result.Valid {
+		t.Fatalf("expected invalid result for embedded JavaScript")
+	}
+}
+
+func TestValidatePDF_SanitizeModeWarnsInsteadOfRejecting(t *testing.T) {
+	data := buildPDF("<< /S /Launch /F (calc.exe) >>")
+
+	result := validate.ValidatePDF(data, validate.PDFPolicy{Action: validate.PDFActionSanitize})
+
+	if !result.Valid {
+		t.Fatalf("expected sanitize-mode result to remain valid, findings: %+v", result.Findings)
+	}
+	if len(result.Findings) == 0 {
+		t.Fatalf("expected a warning finding to be recorded")
+	}
+}
+
+func TestValidatePDF_CleanFileHasNoFindings(t *testing.T) {
+	data := buildPDF("<< /Type /Page >>")
+
+	result := validate.ValidatePDF(data, validate.PDFPolicy{})
+
+	if !result.Valid || len(result.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", result.Findings)
+	}
+}
+
+func TestSanitizePDF_RemovesOffendingObjectsOnly(t *testing.T) {
+	data := buildPDF("<< /S /JavaScript /JS (app.alert('hi')) >>", "<< /Type /Page >>")
+
+	sanitized := validate.SanitizePDF(data)
+
+	if bytes.Contains(sanitized, []byte("/JavaScript")) {
+		t.Fatalf("expected JavaScript object to be stripped")
+	}
+	if !bytes.Contains(sanitized, []byte("/Type /Page")) {
+		t.Fatalf("expected unrelated object to be left untouched")
+	}
+}
@@ -0,0 +1,40 @@
+// Package validate implements content-specific checks applied to uploaded
+// files before they can be approved (archive policy, active-content
+// scanning, macro detection, and similar).
+package validate
+
+// Severity classifies how serious a Finding is.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityReject  Severity = "reject"
+)
+
+// Finding describes a single issue detected while validating a file.
+type Finding struct {
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+}
+
+// Result is the outcome of validating a file. Valid is false if any Finding
+// has SeverityReject.
+type Result struct {
+	Valid    bool      `json:"valid"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// addFinding appends a finding to the result, flipping Valid to false if the
+// finding is a rejection.
+func (r *Result) addFinding(f Finding) {
+	r.Findings = append(r.Findings, f)
+	if f.Severity == SeverityReject {
+		r.Valid = false
+	}
+}
+
+func newResult() *Result {
+	return &Result{Valid: true}
+}
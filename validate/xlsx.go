@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XLSXAction selects how an OOXML spreadsheet containing macros, external
+// links or DDE formulas is handled.
+type XLSXAction string
+
+const (
+	// XLSXActionReject fails validation outright when active content is found.
+	XLSXActionReject XLSXAction = "reject"
+	// XLSXActionFlag records a warning finding but leaves the file valid.
+	XLSXActionFlag XLSXAction = "flag"
+)
+
+// XLSXPolicy configures how XLSX/XLSM uploads are screened, since an
+// approved file is published straight to the public CDN.
+type XLSXPolicy struct {
+	// Action selects reject or flag behaviour. The zero value behaves as
+	// XLSXActionReject.
+	Action XLSXAction
+}
+
+// ValidateXLSX opens data as an OOXML zip container and inspects its parts
+// for a VBA macro project, external workbook links and DDE formulas.
+// Findings are SeverityReject under XLSXActionReject (the default) and
+// SeverityWarning under XLSXActionFlag.
+func ValidateXLSX(data []byte, policy XLSXPolicy) *Result {
+	result := newResult()
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		result.addFinding(Finding{
+			Code:     "xlsx.invalid_archive",
+			Message:  "file is not a valid OOXML spreadsheet",
+			Severity: SeverityReject,
+		})
+		return result
+	}
+
+	severity := SeverityReject
+	if policy.Action == XLSXActionFlag {
+		severity = SeverityWarning
+	}
+
+	for _, f := range reader.File {
+		switch {
+		case strings.EqualFold(f.Name, "xl/vbaProject.bin"):
+			result.addFinding(Finding{
+				Code:     "xlsx.vba_macro",
+				Message:  "workbook contains a VBA macro project",
+				Severity: severity,
+			})
+		case strings.HasPrefix(f.Name, "xl/externalLinks/") && strings.HasSuffix(f.Name, ".xml"):
+			result.addFinding(Finding{
+				Code:     "xlsx.external_link",
+				Message:  fmt.Sprintf("workbook contains an external link (%s)", f.Name),
+				Severity: severity,
+			})
+		case strings.HasPrefix(f.Name, "xl/worksheets/") && strings.HasSuffix(f.Name, ".xml"):
+			if containsDDEFormula(f) {
+				result.addFinding(Finding{
+					Code:     "xlsx.dde_formula",
+					Message:  fmt.Sprintf("worksheet %q contains a DDE formula", f.Name),
+					Severity: severity,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+func containsDDEFormula(f *zip.File) bool {
+	rc, err := f.Open()
+	if err != nil {
+		return false
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(bytes.ToUpper(data), []byte("DDE("))
+}
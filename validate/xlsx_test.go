@@ -0,0 +1,73 @@
+package validate_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateXLSX_RejectsVBAMacro(t *testing.T) {
+	data := buildXLSX(t, map[string]string{"xl/vbaProject.bin": "binary"})
+
+	result := validate.ValidateXLSX(data, validate.XLSXPolicy{})
+
+	if result.Valid {
+		t.Fatalf("expected invalid result for VBA macro")
+	}
+}
+
+func TestValidateXLSX_FlagModeWarnsInsteadOfRejecting(t *testing.T) {
+	data := buildXLSX(t, map[string]string{"xl/externalLinks/externalLink1.xml": "<externalLink/>"})
+
+	result := validate.ValidateXLSX(data, validate.XLSXPolicy{Action: validate.XLSXActionFlag})
+
+	if !result.Valid {
+		t.Fatalf("expected flag-mode result to remain valid, findings: %+v", result.Findings)
+	}
+	if len(result.Findings) == 0 {
+		t.Fatalf("expected a warning finding to be recorded")
+	}
+}
+
+func TestValidateXLSX_RejectsDDEFormula(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c><f>=DDE("cmd","/c calc";"__DdeLink")</f></c></row></sheetData></worksheet>`,
+	})
+
+	result := validate.ValidateXLSX(data, validate.XLSXPolicy{})
+
+	if result.Valid {
+		t.Fatalf("expected invalid result for DDE formula")
+	}
+}
+
+func TestValidateXLSX_CleanFileHasNoFindings(t *testing.T) {
+	data := buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": `<worksheet/>`})
+
+	result := validate.ValidateXLSX(data, validate.XLSXPolicy{})
+
+	if !result.Valid || len(result.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", result.Findings)
+	}
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ZipPolicy configures the per-entry rules enforced on an uploaded archive.
+type ZipPolicy struct {
+	// AllowedExtensions lists the lower-cased extensions (with leading dot)
+	// permitted for entries, e.g. []string{".csv", ".xlsx"}. Empty means any
+	// extension is allowed.
+	AllowedExtensions []string
+	// MaxEntrySize rejects any single entry larger than this many bytes.
+	// Zero means unlimited.
+	MaxEntrySize int64
+	// MaxEntries rejects archives with more than this many entries. Zero
+	// means unlimited.
+	MaxEntries int
+}
+
+// ZipEntry is a single file extracted from a validated archive.
+type ZipEntry struct {
+	Name string
+	Data []byte
+}
+
+// ValidateZip checks reader against policy, returning a Result describing
+// any violations. It does not extract entries; use ExtractZip for that once
+// a Result is Valid.
+func ValidateZip(reader *zip.Reader, policy ZipPolicy) *Result {
+	result := newResult()
+
+	if policy.MaxEntries > 0 && len(reader.File) > policy.MaxEntries {
+		result.addFinding(Finding{
+			Code:     "zip.too_many_entries",
+			Message:  fmt.Sprintf("archive contains %d entries, limit is %d", len(reader.File), policy.MaxEntries),
+			Severity: SeverityReject,
+		})
+	}
+
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		if strings.Contains(f.Name, "..") || filepath.IsAbs(f.Name) {
+			result.addFinding(Finding{
+				Code:     "zip.unsafe_path",
+				Message:  fmt.Sprintf("entry %q has an unsafe path", f.Name),
+				Severity: SeverityReject,
+			})
+			continue
+		}
+
+		if len(policy.AllowedExtensions) > 0 && !containsExt(policy.AllowedExtensions, f.Name) {
+			result.addFinding(Finding{
+				Code:     "zip.disallowed_extension",
+				Message:  fmt.Sprintf("entry %q has a disallowed extension", f.Name),
+				Severity: SeverityReject,
+			})
+		}
+
+		if policy.MaxEntrySize > 0 && int64(f.UncompressedSize64) > policy.MaxEntrySize {
+			result.addFinding(Finding{
+				Code:     "zip.entry_too_large",
+				Message:  fmt.Sprintf("entry %q is %d bytes, limit is %d", f.Name, f.UncompressedSize64, policy.MaxEntrySize),
+				Severity: SeverityReject,
+			})
+		}
+	}
+
+	return result
+}
+
+// ExtractZip reads every non-directory entry in reader into memory. Callers
+// should run ValidateZip first and only extract if the result is Valid.
+func ExtractZip(reader *zip.Reader) ([]ZipEntry, error) {
+	entries := make([]ZipEntry, 0, len(reader.File))
+
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open zip entry %q: %w", f.Name, err)
+		}
+
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zip entry %q: %w", f.Name, err)
+		}
+
+		entries = append(entries, ZipEntry{Name: f.Name, Data: data})
+	}
+
+	return entries, nil
+}
+
+func containsExt(allowed []string, name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, a := range allowed {
+		if strings.ToLower(a) == ext {
+			return true
+		}
+	}
+	return false
+}
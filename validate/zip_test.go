@@ -0,0 +1,65 @@
+package validate_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/ONSdigital/dis-imf-uploader/validate"
+)
+
+func buildZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestValidateZip_RejectsDisallowedExtension(t *testing.T) {
+	r := buildZip(t, map[string]string{"data.csv": "a,b\n1,2", "run.exe": "bad"})
+
+	result := validate.ValidateZip(r, validate.ZipPolicy{AllowedExtensions: []string{".csv"}})
+
+	if result.Valid {
+		t.Fatalf("expected invalid result")
+	}
+}
+
+func TestValidateZip_RejectsPathTraversal(t *testing.T) {
+	r := buildZip(t, map[string]string{"../../etc/passwd": "bad"})
+
+	result := validate.ValidateZip(r, validate.ZipPolicy{})
+
+	if result.Valid {
+		t.Fatalf("expected invalid result for path traversal entry")
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	r := buildZip(t, map[string]string{"data.csv": "a,b\n1,2"})
+
+	entries, err := validate.ExtractZip(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "data.csv" || string(entries[0].Data) != "a,b\n1,2" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}